@@ -3,12 +3,18 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
-	"time"
 
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/taskavsregistrar"
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/avstaskhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/config"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/membership"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/performer"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/taskfee"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/contracts"
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/server"
-	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"go.uber.org/zap"
 )
@@ -19,217 +25,214 @@ import (
 // to Executors configured to run the AVS Performer. Performers execute the work and
 // return the result to the Executor where the result is signed and return to the
 // Aggregator to place in the outbox once the signing threshold is met.
+//
+// The task logic lives in pkg/performer; this file only wires it to the
+// configuration and the Ponos server.
+
+// loadConfig builds the performer config from the file named by
+// PERFORMER_CONFIG, if any, and the environment, then checks it against the
+// Hourglass context file named by HOURGLASS_CONTEXT, if any
+func loadConfig() (config.Config, error) {
+	cfg := config.Default()
+	if path := os.Getenv("PERFORMER_CONFIG"); path != "" {
+		var err error
+		if cfg, err = config.Load(path); err != nil {
+			return config.Config{}, err
+		}
+	}
+
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return config.Config{}, err
+	}
 
-type TaskWorker struct {
-	logger        *zap.Logger
-	contractStore *contracts.ContractStore
-	l1Client      *ethclient.Client
-	l2Client      *ethclient.Client
+	var hc *config.Context
+	if path := os.Getenv("HOURGLASS_CONTEXT"); path != "" {
+		var err error
+		if hc, err = config.LoadContext(path); err != nil {
+			return config.Config{}, err
+		}
+		cfg.ApplyContext(hc)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return config.Config{}, err
+	}
+	if hc != nil {
+		if err := cfg.ValidateContext(hc); err != nil {
+			return config.Config{}, err
+		}
+	}
+	return cfg, nil
 }
 
-func NewTaskWorker(logger *zap.Logger) *TaskWorker {
+func NewTaskWorker(logger *zap.Logger, cfg config.Config) *performer.TaskWorker {
+	pc := cfg.Performer()
+
 	// Initialize contract store from environment variables
 	contractStore, err := contracts.NewContractStore()
 	if err != nil {
 		logger.Warn("Failed to load contract store", zap.Error(err))
 	}
 
-	// Initialize Ethereum clients if RPC URLs are provided
-	var l1Client, l2Client *ethclient.Client
-
-	if l1RpcUrl := os.Getenv("L1_RPC_URL"); l1RpcUrl != "" {
-		l1Client, err = ethclient.Dial(l1RpcUrl)
-		if err != nil {
-			logger.Error("Failed to connect to L1 RPC", zap.Error(err))
+	// The contract store entry wins over the configured hook
+	if contractStore != nil {
+		if c, err := contractStore.GetContract(config.HookContractName); err == nil {
+			pc.HookAddress = c.Address
 		}
 	}
 
-	if l2RpcUrl := os.Getenv("L2_RPC_URL"); l2RpcUrl != "" {
-		l2Client, err = ethclient.Dial(l2RpcUrl)
+	if pc.OperatorAddress == (common.Address{}) {
+		logger.Warn("No operator address configured, performer will never consider itself manager")
+	}
+
+	// Initialize the L2 reader if an RPC URL is configured
+	var (
+		reader performer.Reader
+		fees   performer.FeeSource
+	)
+	if cfg.Chains.L2.RPCURL != "" {
+		l2Client, err := dialL2(cfg)
 		if err != nil {
 			logger.Error("Failed to connect to L2 RPC", zap.Error(err))
+		} else {
+			reader = performer.NewRPCReader(l2Client)
+			fees = newFeeSource(logger, cfg, l2Client)
 		}
 	}
 
-	return &TaskWorker{
-		logger:        logger,
-		contractStore: contractStore,
-		l1Client:      l1Client,
-		l2Client:      l2Client,
+	w := performer.New(logger, reader, performer.SystemClock{}, pc)
+	if fees != nil {
+		w.WithFees(fees)
 	}
+	return w
 }
 
-func (tw *TaskWorker) ValidateTask(t *performerV1.TaskRequest) error {
-	tw.logger.Sugar().Infow("Validating task", zap.Any("task", t))
-	// No validation needed - operators act autonomously
-	return nil
-}
+// dialL2 connects to the configured L2 and checks it is the configured chain
+func dialL2(cfg config.Config) (*ethclient.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
 
-func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskResponse, error) {
-	tw.logger.Sugar().Infow("Handling task", zap.Any("task", t))
-
-	// ------------------------------------------------------------------------
-	// AuctionPool Autonomous Operator Strategy
-	// ------------------------------------------------------------------------
-	// This operator monitors the AuctionPool hook and makes autonomous decisions
-	// about bidding for pool management rights and optimizing fees.
-	//
-	// Key responsibilities:
-	// 1. Monitor current pool state (manager, rent, fee)
-	// 2. Estimate profitability from being manager
-	// 3. Submit competitive bids when profitable
-	// 4. If currently managing, optimize swap fees based on market conditions
-	// ------------------------------------------------------------------------
-
-	result := tw.executeStrategy()
-
-	return &performerV1.TaskResponse{
-		TaskId: t.TaskId,
-		Result: []byte(result),
-	}, nil
-}
+	l2Client, err := ethclient.DialContext(ctx, cfg.Chains.L2.RPCURL)
+	if err != nil {
+		return nil, err
+	}
 
-// executeStrategy runs the autonomous operator strategy
-func (tw *TaskWorker) executeStrategy() string {
-	// TODO: Implement actual strategy
-	// This would:
-	// 1. Call AuctionPoolHook to get pool state
-	// 2. Calculate expected profit (swap fees + arb opportunities)
-	// 3. If profitable, submit bid via AuctionPoolHook.submitBid()
-	// 4. If current manager, update fees via AuctionPoolHook.setSwapFee()
-
-	poolState := tw.getPoolState()
-	if poolState == nil {
-		return "no_action"
-	}
-
-	// Calculate if we should bid
-	expectedProfit := tw.estimateProfit()
-	profitableRent := expectedProfit * 0.8 // Bid 80% of expected profit
-
-	if profitableRent > poolState.currentRent {
-		tw.logger.Info("Profitable bid opportunity detected",
-			zap.Float64("expected_profit", expectedProfit),
-			zap.Float64("profitable_rent", profitableRent),
-			zap.Float64("current_rent", poolState.currentRent),
-		)
-		// TODO: Submit bid via hook contract
-		return "submit_bid"
-	}
-
-	// If we're the manager, optimize fees
-	if poolState.isManager {
-		optimalFee := tw.calculateOptimalFee()
-		if tw.shouldUpdateFee(poolState.currentFee, optimalFee) {
-			tw.logger.Info("Updating swap fee",
-				zap.Uint32("current_fee", poolState.currentFee),
-				zap.Uint32("optimal_fee", optimalFee),
-			)
-			// TODO: Update fee via hook contract
-			return "update_fee"
+	if want := cfg.Chains.L2.ChainID; want != 0 {
+		chainID, err := l2Client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get L2 chain ID: %w", err)
+		}
+		if chainID.Uint64() != want {
+			return nil, fmt.Errorf("L2 RPC serves chain %s, configured for %d", chainID, want)
 		}
 	}
-
-	return "no_action"
+	return l2Client, nil
 }
 
-type PoolState struct {
-	currentManager string
-	currentRent    float64
-	currentFee     uint32
-	managerDeposit float64
-	isManager      bool
-}
-
-func (tw *TaskWorker) getPoolState() *PoolState {
-	// TODO: Query AuctionPoolHook contract for current state
-	// For now, return mock data
-	return &PoolState{
-		currentManager: "0x0000000000000000000000000000000000000000",
-		currentRent:    0.001, // 0.001 ETH per block
-		currentFee:     3000,  // 0.3% in hundredths of bps
-		managerDeposit: 0.1,   // 0.1 ETH
-		isManager:      false,
+// newFeeSource reads task fees from the task hook when the performer is
+// configured to decline underpaid tasks
+func newFeeSource(logger *zap.Logger, cfg config.Config, l2Client *ethclient.Client) performer.FeeSource {
+	if !cfg.TaskFee.Decline {
+		return nil
 	}
-}
-
-func (tw *TaskWorker) estimateProfit() float64 {
-	// TODO: Estimate profit from swap fees + arbitrage opportunities
-	// This would analyze:
-	// - Recent swap volume
-	// - Price volatility (for arb opportunities)
-	// - Current fee tier
-	//
-	// Simplified estimation:
-	// Profit = (swap_volume * avg_fee) + (volatility * arb_multiplier)
-
-	swapVolumePerBlock := 1000.0 // $1000 per block (mock)
-	averageFee := 0.003          // 0.3%
-	swapFeeRevenue := swapVolumePerBlock * averageFee
-
-	volatility := 0.02 // 2% volatility (mock)
-	arbProfit := volatility * swapVolumePerBlock * 0.01
-
-	totalProfitPerBlock := swapFeeRevenue + arbProfit
 
-	// Convert to ETH (assuming $2000 ETH price)
-	profitInEth := totalProfitPerBlock / 2000.0
-
-	return profitInEth
-}
-
-func (tw *TaskWorker) calculateOptimalFee() uint32 {
-	// TODO: Calculate optimal fee based on market conditions
-	// Higher volatility = higher fee
-	// Higher volume = lower fee (to capture flow)
-
-	baseFee := uint32(3000) // 0.3%
-
-	// Adjust for volatility (mock)
-	volatility := 0.02 // 2%
-	volatilityAdjustment := uint32(volatility * 10000) // +200 bps
-
-	// Adjust for volume (mock)
-	// volumeAdjustment := uint32(-100) // -100 bps for high volume
-
-	optimalFee := baseFee + volatilityAdjustment
-
-	// Cap at 1% (10000 in hundredths of bps)
-	if optimalFee > 10000 {
-		optimalFee = 10000
+	caller, err := avstaskhook.NewAVSTaskHookCaller(cfg.TaskFee.Hook, l2Client)
+	if err != nil {
+		logger.Error("Failed to bind task hook", zap.Error(err))
+		return nil
 	}
 
-	// Floor at 0.05% (500)
-	if optimalFee < 500 {
-		optimalFee = 500
+	// AuctionPoolTaskHook charges the same for any operator set, so only
+	// the set ID is filled in
+	var set avstaskhook.OperatorSet
+	if cfg.Membership.OperatorSet != nil {
+		set.Id = *cfg.Membership.OperatorSet
 	}
-
-	return optimalFee
+	logger.Info("Declining tasks that do not cover their cost",
+		zap.Stringer("task_hook", cfg.TaskFee.Hook),
+		zap.Uint64("margin_bps", cfg.TaskFee.MarginBps),
+	)
+	return taskfee.NewHook(caller, set)
 }
 
-func (tw *TaskWorker) shouldUpdateFee(currentFee, optimalFee uint32) bool {
-	// Update if difference is > 0.1% (100 in hundredths of bps)
-	threshold := uint32(100)
-
-	diff := currentFee
-	if optimalFee > currentFee {
-		diff = optimalFee - currentFee
-	} else {
-		diff = currentFee - optimalFee
+// newMonitor tracks the operator's membership of the executor operator set
+// through the TaskAVSRegistrar on L1. Without the settings to check, the
+// monitor reports itself unconfigured and admits every task.
+func newMonitor(logger *zap.Logger, cfg config.Config) *membership.Monitor {
+	m := cfg.Membership
+
+	var (
+		registrar     membership.Registrar
+		operatorSetId uint32
+	)
+	switch {
+	case m.Registrar == (common.Address{}):
+		logger.Warn("No TaskAVSRegistrar configured, operator set membership will not be checked")
+	case m.OperatorSet == nil:
+		logger.Warn("No executor operator set configured, operator set membership will not be checked")
+	case cfg.OperatorAddress == (common.Address{}):
+		logger.Warn("No operator address configured, operator set membership will not be checked")
+	case cfg.Chains.L1.RPCURL == "":
+		logger.Warn("No L1 RPC configured, operator set membership will not be checked")
+	default:
+		operatorSetId = *m.OperatorSet
+		l1Client, err := ethclient.Dial(cfg.Chains.L1.RPCURL)
+		if err != nil {
+			logger.Error("Failed to connect to L1 RPC", zap.Error(err))
+			break
+		}
+		caller, err := taskavsregistrar.NewTaskAVSRegistrarCaller(m.Registrar, l1Client)
+		if err != nil {
+			logger.Error("Failed to bind TaskAVSRegistrar", zap.Error(err))
+			break
+		}
+		registrar = caller
 	}
 
-	return diff > threshold
+	return membership.NewMonitor(logger, registrar, cfg.OperatorAddress, operatorSetId, m.Interval)
 }
 
 func main() {
 	ctx := context.Background()
-	l, _ := zap.NewProduction()
 
-	w := NewTaskWorker(l)
+	cfg, err := loadConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	level, err := cfg.Level()
+	if err != nil {
+		panic(err)
+	}
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.Level = zap.NewAtomicLevelAt(level)
+	l, err := zapConfig.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	monitor := newMonitor(l, cfg)
+	checkCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	_ = monitor.Refresh(checkCtx)
+	cancel()
+	go monitor.Run(ctx)
+
+	if cfg.Membership.StatusPort != 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/status", monitor)
+		go func() {
+			addr := fmt.Sprintf(":%d", cfg.Membership.StatusPort)
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				l.Error("Status server stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	w := NewTaskWorker(l, cfg).WithGate(monitor)
 
 	pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
-		Port:    8080,
-		Timeout: 5 * time.Second,
+		Port:    cfg.Port,
+		Timeout: cfg.Timeout,
 	}, w, l)
 	if err != nil {
 		panic(fmt.Errorf("failed to create performer: %w", err))
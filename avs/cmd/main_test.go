@@ -1,38 +1,44 @@
 package main
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/config"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
 	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 )
 
 func Test_TaskRequestPayload(t *testing.T) {
-	// ------------------------------------------------------------------------
-	// Write your test cases here
-	// ------------------------------------------------------------------------
-
 	logger, err := zap.NewDevelopment()
 	if err != nil {
 		t.Errorf("Failed to create logger: %v", err)
 	}
 
-	taskWorker := NewTaskWorker(logger)
+	taskWorker := NewTaskWorker(logger, config.Default())
 
-	taskRequest := &performerV1.TaskRequest{
+	malformed := &performerV1.TaskRequest{
 		TaskId:  []byte("test-task-id"),
 		Payload: []byte("test-data"),
 	}
-
-	err = taskWorker.ValidateTask(taskRequest)
-	if err != nil {
-		t.Errorf("ValidateTask failed: %v", err)
+	if err := taskWorker.ValidateTask(malformed); !errors.Is(err, task.ErrMalformed) {
+		t.Errorf("expected malformed payload to be rejected, got %v", err)
+	}
+	if _, err := taskWorker.HandleTask(malformed); err == nil {
+		t.Errorf("expected HandleTask to fail on malformed payload")
 	}
 
-	resp, err := taskWorker.HandleTask(taskRequest)
+	unsupported, err := task.Encode(&task.Payload{
+		Type:           9,
+		PoolId:         common.HexToHash("0x01"),
+		ReferenceBlock: 1,
+	})
 	if err != nil {
-		t.Errorf("HandleTask failed: %v", err)
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if err := taskWorker.ValidateTask(&performerV1.TaskRequest{TaskId: []byte("test-task-id"), Payload: unsupported}); !errors.Is(err, task.ErrUnsupportedType) {
+		t.Errorf("expected unhandled task type to be rejected, got %v", err)
 	}
-
-	t.Logf("Response: %v", resp)
 }
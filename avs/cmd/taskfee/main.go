@@ -0,0 +1,149 @@
+// Command taskfee reports whether the fee the task hook charges covers what
+// answering each task type costs the performer. It reads the same config
+// file, environment and Hourglass context as the performer.
+//
+//	taskfee -config config/performer/devnet.yaml -context .hourglass/context/devnet.yaml
+//
+// It exits with status 1 if any task type is underpaid.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/avstaskhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/config"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/taskfee"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	var (
+		configPath  = flag.String("config", os.Getenv("PERFORMER_CONFIG"), "performer config file")
+		contextPath = flag.String("context", os.Getenv("HOURGLASS_CONTEXT"), "Hourglass context file")
+		rangeBlocks = flag.Uint64("range", 7200, "blocks covered by attest_rent and surveil_manager tasks")
+		margin      = flag.Int64("margin", -1, "required margin over cost in bps; defaults to taskFee.marginBps")
+		pool        = flag.String("pool", "", "pool ID to quote tasks for")
+	)
+	flag.Parse()
+
+	underpaid, err := run(*configPath, *contextPath, *rangeBlocks, *margin, common.HexToHash(*pool))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "taskfee:", err)
+		os.Exit(2)
+	}
+	if underpaid {
+		os.Exit(1)
+	}
+}
+
+func loadConfig(configPath, contextPath string) (config.Config, error) {
+	cfg := config.Default()
+	if configPath != "" {
+		var err error
+		if cfg, err = config.Load(configPath); err != nil {
+			return config.Config{}, err
+		}
+	}
+	if err := cfg.ApplyEnv(os.Getenv); err != nil {
+		return config.Config{}, err
+	}
+	if contextPath != "" {
+		hc, err := config.LoadContext(contextPath)
+		if err != nil {
+			return config.Config{}, err
+		}
+		cfg.ApplyContext(hc)
+	}
+	return cfg, nil
+}
+
+func run(configPath, contextPath string, rangeBlocks uint64, margin int64, poolId common.Hash) (bool, error) {
+	cfg, err := loadConfig(configPath, contextPath)
+	if err != nil {
+		return false, err
+	}
+	if cfg.Chains.L2.RPCURL == "" {
+		return false, fmt.Errorf("no L2 RPC configured")
+	}
+	if cfg.TaskFee.Hook == (common.Address{}) {
+		return false, fmt.Errorf("no task hook configured")
+	}
+	if margin < 0 {
+		margin = int64(cfg.TaskFee.MarginBps)
+	}
+	if poolId == (common.Hash{}) {
+		poolId = common.HexToHash("0x01")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, cfg.Chains.L2.RPCURL)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get L2 head: %w", err)
+	}
+	caller, err := avstaskhook.NewAVSTaskHookCaller(cfg.TaskFee.Hook, client)
+	if err != nil {
+		return false, err
+	}
+	var set avstaskhook.OperatorSet
+	if cfg.Membership.OperatorSet != nil {
+		set.Id = *cfg.Membership.OperatorSet
+	}
+	hook := taskfee.NewHook(caller, set)
+
+	from := uint64(1)
+	if head > rangeBlocks {
+		from = head - rangeBlocks + 1
+	}
+	bid, err := task.EncodePriceBidParams(task.PriceBidParams{RentPerBlock: common.Big1, Deposit: common.Big256})
+	if err != nil {
+		return false, err
+	}
+	payloads := []*task.Payload{
+		{Type: task.TypeEvaluatePool},
+		{Type: task.TypeRecommendFee},
+		{Type: task.TypeAttestRent, Params: task.EncodeRangeParams(from)},
+		{Type: task.TypePriceBid, Params: bid},
+		{Type: task.TypeSurveilManager, Params: task.EncodeRangeParams(from)},
+	}
+
+	out := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(out, "task\tcalls\tlog blocks\tcost (wei)\trequired (wei)\tfee (wei)\tcovered\t")
+
+	underpaid := false
+	for _, p := range payloads {
+		p.PoolId, p.ReferenceBlock = poolId, head
+
+		raw, err := task.Encode(p)
+		if err != nil {
+			return false, err
+		}
+		cost, err := taskfee.Estimate(p)
+		if err != nil {
+			return false, err
+		}
+		fee, err := hook.TaskFee(ctx, raw)
+		if err != nil {
+			return false, err
+		}
+
+		q := taskfee.NewQuote(p.Type, cost, cfg.TaskFee.Pricing, fee, uint64(margin))
+		underpaid = underpaid || !q.Covered()
+		fmt.Fprintf(out, "%s\t%d\t%d\t%s\t%s\t%s\t%t\t\n",
+			p.Type, cost.Calls, cost.LogBlocks, q.CostWei, q.Required(), q.Fee, q.Covered())
+	}
+	return underpaid, out.Flush()
+}
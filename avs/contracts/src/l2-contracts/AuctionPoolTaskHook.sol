@@ -3,6 +3,8 @@ pragma solidity ^0.8.27;
 
 import {IAVSTaskHook} from "@eigenlayer-contracts/src/contracts/interfaces/IAVSTaskHook.sol";
 import {ITaskMailboxTypes} from "@eigenlayer-contracts/src/contracts/interfaces/ITaskMailbox.sol";
+import {AuctionPoolTaskPayload} from "./AuctionPoolTaskPayload.sol";
+import {AuctionPoolTaskResult} from "./AuctionPoolTaskResult.sol";
 
 /**
  * @title AuctionPoolTaskHook
@@ -28,25 +30,27 @@ contract AuctionPoolTaskHook is IAVSTaskHook {
         owner = _owner;
     }
 
-    // Minimal validation - operators act autonomously
+    /// @notice Rejects tasks whose payload performers would refuse anyway
     function validatePreTaskCreation(
         address,
-        ITaskMailboxTypes.TaskParams memory
-    ) external pure override {
-        // No validation needed - operators decide independently
+        ITaskMailboxTypes.TaskParams memory taskParams
+    ) external view override {
+        AuctionPoolTaskPayload.validate(AuctionPoolTaskPayload.decode(taskParams.payload));
     }
 
     function handlePostTaskCreation(bytes32) external override {
         // No-op - operators monitor pool directly
     }
 
+    /// @notice Rejects results that are not a well-formed, self-consistent statement
+    /// @dev Operators still submit bids/fee updates directly to the pool hook
     function validatePreTaskResultSubmission(
         address,
         bytes32,
         bytes memory,
-        bytes memory
+        bytes memory result
     ) external pure override {
-        // Operators submit bids/fee updates directly to hook, not via tasks
+        AuctionPoolTaskResult.validate(AuctionPoolTaskResult.decode(result));
     }
 
     function handlePostTaskResultSubmission(address, bytes32) external override {
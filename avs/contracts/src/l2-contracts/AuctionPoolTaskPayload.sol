@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity ^0.8.27;
+
+/**
+ * @title AuctionPoolTaskPayload
+ * @notice Wire format of AuctionPool AVS task payloads
+ * @dev Must stay field-for-field identical to avs/pkg/task in Go
+ */
+library AuctionPoolTaskPayload {
+    uint8 internal constant VERSION = 1;
+
+    uint8 internal constant TASK_EVALUATE_POOL = 1;
+    uint8 internal constant TASK_RECOMMEND_FEE = 2;
+    uint8 internal constant TASK_ATTEST_RENT = 3;
+    uint8 internal constant TASK_PRICE_BID = 4;
+    uint8 internal constant TASK_SURVEIL_MANAGER = 5;
+
+    /// @notice Uniswap v4 PoolKey, with currencies as plain addresses
+    struct PoolKey {
+        address currency0;
+        address currency1;
+        uint24 fee;
+        int24 tickSpacing;
+        address hooks;
+    }
+
+    struct Payload {
+        uint8 version;
+        uint8 taskType;
+        bytes32 poolId;
+        PoolKey poolKey;
+        uint64 referenceBlock;
+        bytes params;
+    }
+
+    error UnsupportedVersion(uint8 version);
+    error UnsupportedTaskType(uint8 taskType);
+    error InvalidPool();
+    error InvalidReferenceBlock(uint64 referenceBlock);
+
+    function decode(bytes memory data) internal pure returns (Payload memory) {
+        return abi.decode(data, (Payload));
+    }
+
+    /// @notice Returns the pool the task refers to, derived from the key if needed
+    function pool(Payload memory p) internal pure returns (bytes32) {
+        if (p.poolId == bytes32(0)) {
+            return keccak256(abi.encode(p.poolKey));
+        }
+        return p.poolId;
+    }
+
+    /// @notice Checks the parts of a payload that can be verified on-chain
+    function validate(Payload memory p) internal view {
+        if (p.version != VERSION) revert UnsupportedVersion(p.version);
+        if (p.taskType < TASK_EVALUATE_POOL || p.taskType > TASK_SURVEIL_MANAGER) {
+            revert UnsupportedTaskType(p.taskType);
+        }
+
+        bool hasKey = p.poolKey.hooks != address(0) || p.poolKey.currency1 != address(0);
+        if (p.poolId == bytes32(0) && !hasKey) revert InvalidPool();
+        if (p.poolId != bytes32(0) && hasKey && keccak256(abi.encode(p.poolKey)) != p.poolId) {
+            revert InvalidPool();
+        }
+
+        if (p.referenceBlock == 0 || p.referenceBlock > block.number) {
+            revert InvalidReferenceBlock(p.referenceBlock);
+        }
+    }
+}
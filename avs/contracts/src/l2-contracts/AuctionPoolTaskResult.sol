@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity ^0.8.27;
+
+/**
+ * @title AuctionPoolTaskResult
+ * @notice Wire format of AuctionPool AVS task results
+ * @dev Must stay field-for-field identical to avs/pkg/task in Go
+ */
+library AuctionPoolTaskResult {
+    uint8 internal constant VERSION = 1;
+
+    uint8 internal constant ACTION_NONE = 0;
+    uint8 internal constant ACTION_SUBMIT_BID = 1;
+    uint8 internal constant ACTION_UPDATE_FEE = 2;
+    uint8 internal constant ACTION_BID_ACCEPTED = 3;
+    uint8 internal constant ACTION_BID_REJECTED = 4;
+    uint8 internal constant ACTION_ATTEST_MATCH = 5;
+    uint8 internal constant ACTION_ATTEST_MISMATCH = 6;
+    uint8 internal constant ACTION_CLEAN = 7;
+    uint8 internal constant ACTION_ABUSE_FLAGGED = 8;
+
+    /// @notice Hook state the decision was based on
+    struct Inputs {
+        address currentManager;
+        uint256 rentPerBlock;
+        uint256 managerDeposit;
+        uint24 currentFee;
+        address nextBidder;
+        uint256 nextRent;
+        uint256 expectedProfit;
+    }
+
+    struct Result {
+        uint8 version;
+        uint8 taskType;
+        uint8 action;
+        bytes32 poolId;
+        uint64 referenceBlock;
+        bytes32 blockHash;
+        bytes32 stateHash;
+        uint256 recommendedRent;
+        uint24 recommendedFee;
+        Inputs inputs;
+        bytes detail;
+    }
+
+    error UnsupportedVersion(uint8 version);
+    error StateHashMismatch(bytes32 stateHash);
+
+    function decode(bytes memory data) internal pure returns (Result memory) {
+        return abi.decode(data, (Result));
+    }
+
+    /// @notice Checks the version and that the state hash commits to the inputs
+    function validate(Result memory r) internal pure {
+        if (r.version != VERSION) revert UnsupportedVersion(r.version);
+        if (keccak256(abi.encode(r.inputs)) != r.stateHash) revert StateHashMismatch(r.stateHash);
+    }
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: BUSL-1.1
+pragma solidity ^0.8.27;
+
+import {Test} from "forge-std/Test.sol";
+
+import {AuctionPoolTaskPayload} from "@project/l2-contracts/AuctionPoolTaskPayload.sol";
+import {AuctionPoolTaskResult} from "@project/l2-contracts/AuctionPoolTaskResult.sol";
+
+/// @notice Decodes the golden vectors the Go encoder in avs/pkg/task writes,
+/// so a change on either side of the wire format fails here
+contract AuctionPoolTaskCodecTest is Test {
+    bytes32 constant POOL_ID = 0xa1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90;
+    uint64 constant REFERENCE_BLOCK = 19_000_000;
+
+    function golden(string memory name) internal view returns (bytes memory) {
+        string memory path = string.concat(vm.projectRoot(), "/../../pkg/task/testdata/", name);
+        return vm.parseBytes(string.concat("0x", vm.readLine(path)));
+    }
+
+    function testPayloadV1() public {
+        AuctionPoolTaskPayload.Payload memory p = AuctionPoolTaskPayload.decode(golden("payload_v1.hex"));
+
+        assertEq(p.version, AuctionPoolTaskPayload.VERSION);
+        assertEq(p.taskType, AuctionPoolTaskPayload.TASK_EVALUATE_POOL);
+        assertEq(p.poolId, POOL_ID);
+        assertEq(p.poolKey.currency0, address(0));
+        assertEq(p.poolKey.currency1, address(0));
+        assertEq(p.poolKey.fee, 0);
+        assertEq(p.poolKey.tickSpacing, 0);
+        assertEq(p.poolKey.hooks, address(0));
+        assertEq(p.referenceBlock, REFERENCE_BLOCK);
+        assertEq(p.params.length, 0);
+        assertEq(AuctionPoolTaskPayload.pool(p), POOL_ID);
+
+        vm.roll(REFERENCE_BLOCK);
+        AuctionPoolTaskPayload.validate(p);
+    }
+
+    function testResultV1() public view {
+        AuctionPoolTaskResult.Result memory r = AuctionPoolTaskResult.decode(golden("result_v1.hex"));
+
+        assertEq(r.version, AuctionPoolTaskResult.VERSION);
+        assertEq(r.taskType, AuctionPoolTaskPayload.TASK_EVALUATE_POOL);
+        assertEq(r.action, AuctionPoolTaskResult.ACTION_SUBMIT_BID);
+        assertEq(r.poolId, POOL_ID);
+        assertEq(r.referenceBlock, REFERENCE_BLOCK);
+        assertEq(r.blockHash, bytes32(0x1111111111111111111111111111111111111111111111111111111111111111));
+        assertEq(r.recommendedRent, 1_000_000_000_000_100);
+        assertEq(r.recommendedFee, 0);
+        assertEq(r.detail.length, 0);
+
+        assertEq(r.inputs.currentManager, 0x2222222222222222222222222222222222222222);
+        assertEq(r.inputs.rentPerBlock, 1_000_000_000_000_000);
+        assertEq(r.inputs.managerDeposit, 100_000_000_000_000_000);
+        assertEq(r.inputs.currentFee, 3000);
+        assertEq(r.inputs.nextBidder, address(0));
+        assertEq(r.inputs.nextRent, 0);
+        assertEq(r.inputs.expectedProfit, 1_600_000_000_000_000);
+
+        // The state hash commits to the inputs as Go hashes them
+        assertEq(r.stateHash, bytes32(golden("inputs_v1_hash.hex")));
+        AuctionPoolTaskResult.validate(r);
+    }
+}
@@ -7,6 +7,7 @@ require (
 	github.com/Layr-Labs/protocol-apis v1.17.0
 	github.com/ethereum/go-ethereum v1.15.11
 	go.uber.org/zap v1.27.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,114 @@
+package accounting
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/auctionpoolhook"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Backend is what the reader needs from an L2 client
+type Backend interface {
+	bind.ContractCaller
+	bind.BlockHashContractCaller
+	ethereum.LogFilterer
+}
+
+// ReadState loads the accounting state of poolId as of the block with hash
+// block, which a reorg cannot swap for another
+func ReadState(ctx context.Context, backend Backend, hook common.Address, poolId common.Hash, block common.Hash) (State, error) {
+	caller, err := auctionpoolhook.NewAuctionPoolHookCaller(hook, backend)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to bind AuctionPoolHook: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockHash: block}
+
+	auction, err := caller.PoolAuctions(opts, poolId)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to get pool auction at %s: %w", block.Hex(), err)
+	}
+	accumulated, err := caller.RentPerShareAccumulated(opts, poolId)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to get rent per share at %s: %w", block.Hex(), err)
+	}
+	shares, err := caller.TotalShares(opts, poolId)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to get total shares at %s: %w", block.Hex(), err)
+	}
+
+	return State{
+		Accumulated:   accumulated,
+		TotalShares:   shares,
+		TotalRentPaid: auction.TotalRentPaid,
+		Manager:       auction.CurrentManager,
+		RentPerBlock:  auction.RentPerBlock,
+		Deposit:       auction.ManagerDeposit,
+		LastRentBlock: auction.LastRentBlock.Uint64(),
+	}, nil
+}
+
+// ReadEvents loads the accounting events of poolId in [from, to]
+func ReadEvents(ctx context.Context, backend Backend, hook common.Address, poolId common.Hash, from, to uint64) ([]Event, error) {
+	filterer, err := auctionpoolhook.NewAuctionPoolHookFilterer(hook, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind AuctionPoolHook: %w", err)
+	}
+	parsed, err := auctionpoolhook.AuctionPoolHookMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{hook},
+		Topics: [][]common.Hash{{
+			parsed.Events["RentCollected"].ID,
+			parsed.Events["ManagerChanged"].ID,
+			parsed.Events["LiquidityUpdated"].ID,
+		}, {poolId}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter hook events: %w", err)
+	}
+
+	events := make([]Event, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		ev := Event{Block: log.BlockNumber, LogIndex: log.Index}
+
+		switch log.Topics[0] {
+		case parsed.Events["RentCollected"].ID:
+			rc, err := filterer.ParseRentCollected(log)
+			if err != nil {
+				return nil, err
+			}
+			ev.Kind, ev.Amount = RentCollected, rc.Amount
+
+		case parsed.Events["ManagerChanged"].ID:
+			mc, err := filterer.ParseManagerChanged(log)
+			if err != nil {
+				return nil, err
+			}
+			ev.Kind, ev.NewManager, ev.Rent = ManagerChanged, mc.NewManager, mc.RentPerBlock
+
+		case parsed.Events["LiquidityUpdated"].ID:
+			lu, err := filterer.ParseLiquidityUpdated(log)
+			if err != nil {
+				return nil, err
+			}
+			ev.Kind, ev.Amount = LiquidityRemoved, lu.Shares
+			if lu.IsAddition {
+				ev.Kind = LiquidityAdded
+			}
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
@@ -0,0 +1,162 @@
+// Package accounting independently recomputes AuctionPoolHook's rent
+// accounting from its events, so the AVS can attest whether the hook's
+// rentPerShareAccumulated is what LPs are owed.
+package accounting
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/auctionpoolhook"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// precision mirrors the 1e18 scaling of rentPerShareAccumulated
+var precision = big.NewInt(1e18)
+
+// State is the slice of hook storage the replay starts from and ends at
+type State struct {
+	Accumulated   *big.Int // rentPerShareAccumulated
+	TotalShares   *big.Int
+	TotalRentPaid *big.Int
+	Manager       common.Address
+	RentPerBlock  *big.Int
+	Deposit       *big.Int
+	LastRentBlock uint64
+}
+
+func (s State) clone() State {
+	c := s
+	c.Accumulated = new(big.Int).Set(s.Accumulated)
+	c.TotalShares = new(big.Int).Set(s.TotalShares)
+	c.TotalRentPaid = new(big.Int).Set(s.TotalRentPaid)
+	c.RentPerBlock = new(big.Int).Set(s.RentPerBlock)
+	c.Deposit = new(big.Int).Set(s.Deposit)
+	return c
+}
+
+// EventKind identifies the hook events that move rent accounting
+type EventKind uint8
+
+const (
+	RentCollected EventKind = iota + 1
+	ManagerChanged
+	LiquidityAdded
+	LiquidityRemoved
+)
+
+// Event is one accounting-relevant hook event
+type Event struct {
+	Kind     EventKind
+	Block    uint64
+	LogIndex uint
+
+	Amount     *big.Int       // RentCollected amount, or liquidity shares
+	NewManager common.Address // ManagerChanged
+	Rent       *big.Int       // ManagerChanged rentPerBlock
+}
+
+// Outcome is what the replay expects the hook's state to be
+type Outcome struct {
+	Expected State
+
+	// Collected is the sum of RentCollected events
+	Collected *big.Int
+	// Distributed includes the final rent settled on each manager change,
+	// which the hook distributes without a RentCollected event
+	Distributed *big.Int
+	// Undistributed is rent paid while no LP shares existed; the hook keeps
+	// it but credits nobody
+	Undistributed  *big.Int
+	ManagerChanges uint32
+}
+
+// Replay applies events in chain order to start, mirroring _collectRent,
+// _updateAuction and _distributeRent. bids is the pool's bid history, used
+// to find the deposit each new manager starts with.
+func Replay(start State, events []Event, bids []auctionpoolhook.AuctionPoolHookBid) Outcome {
+	events = append([]Event(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Block != events[j].Block {
+			return events[i].Block < events[j].Block
+		}
+		return events[i].LogIndex < events[j].LogIndex
+	})
+
+	st := start.clone()
+	out := Outcome{
+		Collected:     new(big.Int),
+		Distributed:   new(big.Int),
+		Undistributed: new(big.Int),
+	}
+
+	distribute := func(amount *big.Int) {
+		out.Distributed.Add(out.Distributed, amount)
+		st.TotalRentPaid.Add(st.TotalRentPaid, amount)
+		if st.TotalShares.Sign() == 0 {
+			out.Undistributed.Add(out.Undistributed, amount)
+			return
+		}
+		perShare := new(big.Int).Mul(amount, precision)
+		st.Accumulated.Add(st.Accumulated, perShare.Div(perShare, st.TotalShares))
+	}
+
+	for _, ev := range events {
+		switch ev.Kind {
+		case RentCollected:
+			out.Collected.Add(out.Collected, ev.Amount)
+			st.Deposit.Sub(st.Deposit, ev.Amount)
+			st.LastRentBlock = ev.Block
+			distribute(ev.Amount)
+
+		case ManagerChanged:
+			out.ManagerChanges++
+			if st.Manager != (common.Address{}) && st.Deposit.Sign() > 0 && ev.Block > st.LastRentBlock {
+				owed := new(big.Int).SetUint64(ev.Block - st.LastRentBlock)
+				owed.Mul(owed, st.RentPerBlock)
+				if owed.Cmp(st.Deposit) > 0 {
+					owed.Set(st.Deposit)
+				}
+				distribute(owed)
+			}
+			// Whatever is left of the old deposit is refunded, not distributed
+			st.Manager = ev.NewManager
+			st.RentPerBlock = new(big.Int).Set(ev.Rent)
+			st.Deposit = depositOf(bids, ev.NewManager, ev.Rent, ev.Block)
+			st.LastRentBlock = ev.Block
+
+		case LiquidityAdded:
+			st.TotalShares.Add(st.TotalShares, ev.Amount)
+
+		case LiquidityRemoved:
+			st.TotalShares.Sub(st.TotalShares, ev.Amount)
+		}
+	}
+
+	out.Expected = st
+	return out
+}
+
+// depositOf finds the deposit of the bid that made manager the manager: the
+// latest matching bid activated by block
+func depositOf(bids []auctionpoolhook.AuctionPoolHookBid, manager common.Address, rent *big.Int, block uint64) *big.Int {
+	if manager == (common.Address{}) {
+		return new(big.Int)
+	}
+	for i := len(bids) - 1; i >= 0; i-- {
+		b := bids[i]
+		if b.Bidder == manager && b.RentPerBlock.Cmp(rent) == 0 && b.ActivationBlock.Uint64() <= block {
+			return new(big.Int).Set(b.Deposit)
+		}
+	}
+	return new(big.Int)
+}
+
+// Matches reports whether the on-chain state agrees with the replay on
+// every field the replay can predict
+func (o Outcome) Matches(onChain State) bool {
+	e := o.Expected
+	return e.Accumulated.Cmp(onChain.Accumulated) == 0 &&
+		e.TotalShares.Cmp(onChain.TotalShares) == 0 &&
+		e.TotalRentPaid.Cmp(onChain.TotalRentPaid) == 0
+}
@@ -0,0 +1,227 @@
+// Package config loads the performer's settings from an optional YAML file
+// and environment overrides, and checks them against the Hourglass context
+// the AVS is deployed with.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/performer"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/strategy"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/taskfee"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalid is wrapped by every validation failure
+var ErrInvalid = errors.New("invalid performer config")
+
+// Chain is one chain the performer reads
+type Chain struct {
+	ChainID uint64 `yaml:"chainId"`
+	RPCURL  string `yaml:"rpcUrl"`
+}
+
+// Task holds the task admission settings
+type Task struct {
+	MaxAgeBlocks uint64        `yaml:"maxAgeBlocks"`
+	MaxHeadLag   time.Duration `yaml:"maxHeadLag"`
+}
+
+// Membership configures the operator set membership check against the
+// TaskAVSRegistrar on L1
+type Membership struct {
+	Registrar common.Address `yaml:"registrar"`
+	// OperatorSet is the executor operator set; nil until configured or
+	// taken from the Hourglass context
+	OperatorSet *uint32       `yaml:"operatorSetId"`
+	Interval    time.Duration `yaml:"interval"`
+	// StatusPort serves the membership status endpoint; zero disables it
+	StatusPort int `yaml:"statusPort"`
+}
+
+// TaskFee configures the check of what the task hook charges per task
+// against what answering it costs
+type TaskFee struct {
+	// Hook is the task hook on L2 the task mailbox asks for fees
+	Hook common.Address `yaml:"hook"`
+	// Decline refuses tasks whose fee is below cost plus MarginBps
+	Decline   bool            `yaml:"decline"`
+	MarginBps uint64          `yaml:"marginBps"`
+	Pricing   taskfee.Pricing `yaml:"pricing"`
+}
+
+type Config struct {
+	Port     int           `yaml:"port"`
+	Timeout  time.Duration `yaml:"timeout"`
+	LogLevel string        `yaml:"logLevel"`
+
+	OperatorAddress common.Address `yaml:"operatorAddress"`
+
+	Chains struct {
+		L1 Chain `yaml:"l1"`
+		L2 Chain `yaml:"l2"`
+	} `yaml:"chains"`
+
+	// Hooks maps chain ID to the AuctionPoolHook deployed there
+	Hooks map[uint64]common.Address `yaml:"hooks"`
+
+	Task       Task                  `yaml:"task"`
+	Strategy   strategy.ProfitParams `yaml:"strategy"`
+	Membership Membership            `yaml:"membership"`
+	TaskFee    TaskFee               `yaml:"taskFee"`
+}
+
+// Default returns the settings the performer used before it was
+// configurable
+func Default() Config {
+	p := performer.DefaultConfig()
+
+	return Config{
+		Port:     8080,
+		Timeout:  p.Timeout,
+		LogLevel: "info",
+		Hooks:    map[uint64]common.Address{},
+		Task:     Task{MaxAgeBlocks: p.MaxTaskAge, MaxHeadLag: p.MaxHeadLag},
+		Strategy: p.Strategy,
+		Membership: Membership{
+			Interval:   5 * time.Minute,
+			StatusPort: 8090,
+		},
+		TaskFee: TaskFee{Pricing: p.Pricing},
+	}
+}
+
+// Load reads a YAML config over the defaults. Unknown keys are an error so
+// a typo can't silently fall back to a default.
+func Load(path string) (Config, error) {
+	c := Default()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	if c.Hooks == nil {
+		c.Hooks = map[uint64]common.Address{}
+	}
+	return c, nil
+}
+
+// ApplyEnv overrides the config from the environment the Hourglass
+// executor starts the performer with
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	var err error
+	set := func(key string, apply func(string) error) {
+		if value := getenv(key); value != "" && err == nil {
+			if e := apply(value); e != nil {
+				err = fmt.Errorf("%w: %s=%q: %v", ErrInvalid, key, value, e)
+			}
+		}
+	}
+
+	set("PERFORMER_PORT", func(v string) (e error) { c.Port, e = strconv.Atoi(v); return })
+	set("PERFORMER_TIMEOUT", func(v string) (e error) { c.Timeout, e = time.ParseDuration(v); return })
+	set("LOG_LEVEL", func(v string) error { c.LogLevel = v; return nil })
+	set("OPERATOR_ADDRESS", func(v string) error { return c.OperatorAddress.UnmarshalText([]byte(v)) })
+	set("L1_RPC_URL", func(v string) error { c.Chains.L1.RPCURL = v; return nil })
+	set("L2_RPC_URL", func(v string) error { c.Chains.L2.RPCURL = v; return nil })
+	set("L1_CHAIN_ID", func(v string) (e error) { c.Chains.L1.ChainID, e = strconv.ParseUint(v, 10, 64); return })
+	set("L2_CHAIN_ID", func(v string) (e error) { c.Chains.L2.ChainID, e = strconv.ParseUint(v, 10, 64); return })
+	set("TASK_MAX_AGE_BLOCKS", func(v string) (e error) { c.Task.MaxAgeBlocks, e = strconv.ParseUint(v, 10, 64); return })
+	set("TASK_MAX_HEAD_LAG", func(v string) (e error) { c.Task.MaxHeadLag, e = time.ParseDuration(v); return })
+	set("TASK_AVS_REGISTRAR", func(v string) error { return c.Membership.Registrar.UnmarshalText([]byte(v)) })
+	set("EXECUTOR_OPERATOR_SET_ID", func(v string) error {
+		id, err := strconv.ParseUint(v, 10, 32)
+		set := uint32(id)
+		c.Membership.OperatorSet = &set
+		return err
+	})
+	set("MEMBERSHIP_INTERVAL", func(v string) (e error) { c.Membership.Interval, e = time.ParseDuration(v); return })
+	set("STATUS_PORT", func(v string) (e error) { c.Membership.StatusPort, e = strconv.Atoi(v); return })
+	set("AVS_TASK_HOOK", func(v string) error { return c.TaskFee.Hook.UnmarshalText([]byte(v)) })
+	set("TASK_FEE_DECLINE", func(v string) (e error) { c.TaskFee.Decline, e = strconv.ParseBool(v); return })
+	set("TASK_FEE_MARGIN_BPS", func(v string) (e error) { c.TaskFee.MarginBps, e = strconv.ParseUint(v, 10, 64); return })
+
+	// HOOK_ADDRESS is the hook on the L2 chain, so it is applied after
+	// L2_CHAIN_ID
+	set("HOOK_ADDRESS", func(v string) error {
+		var hook common.Address
+		if err := hook.UnmarshalText([]byte(v)); err != nil {
+			return err
+		}
+		c.Hooks[c.Chains.L2.ChainID] = hook
+		return nil
+	})
+	return err
+}
+
+// Hook is the AuctionPoolHook on the configured L2 chain
+func (c *Config) Hook() common.Address {
+	return c.Hooks[c.Chains.L2.ChainID]
+}
+
+// Level is the configured log level
+func (c *Config) Level() (zapcore.Level, error) {
+	return zapcore.ParseLevel(c.LogLevel)
+}
+
+// Validate checks the config on its own
+func (c *Config) Validate() error {
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("%w: port %d out of range", ErrInvalid, c.Port)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("%w: timeout must be positive", ErrInvalid)
+	}
+	if _, err := c.Level(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if c.Task.MaxAgeBlocks == 0 {
+		return fmt.Errorf("%w: task.maxAgeBlocks must be positive", ErrInvalid)
+	}
+	if c.Task.MaxHeadLag < 0 {
+		return fmt.Errorf("%w: task.maxHeadLag must not be negative", ErrInvalid)
+	}
+	if c.Membership.Interval <= 0 {
+		return fmt.Errorf("%w: membership.interval must be positive", ErrInvalid)
+	}
+	if p := c.Membership.StatusPort; p < 0 || p > 65535 || p == c.Port {
+		return fmt.Errorf("%w: membership.statusPort %d out of range or equal to port", ErrInvalid, p)
+	}
+	if c.TaskFee.Decline && c.TaskFee.Hook == (common.Address{}) {
+		return fmt.Errorf("%w: taskFee.decline needs the task hook address", ErrInvalid)
+	}
+	if c.Strategy.ETHPriceUSD == 0 {
+		return fmt.Errorf("%w: strategy.ethPriceUsd must be positive", ErrInvalid)
+	}
+	if c.Strategy.BidShareBps > 10000 {
+		return fmt.Errorf("%w: strategy.bidShareBps %d exceeds 10000", ErrInvalid, c.Strategy.BidShareBps)
+	}
+	return nil
+}
+
+// Performer is the worker config this file describes
+func (c *Config) Performer() performer.Config {
+	return performer.Config{
+		HookAddress:     c.Hook(),
+		OperatorAddress: c.OperatorAddress,
+		MaxTaskAge:      c.Task.MaxAgeBlocks,
+		MaxHeadLag:      c.Task.MaxHeadLag,
+		Timeout:         c.Timeout,
+		Strategy:        c.Strategy,
+		FeeMarginBps:    c.TaskFee.MarginBps,
+		Pricing:         c.TaskFee.Pricing,
+	}
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	executor = common.HexToAddress("0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65")
+	hook     = common.HexToAddress("0x00000000000000000000000000000000000000c0")
+)
+
+// TestNetworks checks every shipped performer config loads and agrees with
+// the Hourglass context of the same network
+func TestNetworks(t *testing.T) {
+	for _, network := range []string{"devnet", "testnet", "mainnet"} {
+		t.Run(network, func(t *testing.T) {
+			cfg, err := Load(filepath.Join("..", "..", "config", "performer", network+".yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			hc, err := LoadContext(filepath.Join("..", "..", ".hourglass", "context", network+".yaml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cfg.ApplyContext(hc)
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate() = %v", err)
+			}
+			if err := cfg.ValidateContext(hc); err != nil {
+				t.Errorf("ValidateContext() = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "performer.yaml")
+	write := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("port: 9000\nhooks:\n  31338: \"0x00000000000000000000000000000000000000c0\"\nchains:\n  l2:\n    chainId: 31338\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 9000 || cfg.Hook() != hook {
+		t.Errorf("file settings not applied: %+v", cfg)
+	}
+	if cfg.Timeout != 5*time.Second || cfg.Task.MaxAgeBlocks != 50 || cfg.Strategy.BidShareBps != 8000 {
+		t.Errorf("defaults not kept: %+v", cfg)
+	}
+
+	write("prot: 9000\n")
+	if _, err := Load(path); err == nil {
+		t.Errorf("expected an unknown key to be rejected")
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"PERFORMER_PORT":      "9001",
+		"PERFORMER_TIMEOUT":   "3s",
+		"LOG_LEVEL":           "warn",
+		"OPERATOR_ADDRESS":    executor.Hex(),
+		"L2_RPC_URL":          "http://l2:8545",
+		"L2_CHAIN_ID":         "31338",
+		"HOOK_ADDRESS":        hook.Hex(),
+		"TASK_MAX_AGE_BLOCKS": "10",
+		"TASK_MAX_HEAD_LAG":   "1m",
+
+		"EXECUTOR_OPERATOR_SET_ID": "1",
+		"STATUS_PORT":              "0",
+		"AVS_TASK_HOOK":            "0x00000000000000000000000000000000000000d0",
+		"TASK_FEE_DECLINE":         "true",
+		"TASK_FEE_MARGIN_BPS":      "2500",
+	}
+
+	cfg := Default()
+	if err := cfg.ApplyEnv(func(key string) string { return env[key] }); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 9001 || cfg.Timeout != 3*time.Second || cfg.LogLevel != "warn" {
+		t.Errorf("server settings not applied: %+v", cfg)
+	}
+	if cfg.OperatorAddress != executor || cfg.Chains.L2.RPCURL != "http://l2:8545" || cfg.Hooks[31338] != hook {
+		t.Errorf("chain settings not applied: %+v", cfg)
+	}
+	if set := cfg.Membership.OperatorSet; set == nil || *set != 1 || cfg.Membership.StatusPort != 0 {
+		t.Errorf("membership settings not applied: %+v", cfg.Membership)
+	}
+	if f := cfg.TaskFee; f.Hook != common.HexToAddress("0xd0") || !f.Decline || f.MarginBps != 2500 {
+		t.Errorf("task fee settings not applied: %+v", f)
+	}
+	if p := cfg.Performer(); p.HookAddress != hook || p.MaxTaskAge != 10 || p.MaxHeadLag != time.Minute || p.FeeMarginBps != 2500 {
+		t.Errorf("performer config = %+v", p)
+	}
+
+	for key, value := range map[string]string{
+		"PERFORMER_PORT":   "eighty",
+		"OPERATOR_ADDRESS": "0x15d3",
+		"HOOK_ADDRESS":     "hook",
+
+		"EXECUTOR_OPERATOR_SET_ID": "-1",
+		"TASK_FEE_DECLINE":         "sometimes",
+	} {
+		cfg := Default()
+		if err := cfg.ApplyEnv(func(k string) string { return map[string]string{key: value}[k] }); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s=%q: expected ErrInvalid, got %v", key, value, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		ok     bool
+	}{
+		{"defaults", func(*Config) {}, true},
+		{"port zero", func(c *Config) { c.Port = 0 }, false},
+		{"port too high", func(c *Config) { c.Port = 70000 }, false},
+		{"no timeout", func(c *Config) { c.Timeout = 0 }, false},
+		{"bad log level", func(c *Config) { c.LogLevel = "loud" }, false},
+		{"no task age", func(c *Config) { c.Task.MaxAgeBlocks = 0 }, false},
+		{"negative head lag", func(c *Config) { c.Task.MaxHeadLag = -time.Second }, false},
+		{"no eth price", func(c *Config) { c.Strategy.ETHPriceUSD = 0 }, false},
+		{"bid over profit", func(c *Config) { c.Strategy.BidShareBps = 10001 }, false},
+		{"no membership interval", func(c *Config) { c.Membership.Interval = 0 }, false},
+		{"status port clash", func(c *Config) { c.Membership.StatusPort = c.Port }, false},
+		{"status disabled", func(c *Config) { c.Membership.StatusPort = 0 }, true},
+		{"decline without task hook", func(c *Config) { c.TaskFee.Decline = true }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.ok != (err == nil) || (err != nil && !errors.Is(err, ErrInvalid)) {
+				t.Errorf("Validate() = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func deployedContext(t *testing.T) *Context {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "context.yaml")
+	data := `
+operatorSets:
+  - id: 1
+    curve_type: "ECDSA"
+executor:
+  operatorSetId: 1
+  operators:
+    - address: "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+      socket: "executor:9090"
+mailbox:
+  taskSla: 10
+context:
+  chains:
+    l1: {chain_id: 31337, rpc_url: "http://l1:8545"}
+    l2: {chain_id: 31338, rpc_url: "http://l2:8545"}
+  deployed_l1_contracts:
+    - name: taskAVSRegistrar
+      address: "0x00000000000000000000000000000000000000a1"
+  deployed_l2_contracts:
+    - name: AuctionPoolHook
+      address: "0x00000000000000000000000000000000000000c0"
+    - name: avsTaskHook
+      address: "0x00000000000000000000000000000000000000d0"
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hc, err := LoadContext(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hc
+}
+
+func TestContext(t *testing.T) {
+	hc := deployedContext(t)
+
+	cfg := Default()
+	cfg.ApplyContext(hc)
+	if cfg.Chains.L2.ChainID != 31338 || cfg.Chains.L2.RPCURL != "http://l2:8545" || cfg.Hook() != hook {
+		t.Errorf("context not applied: %+v", cfg)
+	}
+	if cfg.Membership.Registrar != common.HexToAddress("0xa1") || cfg.Membership.OperatorSet == nil || *cfg.Membership.OperatorSet != 1 {
+		t.Errorf("membership not applied: %+v", cfg.Membership)
+	}
+	if cfg.TaskFee.Hook != common.HexToAddress("0xd0") {
+		t.Errorf("task hook not applied: %+v", cfg.TaskFee)
+	}
+
+	// A hook set from the environment before the chain was known moves to it
+	cfg = Default()
+	cfg.Hooks[0] = hook
+	cfg.ApplyContext(hc)
+	if _, ok := cfg.Hooks[0]; ok || cfg.Hook() != hook {
+		t.Errorf("unkeyed hook not moved to L2 chain: %+v", cfg.Hooks)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config, *Context)
+		ok     bool
+	}{
+		{"consistent", func(*Config, *Context) {}, true},
+		{"executor operator", func(c *Config, _ *Context) { c.OperatorAddress = executor }, true},
+		{"unknown operator", func(c *Config, _ *Context) { c.OperatorAddress = common.HexToAddress("0x0b") }, false},
+		{"timeout at SLA", func(c *Config, _ *Context) { c.Timeout = 10 * time.Second }, false},
+		{"wrong l2", func(c *Config, _ *Context) { c.Chains.L2.ChainID = 8453 }, false},
+		{"wrong hook", func(c *Config, _ *Context) { c.Hooks[31338] = common.HexToAddress("0xc1") }, false},
+		{"undeclared operator set", func(_ *Config, hc *Context) { hc.Executor.OperatorSetID = 2 }, false},
+		{"wrong registrar", func(c *Config, _ *Context) { c.Membership.Registrar = common.HexToAddress("0xa2") }, false},
+		{"wrong task hook", func(c *Config, _ *Context) { c.TaskFee.Hook = common.HexToAddress("0xd1") }, false},
+		{"wrong operator set", func(c *Config, _ *Context) { set := uint32(0); c.Membership.OperatorSet = &set }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hc := deployedContext(t)
+			cfg := Default()
+			cfg.ApplyContext(hc)
+			tt.modify(&cfg, hc)
+
+			err := cfg.ValidateContext(hc)
+			if tt.ok != (err == nil) || (err != nil && !errors.Is(err, ErrInvalid)) {
+				t.Errorf("ValidateContext() = %v, want ok=%v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// HookContractName is the name the hook is deployed under in the
+	// Hourglass context and registered under in the Ponos contract store
+	HookContractName = "AuctionPoolHook"
+
+	// RegistrarContractName is the L1 TaskAVSRegistrar's name in the context
+	RegistrarContractName = "taskAVSRegistrar"
+
+	// TaskHookContractName is the L2 task hook's name in the context
+	TaskHookContractName = "avsTaskHook"
+)
+
+// Context is the subset of an Hourglass context file (.hourglass/context/
+// <network>.yaml) the performer depends on. The devkit adds a "context"
+// section with chains and deployed contracts once the AVS is deployed.
+type Context struct {
+	OperatorSets []struct {
+		ID        uint32 `yaml:"id"`
+		CurveType string `yaml:"curve_type"`
+	} `yaml:"operatorSets"`
+
+	Executor struct {
+		OperatorSetID uint32 `yaml:"operatorSetId"`
+		Operators     []struct {
+			Address common.Address `yaml:"address"`
+			Socket  string         `yaml:"socket"`
+		} `yaml:"operators"`
+	} `yaml:"executor"`
+
+	Mailbox struct {
+		TaskSLA uint64 `yaml:"taskSla"` // seconds
+	} `yaml:"mailbox"`
+
+	Context struct {
+		Chains struct {
+			L1 ContextChain `yaml:"l1"`
+			L2 ContextChain `yaml:"l2"`
+		} `yaml:"chains"`
+		DeployedL1Contracts []ContextContract `yaml:"deployed_l1_contracts"`
+		DeployedL2Contracts []ContextContract `yaml:"deployed_l2_contracts"`
+	} `yaml:"context"`
+}
+
+type ContextContract struct {
+	Name    string         `yaml:"name"`
+	Address common.Address `yaml:"address"`
+}
+
+type ContextChain struct {
+	ChainID uint64 `yaml:"chain_id"`
+	RPCURL  string `yaml:"rpc_url"`
+}
+
+// LoadContext reads an Hourglass context file
+func LoadContext(path string) (*Context, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context: %w", err)
+	}
+
+	var hc Context
+	if err := yaml.Unmarshal(data, &hc); err != nil {
+		return nil, fmt.Errorf("failed to parse context %s: %w", path, err)
+	}
+	return &hc, nil
+}
+
+// deployed finds a contract by name, returning the zero address if the
+// context didn't deploy it
+func deployed(contracts []ContextContract, name string) common.Address {
+	for _, c := range contracts {
+		if strings.EqualFold(c.Name, name) {
+			return c.Address
+		}
+	}
+	return common.Address{}
+}
+
+// hook is the AuctionPoolHook the context deployed to L2, if any
+func (hc *Context) hook() common.Address {
+	return deployed(hc.Context.DeployedL2Contracts, HookContractName)
+}
+
+// registrar is the TaskAVSRegistrar the context deployed to L1, if any
+func (hc *Context) registrar() common.Address {
+	return deployed(hc.Context.DeployedL1Contracts, RegistrarContractName)
+}
+
+// taskHook is the task hook the context deployed to L2, if any
+func (hc *Context) taskHook() common.Address {
+	return deployed(hc.Context.DeployedL2Contracts, TaskHookContractName)
+}
+
+// ApplyContext fills settings the config leaves empty from the context
+func (c *Config) ApplyContext(hc *Context) {
+	fill := func(chain *Chain, from ContextChain) {
+		if chain.ChainID == 0 {
+			chain.ChainID = from.ChainID
+		}
+		if chain.RPCURL == "" {
+			chain.RPCURL = from.RPCURL
+		}
+	}
+
+	// An L2 hook configured before the chain ID was known moves with it
+	unkeyed, hadUnkeyed := c.Hooks[0]
+	fill(&c.Chains.L1, hc.Context.Chains.L1)
+	fill(&c.Chains.L2, hc.Context.Chains.L2)
+	if hadUnkeyed && c.Chains.L2.ChainID != 0 {
+		delete(c.Hooks, 0)
+		if _, ok := c.Hooks[c.Chains.L2.ChainID]; !ok {
+			c.Hooks[c.Chains.L2.ChainID] = unkeyed
+		}
+	}
+
+	if hook := hc.hook(); hook != (common.Address{}) && c.Hook() == (common.Address{}) {
+		c.Hooks[c.Chains.L2.ChainID] = hook
+	}
+
+	if c.Membership.Registrar == (common.Address{}) {
+		c.Membership.Registrar = hc.registrar()
+	}
+	if c.TaskFee.Hook == (common.Address{}) {
+		c.TaskFee.Hook = hc.taskHook()
+	}
+	if c.Membership.OperatorSet == nil {
+		set := hc.Executor.OperatorSetID
+		c.Membership.OperatorSet = &set
+	}
+}
+
+// ValidateContext checks the config is consistent with the context the
+// AVS was deployed with
+func (c *Config) ValidateContext(hc *Context) error {
+	found := false
+	for _, set := range hc.OperatorSets {
+		found = found || set.ID == hc.Executor.OperatorSetID
+	}
+	if !found {
+		return fmt.Errorf("%w: context executor operator set %d is not declared", ErrInvalid, hc.Executor.OperatorSetID)
+	}
+
+	// The executor signs results within the mailbox SLA, so a performer
+	// that can take longer is useless
+	if sla := time.Duration(hc.Mailbox.TaskSLA) * time.Second; sla > 0 && c.Timeout >= sla {
+		return fmt.Errorf("%w: timeout %s is not below the mailbox task SLA %s", ErrInvalid, c.Timeout, sla)
+	}
+
+	if c.OperatorAddress != (common.Address{}) {
+		found = false
+		for _, op := range hc.Executor.Operators {
+			found = found || op.Address == c.OperatorAddress
+		}
+		if !found {
+			return fmt.Errorf("%w: operator %s is not an executor operator in the context", ErrInvalid, c.OperatorAddress)
+		}
+	}
+
+	for _, pair := range []struct {
+		name string
+		got  Chain
+		want ContextChain
+	}{
+		{"l1", c.Chains.L1, hc.Context.Chains.L1},
+		{"l2", c.Chains.L2, hc.Context.Chains.L2},
+	} {
+		if pair.want.ChainID != 0 && pair.got.ChainID != pair.want.ChainID {
+			return fmt.Errorf("%w: %s chain ID %d, context has %d", ErrInvalid, pair.name, pair.got.ChainID, pair.want.ChainID)
+		}
+	}
+
+	if hook := hc.hook(); hook != (common.Address{}) && c.Hook() != hook {
+		return fmt.Errorf("%w: hook %s, context deployed %s", ErrInvalid, c.Hook(), hook)
+	}
+
+	if registrar := hc.registrar(); registrar != (common.Address{}) && c.Membership.Registrar != registrar {
+		return fmt.Errorf("%w: registrar %s, context deployed %s", ErrInvalid, c.Membership.Registrar, registrar)
+	}
+	if taskHook := hc.taskHook(); taskHook != (common.Address{}) && c.TaskFee.Hook != taskHook {
+		return fmt.Errorf("%w: task hook %s, context deployed %s", ErrInvalid, c.TaskFee.Hook, taskHook)
+	}
+	if set := c.Membership.OperatorSet; set != nil && *set != hc.Executor.OperatorSetID {
+		return fmt.Errorf("%w: operator set %d, context executor set is %d", ErrInvalid, *set, hc.Executor.OperatorSetID)
+	}
+	return nil
+}
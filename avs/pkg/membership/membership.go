@@ -0,0 +1,227 @@
+// Package membership tracks whether this performer's operator is
+// registered and allowed in the AVS's executor operator set, as recorded by
+// the TaskAVSRegistrar on L1.
+package membership
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/taskavsregistrar"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrNotMember is returned when the last check found the operator
+	// outside the operator set
+	ErrNotMember = errors.New("operator is not a member of the executor operator set")
+
+	// ErrUnknown is returned before any check has succeeded
+	ErrUnknown = errors.New("operator set membership not yet known")
+)
+
+// retryInterval bounds the wait before retrying a failed check
+const retryInterval = 30 * time.Second
+
+// Registrar is the read side of TaskAVSRegistrar the check needs.
+// *taskavsregistrar.TaskAVSRegistrarCaller implements it.
+type Registrar interface {
+	Avs(opts *bind.CallOpts) (common.Address, error)
+	GetAvsConfig(opts *bind.CallOpts) (taskavsregistrar.ITaskAVSRegistrarBaseTypesAvsConfig, error)
+	IsOperatorAllowed(opts *bind.CallOpts, operatorSet taskavsregistrar.OperatorSet, operator common.Address) (bool, error)
+	GetAllowedOperators(opts *bind.CallOpts, operatorSet taskavsregistrar.OperatorSet) ([]common.Address, error)
+	GetOperatorSocket(opts *bind.CallOpts, operator common.Address) (string, error)
+}
+
+// Status is the outcome of one membership check
+type Status struct {
+	Configured    bool           `json:"configured"`
+	Operator      common.Address `json:"operator"`
+	AVS           common.Address `json:"avs"`
+	OperatorSetID uint32         `json:"operatorSetId"`
+
+	// ExecutorSet is whether the AVS config lists the set as an executor set
+	ExecutorSet bool `json:"executorSet"`
+	// Allowed is whether the operator is on the set's allowlist
+	Allowed          bool `json:"allowed"`
+	AllowedOperators int  `json:"allowedOperators"`
+	// Socket is set by the registrar when the operator registers
+	Socket string `json:"socket"`
+
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Member reports whether the status shows a registered, allowed operator
+// in an executor set
+func (s Status) Member() bool {
+	return s.ExecutorSet && s.Allowed && s.Socket != ""
+}
+
+// Check reads the operator's membership of operatorSetId from the registrar
+func Check(ctx context.Context, reg Registrar, operator common.Address, operatorSetId uint32) (Status, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	s := Status{Configured: true, Operator: operator, OperatorSetID: operatorSetId}
+
+	avs, err := reg.Avs(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get AVS: %w", err)
+	}
+	s.AVS = avs
+
+	avsConfig, err := reg.GetAvsConfig(opts)
+	if err != nil {
+		return s, fmt.Errorf("failed to get AVS config: %w", err)
+	}
+	for _, id := range avsConfig.ExecutorOperatorSetIds {
+		s.ExecutorSet = s.ExecutorSet || id == operatorSetId
+	}
+
+	set := taskavsregistrar.OperatorSet{Avs: avs, Id: operatorSetId}
+	if s.Allowed, err = reg.IsOperatorAllowed(opts, set, operator); err != nil {
+		return s, fmt.Errorf("failed to check allowlist: %w", err)
+	}
+	allowed, err := reg.GetAllowedOperators(opts, set)
+	if err != nil {
+		return s, fmt.Errorf("failed to get allowlist: %w", err)
+	}
+	s.AllowedOperators = len(allowed)
+
+	if s.Socket, err = reg.GetOperatorSocket(opts, operator); err != nil {
+		return s, fmt.Errorf("failed to get operator socket: %w", err)
+	}
+	return s, nil
+}
+
+// Monitor keeps the latest membership status. A nil registrar makes an
+// unconfigured monitor that admits every task.
+type Monitor struct {
+	logger        *zap.Logger
+	registrar     Registrar
+	operator      common.Address
+	operatorSetId uint32
+	interval      time.Duration
+
+	mu     sync.RWMutex
+	status Status
+	known  bool // a check has succeeded
+}
+
+func NewMonitor(logger *zap.Logger, registrar Registrar, operator common.Address, operatorSetId uint32, interval time.Duration) *Monitor {
+	return &Monitor{
+		logger:        logger,
+		registrar:     registrar,
+		operator:      operator,
+		operatorSetId: operatorSetId,
+		interval:      interval,
+		status:        Status{Configured: registrar != nil, Operator: operator, OperatorSetID: operatorSetId},
+	}
+}
+
+// Refresh checks membership now. A failed check keeps the previous
+// result, so a flaky L1 RPC doesn't take the performer offline.
+func (m *Monitor) Refresh(ctx context.Context) error {
+	if m.registrar == nil {
+		return nil
+	}
+
+	s, err := Check(ctx, m.registrar, m.operator, m.operatorSetId)
+	s.CheckedAt = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err != nil {
+		m.status.Error = err.Error()
+		m.logger.Warn("Operator set membership check failed", zap.Error(err))
+		return err
+	}
+
+	if !m.known || m.status.Member() != s.Member() {
+		m.logger.Info("Operator set membership",
+			zap.Stringer("operator", s.Operator),
+			zap.Uint32("operator_set_id", s.OperatorSetID),
+			zap.Bool("member", s.Member()),
+			zap.Bool("executor_set", s.ExecutorSet),
+			zap.Bool("allowed", s.Allowed),
+			zap.String("socket", s.Socket),
+		)
+	}
+	m.status, m.known = s, true
+	return nil
+}
+
+// Run rechecks membership every interval until ctx is done, retrying a
+// failed check sooner. Call Refresh first for the startup check.
+func (m *Monitor) Run(ctx context.Context) {
+	if m.registrar == nil {
+		return
+	}
+
+	for {
+		wait := m.interval
+		if m.Status().Error != "" && retryInterval < wait {
+			wait = retryInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, retryInterval)
+		_ = m.Refresh(checkCtx)
+		cancel()
+	}
+}
+
+// Status returns the latest status
+func (m *Monitor) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// Admit returns an error unless the operator may take tasks
+func (m *Monitor) Admit() error {
+	if m.registrar == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	switch {
+	case !m.known:
+		return ErrUnknown
+	case !m.status.Member():
+		return fmt.Errorf("%w: operator %s, set %d (executor set %v, allowed %v, registered %v)", ErrNotMember,
+			m.status.Operator, m.status.OperatorSetID, m.status.ExecutorSet, m.status.Allowed, m.status.Socket != "")
+	}
+	return nil
+}
+
+// ServeHTTP reports the latest status as JSON, with 503 while the
+// performer is refusing tasks
+func (m *Monitor) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	admitted := m.Admit() == nil
+	code := http.StatusOK
+	if !admitted {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Status
+		Member bool `json:"member"`
+	}{m.Status(), admitted})
+}
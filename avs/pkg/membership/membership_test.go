@@ -0,0 +1,174 @@
+package membership
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l1/taskavsregistrar"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var (
+	avs      = common.HexToAddress("0xa5")
+	operator = common.HexToAddress("0x0b")
+)
+
+type fakeRegistrar struct {
+	executorSets []uint32
+	allowed      map[common.Address]bool
+	sockets      map[common.Address]string
+	err          error
+	sets         []taskavsregistrar.OperatorSet
+}
+
+func (f *fakeRegistrar) Avs(*bind.CallOpts) (common.Address, error) { return avs, f.err }
+
+func (f *fakeRegistrar) GetAvsConfig(*bind.CallOpts) (taskavsregistrar.ITaskAVSRegistrarBaseTypesAvsConfig, error) {
+	return taskavsregistrar.ITaskAVSRegistrarBaseTypesAvsConfig{ExecutorOperatorSetIds: f.executorSets}, nil
+}
+
+func (f *fakeRegistrar) IsOperatorAllowed(_ *bind.CallOpts, set taskavsregistrar.OperatorSet, op common.Address) (bool, error) {
+	f.sets = append(f.sets, set)
+	return f.allowed[op], nil
+}
+
+func (f *fakeRegistrar) GetAllowedOperators(*bind.CallOpts, taskavsregistrar.OperatorSet) ([]common.Address, error) {
+	var out []common.Address
+	for op, ok := range f.allowed {
+		if ok {
+			out = append(out, op)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRegistrar) GetOperatorSocket(_ *bind.CallOpts, op common.Address) (string, error) {
+	return f.sockets[op], nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		reg    *fakeRegistrar
+		member bool
+	}{
+		{"member", &fakeRegistrar{
+			executorSets: []uint32{1},
+			allowed:      map[common.Address]bool{operator: true},
+			sockets:      map[common.Address]string{operator: "executor:9090"},
+		}, true},
+		{"not an executor set", &fakeRegistrar{
+			executorSets: []uint32{2},
+			allowed:      map[common.Address]bool{operator: true},
+			sockets:      map[common.Address]string{operator: "executor:9090"},
+		}, false},
+		{"not allowed", &fakeRegistrar{
+			executorSets: []uint32{1},
+			sockets:      map[common.Address]string{operator: "executor:9090"},
+		}, false},
+		{"not registered", &fakeRegistrar{
+			executorSets: []uint32{1},
+			allowed:      map[common.Address]bool{operator: true},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Check(context.Background(), tt.reg, operator, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Member() != tt.member {
+				t.Errorf("Member() = %v, want %v: %+v", s.Member(), tt.member, s)
+			}
+			if s.AVS != avs || tt.reg.sets[0] != (taskavsregistrar.OperatorSet{Avs: avs, Id: 1}) {
+				t.Errorf("checked the wrong operator set: %+v", tt.reg.sets)
+			}
+		})
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	reg := &fakeRegistrar{err: errors.New("l1 down")}
+	m := NewMonitor(zap.NewNop(), reg, operator, 1, 0)
+
+	// Nothing is admitted until a check succeeds
+	if err := m.Refresh(context.Background()); err == nil {
+		t.Fatal("expected the check to fail")
+	}
+	if err := m.Admit(); !errors.Is(err, ErrUnknown) {
+		t.Errorf("Admit() = %v, want %v", err, ErrUnknown)
+	}
+
+	reg.err = nil
+	reg.executorSets = []uint32{1}
+	reg.allowed = map[common.Address]bool{operator: true}
+	if err := m.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Admit(); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Admit() = %v, want %v for an unregistered operator", err, ErrNotMember)
+	}
+
+	reg.sockets = map[common.Address]string{operator: "executor:9090"}
+	if err := m.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Admit(); err != nil {
+		t.Errorf("Admit() = %v for a member", err)
+	}
+
+	// A failed check keeps the last known membership
+	reg.err = errors.New("l1 down")
+	_ = m.Refresh(context.Background())
+	if err := m.Admit(); err != nil {
+		t.Errorf("Admit() = %v after a failed recheck", err)
+	}
+	if m.Status().Error == "" {
+		t.Errorf("expected the failed recheck to be reported")
+	}
+
+	// Without a registrar nothing is checked or refused
+	if err := NewMonitor(zap.NewNop(), nil, operator, 1, 0).Admit(); err != nil {
+		t.Errorf("unconfigured monitor refused: %v", err)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	reg := &fakeRegistrar{
+		executorSets: []uint32{1},
+		sockets:      map[common.Address]string{operator: "executor:9090"},
+	}
+	m := NewMonitor(zap.NewNop(), reg, operator, 1, 0)
+	if err := m.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func() (int, map[string]any) {
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, body
+	}
+
+	if code, body := get(); code != http.StatusServiceUnavailable || body["member"] != false || body["allowed"] != false {
+		t.Errorf("non-member status = %d %v", code, body)
+	}
+
+	reg.allowed = map[common.Address]bool{operator: true}
+	if err := m.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if code, body := get(); code != http.StatusOK || body["member"] != true || body["allowedOperators"] != float64(1) {
+		t.Errorf("member status = %d %v", code, body)
+	}
+}
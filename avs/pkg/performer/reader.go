@@ -0,0 +1,196 @@
+package performer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/auctionpoolhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/accounting"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/surveillance"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Block is the part of an L2 header the worker commits to
+type Block struct {
+	Number uint64
+	Hash   common.Hash
+	Time   uint64
+}
+
+// ChainReader reads L2 blocks
+type ChainReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	Block(ctx context.Context, number uint64) (Block, error)
+}
+
+// HookState is an AuctionPoolHook's view of one pool at one block
+type HookState struct {
+	PoolManager    common.Address
+	Manager        common.Address
+	RentPerBlock   *big.Int
+	ManagerDeposit *big.Int
+	CurrentFee     uint32
+	NextBidder     common.Address
+	NextRent       *big.Int
+	BidHistory     []auctionpoolhook.AuctionPoolHookBid
+}
+
+// HookReader reads an AuctionPoolHook and its pool manager. Every read is
+// pinned to a resolved block: state is read at the block's hash, and a range
+// of logs ending at a block fails with ErrReorged unless the block is still
+// canonical once read, so a task reads only the fork its result commits to.
+type HookReader interface {
+	PoolState(ctx context.Context, hook common.Address, poolId common.Hash, block Block) (*HookState, error)
+	Swaps(ctx context.Context, poolManager common.Address, poolId common.Hash, from uint64, to Block) ([]*poolmanager.Swap, error)
+	RentState(ctx context.Context, hook common.Address, poolId common.Hash, block Block) (accounting.State, error)
+	RentEvents(ctx context.Context, hook common.Address, poolId common.Hash, from uint64, to Block) ([]accounting.Event, error)
+	Surveillance(ctx context.Context, hook, poolManager common.Address, poolId common.Hash, from uint64, to Block) (surveillance.Input, error)
+}
+
+// ErrReorged means a block a task is pinned to left the canonical chain
+// while the task read it
+var ErrReorged = errors.New("reference block was reorged out")
+
+// Reader is everything the worker reads from L2
+type Reader interface {
+	ChainReader
+	HookReader
+}
+
+// Clock tells the worker the time
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// Backend is what RPCReader needs from an L2 client
+type Backend interface {
+	bind.ContractCaller
+	bind.BlockHashContractCaller
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// RPCReader implements Reader over an L2 RPC client
+type RPCReader struct {
+	backend Backend
+}
+
+func NewRPCReader(backend Backend) *RPCReader {
+	return &RPCReader{backend: backend}
+}
+
+func (r *RPCReader) BlockNumber(ctx context.Context) (uint64, error) {
+	return r.backend.BlockNumber(ctx)
+}
+
+func (r *RPCReader) Block(ctx context.Context, number uint64) (Block, error) {
+	header, err := r.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return Block{}, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+	return Block{Number: number, Hash: header.Hash(), Time: header.Time}, nil
+}
+
+func (r *RPCReader) PoolState(ctx context.Context, hook common.Address, poolId common.Hash, block Block) (*HookState, error) {
+	caller, err := auctionpoolhook.NewAuctionPoolHookCaller(hook, r.backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind AuctionPoolHook: %w", err)
+	}
+
+	opts := &bind.CallOpts{Context: ctx, BlockHash: block.Hash}
+
+	auction, err := caller.PoolAuctions(opts, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool auction: %w", err)
+	}
+
+	next, err := caller.NextBid(opts, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next bid: %w", err)
+	}
+
+	poolManager, err := caller.PoolManager(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool manager: %w", err)
+	}
+
+	history, err := caller.GetBidHistory(opts, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bid history: %w", err)
+	}
+
+	return &HookState{
+		PoolManager:    poolManager,
+		Manager:        auction.CurrentManager,
+		RentPerBlock:   auction.RentPerBlock,
+		ManagerDeposit: auction.ManagerDeposit,
+		CurrentFee:     uint32(auction.CurrentFee.Uint64()),
+		NextBidder:     next.Bidder,
+		NextRent:       next.RentPerBlock,
+		BidHistory:     history,
+	}, nil
+}
+
+func (r *RPCReader) Swaps(ctx context.Context, poolManager common.Address, poolId common.Hash, from uint64, to Block) ([]*poolmanager.Swap, error) {
+	swaps, err := poolmanager.Swaps(ctx, r.backend, poolManager, poolId, from, to.Number)
+	if err != nil {
+		return nil, err
+	}
+	return swaps, r.canonical(ctx, to)
+}
+
+func (r *RPCReader) RentState(ctx context.Context, hook common.Address, poolId common.Hash, block Block) (accounting.State, error) {
+	return accounting.ReadState(ctx, r.backend, hook, poolId, block.Hash)
+}
+
+func (r *RPCReader) RentEvents(ctx context.Context, hook common.Address, poolId common.Hash, from uint64, to Block) ([]accounting.Event, error) {
+	events, err := accounting.ReadEvents(ctx, r.backend, hook, poolId, from, to.Number)
+	if err != nil {
+		return nil, err
+	}
+	return events, r.canonical(ctx, to)
+}
+
+func (r *RPCReader) Surveillance(ctx context.Context, hook, poolManager common.Address, poolId common.Hash, from uint64, to Block) (surveillance.Input, error) {
+	var start common.Hash
+	if from > 0 {
+		// The range opens with the state of the block before it, on to's fork
+		// as long as to is still canonical below
+		b, err := r.Block(ctx, from-1)
+		if err != nil {
+			return surveillance.Input{}, err
+		}
+		start = b.Hash
+	}
+	in, err := surveillance.Read(ctx, r.backend, hook, poolManager, poolId, start, from, to.Number)
+	if err != nil {
+		return surveillance.Input{}, err
+	}
+	return in, r.canonical(ctx, to)
+}
+
+// canonical checks that block is still on the canonical chain after a read
+// by number up to it, so what was read is block's fork and not another
+func (r *RPCReader) canonical(ctx context.Context, block Block) error {
+	now, err := r.Block(ctx, block.Number)
+	if err != nil {
+		return err
+	}
+	if now.Hash != block.Hash {
+		return fmt.Errorf("%w: block %d is now %s, not %s", ErrReorged, block.Number, now.Hash.Hex(), block.Hash.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package performer
+
+import (
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minBidIncrement mirrors MIN_BID_INCREMENT in AuctionPoolHook
+var minBidIncrement = big.NewInt(100)
+
+// poolState is a HookState pinned to the block it was read at, seen from
+// this operator
+type poolState struct {
+	*HookState
+	block        Block
+	isManager    bool
+	isNextBidder bool
+}
+
+// result starts a task result committing to this state
+func (ps *poolState) result(payload *task.Payload, expectedProfit *big.Int) *task.Result {
+	return &task.Result{
+		Type:           payload.Type,
+		Action:         task.ActionNone,
+		PoolId:         payload.Pool(),
+		ReferenceBlock: ps.block.Number,
+		BlockHash:      ps.block.Hash,
+		Inputs: task.Inputs{
+			CurrentManager: ps.Manager,
+			RentPerBlock:   ps.RentPerBlock,
+			ManagerDeposit: ps.ManagerDeposit,
+			CurrentFee:     ps.CurrentFee,
+			NextBidder:     ps.NextBidder,
+			NextRent:       ps.NextRent,
+			ExpectedProfit: expectedProfit,
+		},
+	}
+}
+
+// requiredBid is the lowest rent submitBid would accept in this state
+func (ps *poolState) requiredBid() *big.Int {
+	highest := ps.RentPerBlock
+	if ps.NextRent.Cmp(highest) > 0 {
+		highest = ps.NextRent
+	}
+	return new(big.Int).Add(highest, minBidIncrement)
+}
+
+// isWinning reports whether we already hold the position: we are manager
+// with no rival queued, or our own bid is the queued one
+func (ps *poolState) isWinning() bool {
+	return ps.isNextBidder || (ps.isManager && ps.NextBidder == common.Address{})
+}
@@ -0,0 +1,608 @@
+// Package performer is the business logic of the AuctionPool AVS performer:
+// it validates and answers tasks using state read from L2 at each task's
+// reference block. The binary in cmd only wires it to the Ponos server.
+package performer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/accounting"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/strategy"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/surveillance"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/taskfee"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+// ErrNodeBehind is returned when the L2 node's head is older than the
+// configured lag, so its view of the chain can't be trusted
+var ErrNodeBehind = errors.New("L2 node is behind")
+
+// Config holds what the worker needs beyond its readers
+type Config struct {
+	// HookAddress is used for tasks that carry a PoolId but no PoolKey
+	HookAddress     common.Address
+	OperatorAddress common.Address
+
+	// MaxTaskAge is how many blocks a task's reference block may trail
+	// the head before the task is rejected as stale
+	MaxTaskAge uint64
+
+	// MaxHeadLag is how far the L2 head's timestamp may trail the clock.
+	// Zero disables the check, for devnets that only mine on demand.
+	MaxHeadLag time.Duration
+
+	// Timeout bounds the chain reads for a single task
+	Timeout time.Duration
+
+	Strategy strategy.ProfitParams
+
+	// FeeMarginBps is how far a task's fee must exceed its estimated cost
+	// under Pricing for the task to be taken, once fees are checked
+	FeeMarginBps uint64
+	Pricing      taskfee.Pricing
+}
+
+// DefaultConfig returns the settings the performer ran with before they
+// were configurable
+func DefaultConfig() Config {
+	return Config{
+		MaxTaskAge: 50,
+		Timeout:    5 * time.Second,
+		Strategy:   strategy.DefaultProfitParams(),
+		Pricing:    taskfee.DefaultPricing(),
+	}
+}
+
+// Gate decides whether the performer takes tasks at all, independent of
+// the task
+type Gate interface {
+	Admit() error
+}
+
+// FeeSource reports what the task mailbox charges for a task
+type FeeSource interface {
+	TaskFee(ctx context.Context, payload []byte) (*big.Int, error)
+}
+
+type TaskWorker struct {
+	logger *zap.Logger
+	reader Reader
+	clock  Clock
+	cfg    Config
+	gate   Gate
+	fees   FeeSource
+}
+
+// New creates a worker. reader may be nil when no L2 RPC is configured, in
+// which case every task that needs chain state fails.
+func New(logger *zap.Logger, reader Reader, clock Clock, cfg Config) *TaskWorker {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &TaskWorker{
+		logger: logger,
+		reader: reader,
+		clock:  clock,
+		cfg:    cfg,
+	}
+}
+
+// WithGate makes ValidateTask refuse every task while gate does
+func (tw *TaskWorker) WithGate(gate Gate) *TaskWorker {
+	tw.gate = gate
+	return tw
+}
+
+// WithFees makes ValidateTask refuse tasks whose fee from fees does not
+// cover their estimated cost plus the configured margin
+func (tw *TaskWorker) WithFees(fees FeeSource) *TaskWorker {
+	tw.fees = fees
+	return tw
+}
+
+// loggerKey carries a task's logger in its context
+type loggerKey struct{}
+
+// Field names, the operator's own so one query finds the lines of both
+const (
+	keyPool        = "pool_id"
+	keyBlock       = "block"
+	keyCorrelation = "correlation_id"
+)
+
+// taskLogger tags the worker's logger with a task and, once decoded, its
+// payload. The task ID doubles as the correlation ID, the field the
+// operator links the lines of one decision by.
+func (tw *TaskWorker) taskLogger(t *performerV1.TaskRequest, payload *task.Payload) *zap.Logger {
+	id := hexutil.Encode(t.TaskId)
+	fields := []zap.Field{zap.String("task_id", id), zap.String(keyCorrelation, id)}
+	if payload != nil {
+		fields = append(fields,
+			zap.Stringer("type", payload.Type),
+			zap.String(keyPool, payload.Pool().Hex()),
+			zap.Uint64(keyBlock, payload.ReferenceBlock),
+		)
+	}
+	return tw.logger.With(fields...)
+}
+
+// log is the logger of the task ctx belongs to
+func (tw *TaskWorker) log(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return tw.logger
+}
+
+func (tw *TaskWorker) ValidateTask(t *performerV1.TaskRequest) error {
+	payload, err := task.Decode(t.Payload)
+	logger := tw.taskLogger(t, payload)
+	if err == nil {
+		logger.Info("Validating task")
+		err = tw.validate(context.WithValue(context.Background(), loggerKey{}, logger), payload, t.Payload)
+	}
+	if err != nil {
+		logger.Warn("Task rejected", zap.Error(err))
+	}
+	return err
+}
+
+// validate checks a decoded task can be answered now
+func (tw *TaskWorker) validate(ctx context.Context, payload *task.Payload, raw []byte) error {
+	if !tw.handles(payload.Type) {
+		return fmt.Errorf("%w: %s is not handled by this performer", task.ErrUnsupportedType, payload.Type)
+	}
+
+	if tw.gate != nil {
+		if err := tw.gate.Admit(); err != nil {
+			return err
+		}
+	}
+
+	if tw.reader == nil {
+		return errors.New("cannot validate task: L2_RPC_URL not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tw.cfg.Timeout)
+	defer cancel()
+
+	head, err := tw.reader.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get L2 head: %w", err)
+	}
+
+	if tw.cfg.MaxHeadLag > 0 {
+		block, err := tw.reader.Block(ctx, head)
+		if err != nil {
+			return err
+		}
+		if lag := tw.clock.Now().Sub(time.Unix(int64(block.Time), 0)); lag > tw.cfg.MaxHeadLag {
+			return fmt.Errorf("%w: head %d is %s old", ErrNodeBehind, head, lag.Truncate(time.Second))
+		}
+	}
+
+	if err := task.Validate(payload, head, tw.cfg.MaxTaskAge); err != nil {
+		return err
+	}
+
+	if tw.fees != nil {
+		return tw.checkFee(ctx, payload, raw)
+	}
+	return nil
+}
+
+// checkFee refuses a task that costs more to answer than it pays
+func (tw *TaskWorker) checkFee(ctx context.Context, payload *task.Payload, raw []byte) error {
+	cost, err := taskfee.Estimate(payload)
+	if err != nil {
+		return err
+	}
+	fee, err := tw.fees.TaskFee(ctx, raw)
+	if err != nil {
+		return err
+	}
+
+	quote := taskfee.NewQuote(payload.Type, cost, tw.cfg.Pricing, fee, tw.cfg.FeeMarginBps)
+	tw.log(ctx).Debug("Task fee",
+		zap.Stringer("fee", quote.Fee),
+		zap.Stringer("cost", quote.CostWei),
+		zap.Stringer("required", quote.Required()),
+	)
+	return quote.Err()
+}
+
+// handles reports whether this performer implements a task type
+func (tw *TaskWorker) handles(t task.Type) bool {
+	switch t {
+	case task.TypeEvaluatePool, task.TypeRecommendFee, task.TypeAttestRent, task.TypePriceBid, task.TypeSurveilManager:
+		return true
+	}
+	return false
+}
+
+func (tw *TaskWorker) HandleTask(t *performerV1.TaskRequest) (*performerV1.TaskResponse, error) {
+	payload, err := task.Decode(t.Payload)
+	logger := tw.taskLogger(t, payload)
+	var resp *performerV1.TaskResponse
+	if err == nil {
+		logger.Info("Handling task")
+		resp, err = tw.handle(context.WithValue(context.Background(), loggerKey{}, logger), t, payload)
+	}
+	if err != nil {
+		logger.Error("Task failed", zap.Error(err))
+		return nil, err
+	}
+	return resp, nil
+}
+
+// handle answers a decoded task
+func (tw *TaskWorker) handle(ctx context.Context, t *performerV1.TaskRequest, payload *task.Payload) (*performerV1.TaskResponse, error) {
+	// ------------------------------------------------------------------------
+	// AuctionPool Autonomous Operator Strategy
+	// ------------------------------------------------------------------------
+	// This operator monitors the AuctionPool hook and makes autonomous decisions
+	// about bidding for pool management rights and optimizing fees.
+	//
+	// Key responsibilities:
+	// 1. Monitor current pool state (manager, rent, fee)
+	// 2. Estimate profitability from being manager
+	// 3. Submit competitive bids when profitable
+	// 4. If currently managing, optimize swap fees based on market conditions
+	// ------------------------------------------------------------------------
+
+	ctx, cancel := context.WithTimeout(ctx, tw.cfg.Timeout)
+	defer cancel()
+
+	var (
+		result *task.Result
+		err    error
+	)
+	switch payload.Type {
+	case task.TypeEvaluatePool:
+		result, err = tw.executeStrategy(ctx, payload)
+	case task.TypeRecommendFee:
+		result, err = tw.recommendFee(ctx, payload)
+	case task.TypeAttestRent:
+		result, err = tw.attestRent(ctx, payload)
+	case task.TypePriceBid:
+		result, err = tw.priceBid(ctx, payload)
+	case task.TypeSurveilManager:
+		result, err = tw.surveilManager(ctx, payload)
+	default:
+		err = fmt.Errorf("%w: %s is not handled by this performer", task.ErrUnsupportedType, payload.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := task.EncodeResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	tw.log(ctx).Info("Task result", zap.Stringer("action", result.Action))
+
+	return &performerV1.TaskResponse{
+		TaskId: t.TaskId,
+		Result: encoded,
+	}, nil
+}
+
+// executeStrategy runs the autonomous operator strategy
+func (tw *TaskWorker) executeStrategy(ctx context.Context, payload *task.Payload) (*task.Result, error) {
+	// TODO: Submit transactions
+	// This would:
+	// 1. If profitable, submit bid via AuctionPoolHook.submitBid()
+	// 2. If current manager, update fees via AuctionPoolHook.setSwapFee()
+
+	poolState, err := tw.getPoolState(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool state: %w", err)
+	}
+
+	// Calculate if we should bid
+	expectedProfit := strategy.ExpectedProfit(tw.cfg.Strategy)
+	profitableRent := strategy.ProfitableRent(tw.cfg.Strategy, expectedProfit)
+	requiredRent := poolState.requiredBid()
+	result := poolState.result(payload, expectedProfit)
+
+	if !poolState.isWinning() && profitableRent.Cmp(requiredRent) >= 0 {
+		tw.log(ctx).Info("Profitable bid opportunity detected",
+			zap.Stringer("expected_profit", expectedProfit),
+			zap.Stringer("profitable_rent", profitableRent),
+			zap.Stringer("required_rent", requiredRent),
+			zap.Int("past_bids", len(poolState.BidHistory)),
+		)
+		// TODO: Submit bid via hook contract
+		result.Action = task.ActionSubmitBid
+		result.RecommendedRent = requiredRent
+		return result, nil
+	}
+
+	// If we're the manager, optimize fees
+	if poolState.isManager {
+		obs, err := tw.observeFees(ctx, poolState, payload)
+		if err != nil {
+			return nil, err
+		}
+		optimalFee := strategy.RecommendFee(obs)
+		if strategy.ShouldUpdateFee(poolState.CurrentFee, optimalFee) {
+			tw.log(ctx).Info("Updating swap fee",
+				zap.Uint32("current_fee", poolState.CurrentFee),
+				zap.Uint32("optimal_fee", optimalFee),
+			)
+			// TODO: Update fee via hook contract
+			result.Action = task.ActionUpdateFee
+			result.RecommendedFee = optimalFee
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// recommendFee answers a recommend_fee task. Every input is read at the
+// task's reference block and the fee is integer arithmetic over them, so all
+// honest operators return identical bytes and their signatures aggregate.
+func (tw *TaskWorker) recommendFee(ctx context.Context, payload *task.Payload) (*task.Result, error) {
+	poolState, err := tw.getPoolState(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool state: %w", err)
+	}
+
+	obs, err := tw.observeFees(ctx, poolState, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := task.EncodeFeeDetail(task.FeeDetail{
+		WindowStart: strategy.WindowStart(payload.ReferenceBlock),
+		Swaps:       obs.Swaps,
+		MinTick:     obs.MinTick,
+		MaxTick:     obs.MaxTick,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := poolState.result(payload, nil)
+	result.RecommendedFee = strategy.RecommendFee(obs)
+	result.Detail = detail
+	if strategy.ShouldUpdateFee(poolState.CurrentFee, result.RecommendedFee) {
+		result.Action = task.ActionUpdateFee
+	}
+	return result, nil
+}
+
+// observeFees summarizes the pool's swaps in the fee window ending at the
+// task's reference block
+func (tw *TaskWorker) observeFees(ctx context.Context, poolState *poolState, payload *task.Payload) (strategy.FeeObservation, error) {
+	swaps, err := tw.reader.Swaps(ctx, poolState.PoolManager, payload.Pool(),
+		strategy.WindowStart(payload.ReferenceBlock), poolState.block)
+	if err != nil {
+		return strategy.FeeObservation{}, err
+	}
+	return strategy.Observe(swaps), nil
+}
+
+// attestRent replays the hook's rent accounting over the task's block range
+// from its events and attests whether rentPerShareAccumulated, totalShares
+// and totalRentPaid at the reference block match the replay
+func (tw *TaskWorker) attestRent(ctx context.Context, payload *task.Payload) (*task.Result, error) {
+	from, err := task.DecodeRangeParams(payload.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	poolState, err := tw.getPoolState(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool state: %w", err)
+	}
+	hook, err := tw.hookFor(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replay starts from the state just before the range
+	startBlock := from
+	if startBlock > 0 {
+		startBlock--
+	}
+	poolId := payload.Pool()
+
+	// Both ends are read at their hashes; the events between them fail to
+	// read if the reference block is reorged out, so all three are one fork
+	startAt, err := tw.reader.Block(ctx, startBlock)
+	if err != nil {
+		return nil, err
+	}
+	start, err := tw.reader.RentState(ctx, hook, poolId, startAt)
+	if err != nil {
+		return nil, err
+	}
+	end, err := tw.reader.RentState(ctx, hook, poolId, poolState.block)
+	if err != nil {
+		return nil, err
+	}
+	events, err := tw.reader.RentEvents(ctx, hook, poolId, startBlock+1, poolState.block)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := accounting.Replay(start, events, poolState.BidHistory)
+
+	detail, err := task.EncodeRentDetail(task.RentDetail{
+		FromBlock:           startBlock + 1,
+		ToBlock:             payload.ReferenceBlock,
+		StartAccumulated:    start.Accumulated,
+		ExpectedAccumulated: replay.Expected.Accumulated,
+		OnChainAccumulated:  end.Accumulated,
+		ExpectedTotalShares: replay.Expected.TotalShares,
+		OnChainTotalShares:  end.TotalShares,
+		ExpectedRentPaid:    replay.Expected.TotalRentPaid,
+		OnChainRentPaid:     end.TotalRentPaid,
+		Collected:           replay.Collected,
+		Distributed:         replay.Distributed,
+		Undistributed:       replay.Undistributed,
+		ManagerChanges:      replay.ManagerChanges,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := poolState.result(payload, nil)
+	result.Detail = detail
+	result.Action = task.ActionAttestMatch
+	if !replay.Matches(end) {
+		result.Action = task.ActionAttestMismatch
+		tw.log(ctx).Warn("Rent accounting mismatch",
+			zap.Stringer("expected_accumulated", replay.Expected.Accumulated),
+			zap.Stringer("onchain_accumulated", end.Accumulated),
+			zap.Stringer("expected_rent_paid", replay.Expected.TotalRentPaid),
+			zap.Stringer("onchain_rent_paid", end.TotalRentPaid),
+		)
+	}
+	return result, nil
+}
+
+// surveilManager analyzes swaps and fee updates over the task's block range
+// for fee spikes around large user swaps and manager sandwiches, returning
+// the evidence found
+func (tw *TaskWorker) surveilManager(ctx context.Context, payload *task.Payload) (*task.Result, error) {
+	from, err := task.DecodeRangeParams(payload.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	poolState, err := tw.getPoolState(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool state: %w", err)
+	}
+	hook, err := tw.hookFor(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	in, err := tw.reader.Surveillance(ctx, hook, poolState.PoolManager, payload.Pool(), from, poolState.block)
+	if err != nil {
+		return nil, err
+	}
+	report := surveillance.Analyze(in)
+
+	evidence := task.EvidenceDetail{
+		FromBlock:     from,
+		ToBlock:       payload.ReferenceBlock,
+		SwapsAnalyzed: report.SwapsAnalyzed,
+		ManagerSwaps:  report.ManagerSwaps,
+	}
+	for _, f := range report.Findings {
+		evidence.Findings = append(evidence.Findings, task.Evidence{
+			Kind:          uint8(f.Kind),
+			Block:         f.Block,
+			Manager:       f.Manager,
+			FrontTx:       f.FrontTx,
+			VictimTx:      f.VictimTx,
+			BackTx:        f.BackTx,
+			FeeBefore:     new(big.Int).SetUint64(uint64(f.FeeBefore)),
+			FeeDuring:     new(big.Int).SetUint64(uint64(f.FeeDuring)),
+			VictimAmount0: f.VictimAmount0,
+		})
+		tw.log(ctx).Warn("Suspicious manager behavior",
+			zap.Stringer("kind", f.Kind),
+			zap.Uint64("finding_block", f.Block),
+			zap.Stringer("manager", f.Manager),
+			zap.Stringer("victim_tx", f.VictimTx),
+		)
+	}
+
+	detail, err := task.EncodeEvidenceDetail(evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	result := poolState.result(payload, nil)
+	result.Detail = detail
+	result.Action = task.ActionClean
+	if len(report.Findings) > 0 {
+		result.Action = task.ActionAbuseFlagged
+	}
+	return result, nil
+}
+
+// priceBid reports whether the bid in a price_bid task would be accepted
+// by submitBid in the state at the task's reference block
+func (tw *TaskWorker) priceBid(ctx context.Context, payload *task.Payload) (*task.Result, error) {
+	bid, err := task.DecodePriceBidParams(payload.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	poolState, err := tw.getPoolState(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool state: %w", err)
+	}
+
+	result := poolState.result(payload, nil)
+	result.RecommendedRent = poolState.requiredBid()
+	if bid.RentPerBlock.Cmp(result.RecommendedRent) < 0 {
+		result.Action = task.ActionBidRejected
+	} else {
+		result.Action = task.ActionBidAccepted
+	}
+	return result, nil
+}
+
+// hookFor picks the hook a task refers to: the PoolKey's hooks address when
+// the task carries a key, otherwise the configured hook
+func (tw *TaskWorker) hookFor(payload *task.Payload) (common.Address, error) {
+	if !payload.PoolKey.IsZero() {
+		return payload.PoolKey.Hooks, nil
+	}
+	if tw.cfg.HookAddress == (common.Address{}) {
+		return common.Address{}, errors.New("no AuctionPoolHook address in task or configuration")
+	}
+	return tw.cfg.HookAddress, nil
+}
+
+// getPoolState reads the hook's state for the task's pool. All reads are
+// pinned to the task's reference block so every performer sees the same
+// state.
+func (tw *TaskWorker) getPoolState(ctx context.Context, payload *task.Payload) (*poolState, error) {
+	if tw.reader == nil {
+		return nil, errors.New("L2_RPC_URL not configured")
+	}
+
+	hook, err := tw.hookFor(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := tw.reader.Block(ctx, payload.ReferenceBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference block: %w", err)
+	}
+
+	state, err := tw.reader.PoolState(ctx, hook, payload.Pool(), block)
+	if err != nil {
+		return nil, err
+	}
+
+	isOperator := func(addr common.Address) bool {
+		return tw.cfg.OperatorAddress != (common.Address{}) && addr == tw.cfg.OperatorAddress
+	}
+
+	return &poolState{
+		HookState:    state,
+		block:        block,
+		isManager:    isOperator(state.Manager),
+		isNextBidder: isOperator(state.NextBidder),
+	}, nil
+}
@@ -0,0 +1,554 @@
+package performer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/accounting"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/surveillance"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/taskfee"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	operator = common.HexToAddress("0x0b")
+	rival    = common.HexToAddress("0x0c")
+	hook     = common.HexToAddress("0x00c0")
+	poolId   = common.HexToHash("0x01")
+
+	// headTime is the fake L2 head's timestamp; blocks are 2s apart
+	headTime = time.Unix(1_700_000_000, 0)
+)
+
+const head = 1000
+
+// fakeReader serves one pool's state from memory and records the hooks
+// it was asked about and the block hashes their state was pinned to
+type fakeReader struct {
+	state   HookState
+	swaps   []*poolmanager.Swap
+	rent    map[uint64]accounting.State
+	events  []accounting.Event
+	surveil surveillance.Input
+	err     error
+	hooks   []common.Address
+	pinned  []common.Hash
+}
+
+func (f *fakeReader) BlockNumber(context.Context) (uint64, error) {
+	return head, f.err
+}
+
+func (f *fakeReader) Block(_ context.Context, number uint64) (Block, error) {
+	return Block{
+		Number: number,
+		Hash:   common.BigToHash(new(big.Int).SetUint64(number)),
+		Time:   uint64(headTime.Unix()) - 2*(head-number),
+	}, f.err
+}
+
+func (f *fakeReader) PoolState(_ context.Context, h common.Address, _ common.Hash, block Block) (*HookState, error) {
+	f.hooks = append(f.hooks, h)
+	f.pinned = append(f.pinned, block.Hash)
+	state := f.state
+	return &state, f.err
+}
+
+func (f *fakeReader) Swaps(_ context.Context, _ common.Address, _ common.Hash, _ uint64, to Block) ([]*poolmanager.Swap, error) {
+	f.pinned = append(f.pinned, to.Hash)
+	return f.swaps, f.err
+}
+
+func (f *fakeReader) RentState(_ context.Context, _ common.Address, _ common.Hash, block Block) (accounting.State, error) {
+	f.pinned = append(f.pinned, block.Hash)
+	if s, ok := f.rent[block.Number]; ok {
+		return s, f.err
+	}
+	return f.rent[0], f.err
+}
+
+func (f *fakeReader) RentEvents(_ context.Context, _ common.Address, _ common.Hash, _ uint64, to Block) ([]accounting.Event, error) {
+	f.pinned = append(f.pinned, to.Hash)
+	return f.events, f.err
+}
+
+func (f *fakeReader) Surveillance(_ context.Context, _, _ common.Address, _ common.Hash, _ uint64, to Block) (surveillance.Input, error) {
+	f.pinned = append(f.pinned, to.Hash)
+	return f.surveil, f.err
+}
+
+type gateFunc func() error
+
+func (f gateFunc) Admit() error { return f() }
+
+type feeFunc func(payload []byte) (*big.Int, error)
+
+func (f feeFunc) TaskFee(_ context.Context, payload []byte) (*big.Int, error) { return f(payload) }
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func eth(milli int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(milli), big.NewInt(1e15))
+}
+
+func state(manager common.Address, rent *big.Int, nextBidder common.Address, nextRent *big.Int, fee uint32) HookState {
+	return HookState{
+		PoolManager:    common.HexToAddress("0x0900"),
+		Manager:        manager,
+		RentPerBlock:   rent,
+		ManagerDeposit: new(big.Int).Mul(rent, big.NewInt(100)),
+		CurrentFee:     fee,
+		NextBidder:     nextBidder,
+		NextRent:       nextRent,
+	}
+}
+
+func swapsAt(ticks ...int32) []*poolmanager.Swap {
+	swaps := make([]*poolmanager.Swap, len(ticks))
+	for i, tick := range ticks {
+		swaps[i] = &poolmanager.Swap{Tick: tick, BlockNumber: uint64(head - 10 + i)}
+	}
+	return swaps
+}
+
+func newWorker(reader Reader) *TaskWorker {
+	cfg := DefaultConfig()
+	cfg.HookAddress = hook
+	cfg.OperatorAddress = operator
+	cfg.MaxHeadLag = time.Minute
+	return New(zap.NewNop(), reader, fixedClock(headTime.Add(30*time.Second)), cfg)
+}
+
+func request(t *testing.T, p *task.Payload) *performerV1.TaskRequest {
+	t.Helper()
+	data, err := task.Encode(p)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	return &performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: data}
+}
+
+func handle(t *testing.T, tw *TaskWorker, p *task.Payload) *task.Result {
+	t.Helper()
+	resp, err := tw.HandleTask(request(t, p))
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+	result, err := task.DecodeResult(resp.Result)
+	if err != nil {
+		t.Fatalf("DecodeResult failed: %v", err)
+	}
+	return result
+}
+
+// The default strategy expects 0.0016 ETH of profit per block and bids up
+// to 80% of it, 0.00128 ETH
+func TestEvaluatePool(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      HookState
+		swaps      []*poolmanager.Swap
+		wantAction task.Action
+		wantRent   *big.Int
+		wantFee    uint32
+	}{
+		{
+			name:       "bid on a cheap pool",
+			state:      state(rival, eth(1), common.Address{}, new(big.Int), 3000),
+			wantAction: task.ActionSubmitBid,
+			wantRent:   new(big.Int).Add(eth(1), minBidIncrement),
+		},
+		{
+			name:       "skip an expensive pool",
+			state:      state(rival, eth(2), common.Address{}, new(big.Int), 3000),
+			wantAction: task.ActionNone,
+		},
+		{
+			name:       "outbid a queued rival",
+			state:      state(rival, big.NewInt(1000), rival, big.NewInt(1_200_000_000_000_000), 3000),
+			wantAction: task.ActionSubmitBid,
+			wantRent:   big.NewInt(1_200_000_000_000_100),
+		},
+		{
+			name:       "own bid already queued",
+			state:      state(rival, big.NewInt(1000), operator, big.NewInt(2000), 3000),
+			wantAction: task.ActionNone,
+		},
+		{
+			name:       "defend against a rival while managing",
+			state:      state(operator, big.NewInt(1000), rival, eth(1), 3000),
+			wantAction: task.ActionSubmitBid,
+			wantRent:   new(big.Int).Add(eth(1), minBidIncrement),
+		},
+		{
+			name:       "manager keeps a fee within threshold",
+			state:      state(operator, big.NewInt(1000), common.Address{}, new(big.Int), 3000),
+			swaps:      swapsAt(10, 10, 10),
+			wantAction: task.ActionNone,
+		},
+		{
+			name:       "manager raises fee on volatility",
+			state:      state(operator, big.NewInt(1000), common.Address{}, new(big.Int), 3000),
+			swaps:      swapsAt(-100, 0, 100),
+			wantAction: task.ActionUpdateFee,
+			wantFee:    3194,
+		},
+		{
+			name:       "non-manager ignores fees",
+			state:      state(rival, eth(2), common.Address{}, new(big.Int), 3000),
+			swaps:      swapsAt(-100, 0, 100),
+			wantAction: task.ActionNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{state: tt.state, swaps: tt.swaps}
+			result := handle(t, newWorker(reader), &task.Payload{
+				Type:           task.TypeEvaluatePool,
+				PoolId:         poolId,
+				ReferenceBlock: head,
+			})
+
+			if result.Action != tt.wantAction {
+				t.Errorf("action = %s, want %s", result.Action, tt.wantAction)
+			}
+			if tt.wantRent != nil && result.RecommendedRent.Cmp(tt.wantRent) != 0 {
+				t.Errorf("recommended rent = %s, want %s", result.RecommendedRent, tt.wantRent)
+			}
+			if result.RecommendedFee != tt.wantFee {
+				t.Errorf("recommended fee = %d, want %d", result.RecommendedFee, tt.wantFee)
+			}
+			if result.Inputs.ExpectedProfit.Cmp(big.NewInt(1_600_000_000_000_000)) != 0 {
+				t.Errorf("expected profit = %s, want 0.0016 ETH", result.Inputs.ExpectedProfit)
+			}
+		})
+	}
+}
+
+func TestRecommendFeeThreshold(t *testing.T) {
+	// Three flat swaps recommend BaseFee less the volume discount, 2994
+	tests := []struct {
+		name       string
+		currentFee uint32
+		wantAction task.Action
+	}{
+		{"at recommendation", 2994, task.ActionNone},
+		{"exactly threshold above", 3094, task.ActionNone},
+		{"exactly threshold below", 2894, task.ActionNone},
+		{"over threshold above", 3095, task.ActionUpdateFee},
+		{"over threshold below", 2893, task.ActionUpdateFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{
+				state: state(rival, big.NewInt(1000), common.Address{}, new(big.Int), tt.currentFee),
+				swaps: swapsAt(10, 10, 10),
+			}
+			result := handle(t, newWorker(reader), &task.Payload{
+				Type:           task.TypeRecommendFee,
+				PoolId:         poolId,
+				ReferenceBlock: head,
+			})
+
+			if result.RecommendedFee != 2994 || result.Action != tt.wantAction {
+				t.Errorf("got fee %d action %s, want 2994 %s", result.RecommendedFee, result.Action, tt.wantAction)
+			}
+			detail, err := task.DecodeFeeDetail(result.Detail)
+			if err != nil {
+				t.Fatalf("DecodeFeeDetail failed: %v", err)
+			}
+			if detail.Swaps != 3 || detail.MinTick != 10 || detail.MaxTick != 10 {
+				t.Errorf("unexpected fee detail: %+v", detail)
+			}
+		})
+	}
+}
+
+func TestManagerDetection(t *testing.T) {
+	tests := []struct {
+		name         string
+		operator     common.Address
+		manager      common.Address
+		nextBidder   common.Address
+		isManager    bool
+		isNextBidder bool
+		isWinning    bool
+	}{
+		{"manager without rival", operator, operator, common.Address{}, true, false, true},
+		{"manager with rival", operator, operator, rival, true, false, false},
+		{"queued bidder", operator, rival, operator, false, true, true},
+		{"bystander", operator, rival, common.Address{}, false, false, false},
+		// An unconfigured operator must not match an empty manager slot
+		{"no operator address", common.Address{}, common.Address{}, common.Address{}, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{state: state(tt.manager, big.NewInt(1000), tt.nextBidder, new(big.Int), 3000)}
+			tw := newWorker(reader)
+			tw.cfg.OperatorAddress = tt.operator
+
+			ps, err := tw.getPoolState(context.Background(), &task.Payload{PoolId: poolId, ReferenceBlock: head})
+			if err != nil {
+				t.Fatalf("getPoolState failed: %v", err)
+			}
+			// The state is read at the hash the result commits to
+			if len(reader.pinned) != 1 || reader.pinned[0] != ps.block.Hash {
+				t.Errorf("state pinned to %v, want %s", reader.pinned, ps.block.Hash)
+			}
+			if ps.isManager != tt.isManager || ps.isNextBidder != tt.isNextBidder || ps.isWinning() != tt.isWinning {
+				t.Errorf("manager/next/winning = %v/%v/%v, want %v/%v/%v",
+					ps.isManager, ps.isNextBidder, ps.isWinning(), tt.isManager, tt.isNextBidder, tt.isWinning)
+			}
+		})
+	}
+}
+
+func TestValidateTask(t *testing.T) {
+	valid := &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head - 5}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		reader  Reader
+		clock   time.Time
+		wantErr error
+	}{
+		{"valid", nil, &fakeReader{}, headTime, nil},
+		{"malformed", []byte("test-data"), &fakeReader{}, headTime, task.ErrMalformed},
+		{"unhandled type", encode(t, &task.Payload{Type: 9, PoolId: poolId, ReferenceBlock: head}), &fakeReader{}, headTime, task.ErrUnsupportedType},
+		{"future version", encode(t, &task.Payload{Version: 2, Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head}), &fakeReader{}, headTime, task.ErrUnsupportedVersion},
+		{"no pool", encode(t, &task.Payload{Type: task.TypeEvaluatePool, ReferenceBlock: head}), &fakeReader{}, headTime, task.ErrInvalidPool},
+		{"stale", encode(t, &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head - 51}), &fakeReader{}, headTime, task.ErrStale},
+		{"future block", encode(t, &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head + 1}), &fakeReader{}, headTime, task.ErrFutureBlock},
+		{"bad params", encode(t, &task.Payload{Type: task.TypePriceBid, PoolId: poolId, ReferenceBlock: head, Params: []byte("bid")}), &fakeReader{}, headTime, task.ErrInvalidParams},
+		{"node behind", nil, &fakeReader{}, headTime.Add(2 * time.Minute), ErrNodeBehind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tw := newWorker(tt.reader)
+			tw.clock = fixedClock(tt.clock)
+
+			data := tt.payload
+			if data == nil {
+				data = encode(t, valid)
+			}
+			err := tw.ValidateTask(&performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: data})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("ValidateTask() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("no reader", func(t *testing.T) {
+		tw := New(zap.NewNop(), nil, nil, DefaultConfig())
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); err == nil {
+			t.Errorf("expected validation to fail without an L2 reader")
+		}
+	})
+
+	t.Run("gate closed", func(t *testing.T) {
+		closed := errors.New("not a member")
+		tw := newWorker(&fakeReader{}).WithGate(gateFunc(func() error { return closed }))
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); !errors.Is(err, closed) {
+			t.Errorf("ValidateTask() = %v, want %v", err, closed)
+		}
+		tw.WithGate(gateFunc(func() error { return nil }))
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); err != nil {
+			t.Errorf("ValidateTask() = %v with an open gate", err)
+		}
+	})
+
+	t.Run("underpaid", func(t *testing.T) {
+		fee := eth(1)
+		tw := newWorker(&fakeReader{}).WithFees(feeFunc(func([]byte) (*big.Int, error) { return fee, nil }))
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); err != nil {
+			t.Errorf("ValidateTask() = %v with the default fee", err)
+		}
+
+		// A surveil_manager task over a long range costs more than it pays
+		long := encode(t, &task.Payload{Type: task.TypeSurveilManager, PoolId: poolId, ReferenceBlock: head, Params: task.EncodeRangeParams(1)})
+		tw.cfg.Pricing.LogBlockWei = 1e12
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: long}); !errors.Is(err, taskfee.ErrUnderpaid) {
+			t.Errorf("ValidateTask() = %v, want %v", err, taskfee.ErrUnderpaid)
+		}
+
+		boom := errors.New("boom")
+		tw.WithFees(feeFunc(func([]byte) (*big.Int, error) { return nil, boom }))
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); !errors.Is(err, boom) {
+			t.Errorf("ValidateTask() = %v, want %v", err, boom)
+		}
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		boom := errors.New("boom")
+		tw := newWorker(&fakeReader{err: boom})
+		if err := tw.ValidateTask(&performerV1.TaskRequest{Payload: encode(t, valid)}); !errors.Is(err, boom) {
+			t.Errorf("ValidateTask() = %v, want %v", err, boom)
+		}
+	})
+}
+
+func encode(t *testing.T, p *task.Payload) []byte {
+	t.Helper()
+	return request(t, p).Payload
+}
+
+func TestResponseEncoding(t *testing.T) {
+	bid := func(rent int64) []byte {
+		out, err := task.EncodePriceBidParams(task.PriceBidParams{RentPerBlock: big.NewInt(rent), Deposit: big.NewInt(rent * 100)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	key := task.PoolKey{
+		Currency0:   common.HexToAddress("0x01"),
+		Currency1:   common.HexToAddress("0x02"),
+		Fee:         big.NewInt(0x800000),
+		TickSpacing: big.NewInt(60),
+		Hooks:       common.HexToAddress("0x00c1"),
+	}
+	rent := accounting.State{
+		Accumulated:   big.NewInt(5),
+		TotalShares:   big.NewInt(10),
+		TotalRentPaid: big.NewInt(50),
+		RentPerBlock:  big.NewInt(1000),
+		Deposit:       big.NewInt(100000),
+	}
+
+	tests := []struct {
+		name       string
+		payload    task.Payload
+		wantAction task.Action
+		wantHook   common.Address
+		wantPins   []uint64
+	}{
+		{"bid accepted", task.Payload{Type: task.TypePriceBid, PoolId: poolId, ReferenceBlock: head, Params: bid(1100)}, task.ActionBidAccepted, hook, []uint64{head}},
+		{"bid rejected", task.Payload{Type: task.TypePriceBid, PoolId: poolId, ReferenceBlock: head, Params: bid(1099)}, task.ActionBidRejected, hook, []uint64{head}},
+		{"hook from pool key", task.Payload{Type: task.TypePriceBid, PoolKey: key, ReferenceBlock: head, Params: bid(1100)}, task.ActionBidAccepted, key.Hooks, []uint64{head}},
+		// The replay's start state is read at the hash of the block before the range
+		{"rent attested", task.Payload{Type: task.TypeAttestRent, PoolId: poolId, ReferenceBlock: head, Params: task.EncodeRangeParams(head - 10)}, task.ActionAttestMatch, hook, []uint64{head, head - 11, head, head}},
+		{"manager clean", task.Payload{Type: task.TypeSurveilManager, PoolId: poolId, ReferenceBlock: head, Params: task.EncodeRangeParams(head - 10)}, task.ActionClean, hook, []uint64{head, head}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{
+				state: state(rival, big.NewInt(1000), common.Address{}, new(big.Int), 3000),
+				rent:  map[uint64]accounting.State{0: rent},
+			}
+			tw := newWorker(reader)
+
+			req := request(t, &tt.payload)
+			resp, err := tw.HandleTask(req)
+			if err != nil {
+				t.Fatalf("HandleTask failed: %v", err)
+			}
+			if !bytes.Equal(resp.TaskId, req.TaskId) {
+				t.Errorf("task id = %x, want %x", resp.TaskId, req.TaskId)
+			}
+
+			result, err := task.DecodeResult(resp.Result)
+			if err != nil {
+				t.Fatalf("DecodeResult failed: %v", err)
+			}
+			if result.Version != task.Version || result.Type != tt.payload.Type || result.Action != tt.wantAction {
+				t.Errorf("header = v%d %s %s, want v%d %s %s",
+					result.Version, result.Type, result.Action, task.Version, tt.payload.Type, tt.wantAction)
+			}
+			if result.PoolId != tt.payload.Pool() || result.ReferenceBlock != head {
+				t.Errorf("result not bound to the task's pool and block: %+v", result)
+			}
+			if want := common.BigToHash(big.NewInt(head)); result.BlockHash != want {
+				t.Errorf("block hash = %s, want %s", result.BlockHash, want)
+			}
+			if result.StateHash != result.Inputs.Hash() || result.Inputs.CurrentManager != rival {
+				t.Errorf("result does not commit to the pool state: %+v", result.Inputs)
+			}
+			if reader.hooks[0] != tt.wantHook {
+				t.Errorf("read hook %s, want %s", reader.hooks[0], tt.wantHook)
+			}
+			// Every read is pinned to a resolved block's hash
+			pins := make([]common.Hash, len(tt.wantPins))
+			for i, n := range tt.wantPins {
+				pins[i] = common.BigToHash(new(big.Int).SetUint64(n))
+			}
+			if !slices.Equal(reader.pinned, pins) {
+				t.Errorf("reads pinned to %v, want %v", reader.pinned, pins)
+			}
+
+			// Operators reading the same state must sign the same bytes
+			again, err := tw.HandleTask(req)
+			if err != nil || !bytes.Equal(again.Result, resp.Result) {
+				t.Errorf("result not deterministic: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandleTaskErrors(t *testing.T) {
+	boom := errors.New("boom")
+	tw := newWorker(&fakeReader{err: boom})
+
+	if _, err := tw.HandleTask(&performerV1.TaskRequest{Payload: []byte("test-data")}); !errors.Is(err, task.ErrMalformed) {
+		t.Errorf("expected malformed payload to be rejected, got %v", err)
+	}
+	if _, err := tw.HandleTask(request(t, &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head})); !errors.Is(err, boom) {
+		t.Errorf("expected reader error to surface, got %v", err)
+	}
+
+	tw = newWorker(&fakeReader{})
+	tw.cfg.HookAddress = common.Address{}
+	if _, err := tw.HandleTask(request(t, &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head})); err == nil {
+		t.Errorf("expected a task without a hook address to fail")
+	}
+}
+
+func TestTaskLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	tw := newWorker(&fakeReader{state: state(rival, eth(1), common.Address{}, new(big.Int), 3000)})
+	tw.logger = zap.New(core)
+
+	handle(t, tw, &task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: head})
+
+	// Every line of the task carries its IDs, down to the strategy's own
+	entries := logs.TakeAll()
+	var decided bool
+	for _, e := range entries {
+		fields := e.ContextMap()
+		if fields["task_id"] != "0x7461736b2d31" || fields["correlation_id"] != fields["task_id"] ||
+			fields["pool_id"] != poolId.Hex() || fields["block"] != uint64(head) || fields["type"] != task.TypeEvaluatePool.String() {
+			t.Errorf("%q logged with %v", e.Message, fields)
+		}
+		decided = decided || e.Message == "Profitable bid opportunity detected"
+	}
+	if !decided {
+		t.Errorf("strategy decision not logged: %v", entries)
+	}
+
+	// A task that can't be decoded is still logged by its ID
+	if err := tw.ValidateTask(&performerV1.TaskRequest{TaskId: []byte{1}, Payload: []byte("junk")}); err == nil {
+		t.Fatal("ValidateTask accepted a malformed payload")
+	}
+	rejected := logs.FilterMessage("Task rejected").All()
+	if len(rejected) != 1 || rejected[0].ContextMap()["task_id"] != "0x01" {
+		t.Errorf("rejection logged as %v", rejected)
+	}
+}
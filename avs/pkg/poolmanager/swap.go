@@ -0,0 +1,123 @@
+// Package poolmanager decodes the Uniswap v4 PoolManager events the
+// performer reads. Only the events it needs are described here, so no full
+// PoolManager binding is required.
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const swapEventABI = `[{"type":"event","name":"Swap","anonymous":false,"inputs":[
+	{"name":"id","type":"bytes32","indexed":true},
+	{"name":"sender","type":"address","indexed":true},
+	{"name":"amount0","type":"int128","indexed":false},
+	{"name":"amount1","type":"int128","indexed":false},
+	{"name":"sqrtPriceX96","type":"uint160","indexed":false},
+	{"name":"liquidity","type":"uint128","indexed":false},
+	{"name":"tick","type":"int24","indexed":false},
+	{"name":"fee","type":"uint24","indexed":false}]}]`
+
+var (
+	swapEvent abi.Event
+
+	// SwapTopic is the topic0 of PoolManager's Swap event
+	SwapTopic common.Hash
+)
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(swapEventABI))
+	if err != nil {
+		panic(err)
+	}
+	swapEvent = parsed.Events["Swap"]
+	SwapTopic = swapEvent.ID
+}
+
+// Swap is a decoded PoolManager Swap event. Amounts are from the pool's
+// perspective: negative means tokens left the pool.
+type Swap struct {
+	PoolId       common.Hash
+	Sender       common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         int32
+	Fee          uint32
+
+	BlockNumber uint64
+	TxIndex     uint
+	LogIndex    uint
+	TxHash      common.Hash
+}
+
+// ParseSwap decodes a Swap log
+func ParseSwap(log types.Log) (*Swap, error) {
+	if len(log.Topics) != 3 || log.Topics[0] != SwapTopic {
+		return nil, errors.New("not a Swap event")
+	}
+
+	values, err := swapEvent.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Swap event: %w", err)
+	}
+
+	return &Swap{
+		PoolId:       log.Topics[1],
+		Sender:       common.BytesToAddress(log.Topics[2].Bytes()),
+		Amount0:      values[0].(*big.Int),
+		Amount1:      values[1].(*big.Int),
+		SqrtPriceX96: values[2].(*big.Int),
+		Liquidity:    values[3].(*big.Int),
+		Tick:         int32(values[4].(*big.Int).Int64()),
+		Fee:          uint32(values[5].(*big.Int).Uint64()),
+		BlockNumber:  log.BlockNumber,
+		TxIndex:      log.TxIndex,
+		LogIndex:     log.Index,
+		TxHash:       log.TxHash,
+	}, nil
+}
+
+// Swaps returns the pool's swaps in [from, to], in chain order
+func Swaps(ctx context.Context, filterer ethereum.LogFilterer, poolManager common.Address, poolId common.Hash, from, to uint64) ([]*Swap, error) {
+	logs, err := filterer.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{poolManager},
+		Topics:    [][]common.Hash{{SwapTopic}, {poolId}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter Swap events: %w", err)
+	}
+
+	swaps := make([]*Swap, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		swap, err := ParseSwap(log)
+		if err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+
+	// Nodes return logs in order, but results must not depend on it
+	sort.Slice(swaps, func(i, j int) bool {
+		if swaps[i].BlockNumber != swaps[j].BlockNumber {
+			return swaps[i].BlockNumber < swaps[j].BlockNumber
+		}
+		return swaps[i].LogIndex < swaps[j].LogIndex
+	})
+	return swaps, nil
+}
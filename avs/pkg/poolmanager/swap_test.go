@@ -0,0 +1,40 @@
+package poolmanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParseSwap(t *testing.T) {
+	data, err := swapEvent.Inputs.NonIndexed().Pack(
+		big.NewInt(-5000), big.NewInt(4990), big.NewInt(1<<40), big.NewInt(1e6), big.NewInt(-120), big.NewInt(3000),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poolId := common.HexToHash("0xabc")
+	sender := common.HexToAddress("0x1234")
+	swap, err := ParseSwap(types.Log{
+		Topics:      []common.Hash{SwapTopic, poolId, common.BytesToHash(sender.Bytes())},
+		Data:        data,
+		BlockNumber: 7,
+	})
+	if err != nil {
+		t.Fatalf("ParseSwap failed: %v", err)
+	}
+
+	if swap.PoolId != poolId || swap.Sender != sender || swap.BlockNumber != 7 {
+		t.Errorf("topics not decoded: %+v", swap)
+	}
+	if swap.Amount0.Int64() != -5000 || swap.Tick != -120 || swap.Fee != 3000 {
+		t.Errorf("data not decoded: %+v", swap)
+	}
+
+	if _, err := ParseSwap(types.Log{Topics: []common.Hash{{}}}); err == nil {
+		t.Errorf("expected non-Swap log to be rejected")
+	}
+}
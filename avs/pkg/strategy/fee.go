@@ -0,0 +1,96 @@
+// Package strategy holds the performer's pricing rules. Everything here is
+// integer arithmetic over on-chain inputs, so every operator that reads the
+// same block computes the same answer and their signatures aggregate.
+package strategy
+
+import (
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+)
+
+// Fee parameters are part of the result format: changing them changes
+// what operators sign, so every operator must upgrade together.
+const (
+	// FeeWindow is how many blocks up to the reference block are observed
+	FeeWindow = 100
+
+	BaseFee uint32 = 3000  // 0.30% in hundredths of a bip
+	MinFee  uint32 = 500   // 0.05%
+	MaxFee  uint32 = 10000 // MAX_FEE in AuctionPoolHook, 1%
+
+	// FeeUpdateThreshold is the smallest change worth a setSwapFee
+	FeeUpdateThreshold uint32 = 100
+
+	// fixedPointOne is 1.0 in the 4-decimal fixed point used below
+	fixedPointOne = 10000
+
+	// volatilityPipsPerTick is the fee added per tick of price range, 1.0
+	volatilityPipsPerTick = 1 * fixedPointOne
+	// volumeDiscountPerSwap is the fee removed per swap, 2.0, to capture flow
+	volumeDiscountPerSwap = 2 * fixedPointOne
+	maxVolumeDiscount     = 200
+)
+
+// FeeObservation summarizes the swaps in a fee window
+type FeeObservation struct {
+	Swaps   uint32
+	MinTick int32
+	MaxTick int32
+}
+
+// Observe summarizes swaps. The result does not depend on their order.
+func Observe(swaps []*poolmanager.Swap) FeeObservation {
+	var obs FeeObservation
+	for i, s := range swaps {
+		if i == 0 || s.Tick < obs.MinTick {
+			obs.MinTick = s.Tick
+		}
+		if i == 0 || s.Tick > obs.MaxTick {
+			obs.MaxTick = s.Tick
+		}
+	}
+	obs.Swaps = uint32(len(swaps))
+	return obs
+}
+
+// TickRange is how far the price moved within the window, in ticks. One tick
+// is a 0.01% price move, so this is also the range in bips.
+func (o FeeObservation) TickRange() uint64 {
+	return uint64(int64(o.MaxTick) - int64(o.MinTick))
+}
+
+// RecommendFee prices volatility in and discounts for volume: wider price
+// ranges mean more LP adverse selection, busier pools reward a keener fee.
+func RecommendFee(obs FeeObservation) uint32 {
+	fee := uint64(BaseFee) + obs.TickRange()*volatilityPipsPerTick/fixedPointOne
+
+	discount := uint64(obs.Swaps) * volumeDiscountPerSwap / fixedPointOne
+	if discount > maxVolumeDiscount {
+		discount = maxVolumeDiscount
+	}
+	fee -= discount
+
+	switch {
+	case fee > uint64(MaxFee):
+		return MaxFee
+	case fee < uint64(MinFee):
+		return MinFee
+	}
+	return uint32(fee)
+}
+
+// ShouldUpdateFee reports whether the recommended fee differs enough from
+// the current one to justify a transaction
+func ShouldUpdateFee(current, recommended uint32) bool {
+	if recommended > current {
+		return recommended-current > FeeUpdateThreshold
+	}
+	return current-recommended > FeeUpdateThreshold
+}
+
+// WindowStart is the first block of the fee window ending at reference
+func WindowStart(reference uint64) uint64 {
+	if reference < FeeWindow {
+		return 0
+	}
+	return reference - FeeWindow + 1
+}
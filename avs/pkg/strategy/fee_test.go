@@ -0,0 +1,110 @@
+package strategy
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func swapsAt(ticks ...int32) []*poolmanager.Swap {
+	swaps := make([]*poolmanager.Swap, len(ticks))
+	for i, tick := range ticks {
+		swaps[i] = &poolmanager.Swap{Tick: tick, BlockNumber: uint64(i)}
+	}
+	return swaps
+}
+
+func TestRecommendFee(t *testing.T) {
+	many := make([]int32, 150)
+
+	tests := []struct {
+		name  string
+		ticks []int32
+		want  uint32
+	}{
+		{"no swaps", nil, BaseFee},
+		{"flat price", []int32{10, 10, 10}, BaseFee - 6},
+		{"2% range", []int32{-100, 0, 100}, BaseFee + 200 - 6},
+		{"volume discount capped", many, BaseFee - maxVolumeDiscount},
+		{"capped at MAX_FEE", []int32{-887272, 887272}, MaxFee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecommendFee(Observe(swapsAt(tt.ticks...))); got != tt.want {
+				t.Errorf("RecommendFee() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldUpdateFee(t *testing.T) {
+	if ShouldUpdateFee(3000, 3100) || ShouldUpdateFee(3100, 3000) {
+		t.Errorf("a change of exactly the threshold should not trigger an update")
+	}
+	if !ShouldUpdateFee(3000, 3101) || !ShouldUpdateFee(3101, 3000) {
+		t.Errorf("a change above the threshold should trigger an update")
+	}
+}
+
+// TestFeeResultDeterministic checks that operators fed the same logs in any
+// order sign byte-identical results
+func TestFeeResultDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ticks := make([]int32, 40)
+	for i := range ticks {
+		ticks[i] = int32(rng.Intn(400) - 200)
+	}
+
+	encode := func(swaps []*poolmanager.Swap) []byte {
+		obs := Observe(swaps)
+		detail, err := task.EncodeFeeDetail(task.FeeDetail{WindowStart: WindowStart(1000), Swaps: obs.Swaps, MinTick: obs.MinTick, MaxTick: obs.MaxTick})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := task.EncodeResult(&task.Result{
+			Type:           task.TypeRecommendFee,
+			Action:         task.ActionUpdateFee,
+			PoolId:         common.HexToHash("0x01"),
+			ReferenceBlock: 1000,
+			RecommendedFee: RecommendFee(obs),
+			Inputs:         task.Inputs{RentPerBlock: big.NewInt(1), CurrentFee: 3000},
+			Detail:         detail,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	want := encode(swapsAt(ticks...))
+	for run := 0; run < 50; run++ {
+		swaps := swapsAt(ticks...)
+		rng.Shuffle(len(swaps), func(i, j int) { swaps[i], swaps[j] = swaps[j], swaps[i] })
+		if got := encode(swaps); !bytes.Equal(got, want) {
+			t.Fatalf("run %d produced different result bytes", run)
+		}
+	}
+}
+
+func TestExpectedProfit(t *testing.T) {
+	p := DefaultProfitParams()
+
+	// $3 of fees plus $0.20 of arb per block, at $2000/ETH
+	if got := ExpectedProfit(p); got.Cmp(big.NewInt(1600000000000000)) != 0 {
+		t.Errorf("ExpectedProfit() = %s, want 1600000000000000", got)
+	}
+	if got := ProfitableRent(p, big.NewInt(1000)); got.Int64() != 800 {
+		t.Errorf("ProfitableRent() = %s, want 800", got)
+	}
+
+	p.ETHPriceUSD = 0
+	if got := ExpectedProfit(p); got.Sign() != 0 {
+		t.Errorf("expected zero profit without an ETH price, got %s", got)
+	}
+}
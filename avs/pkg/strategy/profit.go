@@ -0,0 +1,59 @@
+package strategy
+
+import "math/big"
+
+// ProfitParams describe the market the manager expects to trade in. They
+// are per-operator judgement, not consensus inputs.
+type ProfitParams struct {
+	SwapVolumeUSD uint64 `yaml:"swapVolumeUsd"` // swap volume per block, in USD
+	ETHPriceUSD   uint64 `yaml:"ethPriceUsd"`
+	FeeBps        uint64 `yaml:"feeBps"`        // average fee earned on volume
+	VolatilityBps uint64 `yaml:"volatilityBps"` // price volatility available to arbitrage
+	ArbCaptureBps uint64 `yaml:"arbCaptureBps"` // share of volatility * volume captured as arb profit
+	BidShareBps   uint64 `yaml:"bidShareBps"`   // share of expected profit to offer as rent
+}
+
+// DefaultProfitParams reproduce the original mock market: $1000 per block at
+// 0.3%, 2% volatility with 1% captured, ETH at $2000, bidding 80%
+func DefaultProfitParams() ProfitParams {
+	return ProfitParams{
+		SwapVolumeUSD: 1000,
+		ETHPriceUSD:   2000,
+		FeeBps:        30,
+		VolatilityBps: 200,
+		ArbCaptureBps: 100,
+		BidShareBps:   8000,
+	}
+}
+
+var (
+	bps       = big.NewInt(10000)
+	weiPerETH = big.NewInt(1e18)
+)
+
+// ExpectedProfit estimates the manager's profit in wei per block: fee
+// revenue on volume plus arbitrage on volatility, converted at ETHPriceUSD
+func ExpectedProfit(p ProfitParams) *big.Int {
+	if p.ETHPriceUSD == 0 {
+		return new(big.Int)
+	}
+
+	// volume * (fee/1e4 + volatility/1e4 * capture/1e4), kept over 1e8
+	rate := new(big.Int).SetUint64(p.FeeBps)
+	rate.Mul(rate, bps)
+	rate.Add(rate, new(big.Int).Mul(new(big.Int).SetUint64(p.VolatilityBps), new(big.Int).SetUint64(p.ArbCaptureBps)))
+
+	profit := new(big.Int).SetUint64(p.SwapVolumeUSD)
+	profit.Mul(profit, rate)
+	profit.Mul(profit, weiPerETH)
+
+	denominator := new(big.Int).Mul(bps, bps)
+	denominator.Mul(denominator, new(big.Int).SetUint64(p.ETHPriceUSD))
+	return profit.Div(profit, denominator)
+}
+
+// ProfitableRent is the most rent per block worth paying for profit
+func ProfitableRent(p ProfitParams, profit *big.Int) *big.Int {
+	rent := new(big.Int).Mul(profit, new(big.Int).SetUint64(p.BidShareBps))
+	return rent.Div(rent, bps)
+}
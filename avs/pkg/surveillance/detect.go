@@ -0,0 +1,312 @@
+// Package surveillance looks for a pool manager abusing its position. The
+// manager sets the swap fee and pays none itself, which makes two patterns
+// profitable: raising the fee around large user swaps, and sandwiching user
+// swaps with its own fee-free swaps.
+package surveillance
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// FeeSpikeWindow is how many blocks after a raise the fee must come back
+	// down for the raise to count as a spike
+	FeeSpikeWindow = 3
+
+	// LargeSwapMultiple: a user swap is large when it moves at least this
+	// many times the mean user swap size
+	LargeSwapMultiple = 2
+
+	// minSample is the fewest user swaps needed to judge what "large" means;
+	// below it every user swap is treated as large
+	minSample = 5
+)
+
+// FindingKind classifies suspicious patterns
+type FindingKind uint8
+
+const (
+	FeeSpike FindingKind = iota + 1 // fee raised before a large user swap, lowered right after
+	Sandwich                        // manager swaps directly around a user swap
+)
+
+func (k FindingKind) String() string {
+	switch k {
+	case FeeSpike:
+		return "fee_spike"
+	case Sandwich:
+		return "sandwich"
+	}
+	return "unknown"
+}
+
+// FeeChange is a FeeUpdated event
+type FeeChange struct {
+	Block    uint64
+	LogIndex uint
+	TxHash   common.Hash
+	Fee      uint32
+}
+
+// ManagerChange is a ManagerChanged event
+type ManagerChange struct {
+	Block    uint64
+	LogIndex uint
+	Manager  common.Address
+}
+
+// Input is everything the analysis looks at for one pool
+type Input struct {
+	// StartManager and StartFee are the state before the first event
+	StartManager common.Address
+	StartFee     uint32
+
+	Swaps    []*poolmanager.Swap
+	Fees     []FeeChange
+	Managers []ManagerChange
+}
+
+// Finding is one piece of evidence. For a FeeSpike, FrontTx raised the fee
+// and BackTx lowered it; for a Sandwich both are the manager's swaps.
+type Finding struct {
+	Kind          FindingKind
+	Block         uint64
+	Manager       common.Address
+	FrontTx       common.Hash
+	VictimTx      common.Hash
+	BackTx        common.Hash
+	FeeBefore     uint32
+	FeeDuring     uint32
+	VictimAmount0 *big.Int
+}
+
+// Report is the outcome of an analysis
+type Report struct {
+	SwapsAnalyzed uint32
+	ManagerSwaps  uint32
+	Findings      []Finding
+}
+
+type itemKind uint8
+
+const (
+	itemManager itemKind = iota
+	itemFee
+	itemSwap
+)
+
+// item is one event on the merged timeline
+type item struct {
+	kind     itemKind
+	block    uint64
+	logIndex uint
+
+	swap    *poolmanager.Swap
+	fee     FeeChange
+	manager ManagerChange
+
+	// Filled in while walking the timeline
+	byManager bool
+	managerAt common.Address
+	feeAt     uint32
+}
+
+// spike is a fee raise waiting to see whether it comes back down
+type spike struct {
+	raise   FeeChange
+	before  uint32
+	manager common.Address
+	victim  *poolmanager.Swap
+}
+
+// Analyze walks the pool's events in chain order and reports suspicious
+// patterns. It is deterministic: the same input in any order gives the same
+// report.
+func Analyze(in Input) Report {
+	timeline := merge(in)
+
+	var report Report
+	manager, fee := in.StartManager, in.StartFee
+
+	// Tag every swap with who made it and the fee in force
+	for _, it := range timeline {
+		switch it.kind {
+		case itemManager:
+			manager = it.manager.Manager
+		case itemFee:
+			fee = it.fee.Fee
+		case itemSwap:
+			it.managerAt, it.feeAt = manager, fee
+			// The manager is charged nothing, so a zero-fee swap under a
+			// non-zero fee is the manager's even when routed via a contract
+			it.byManager = manager != (common.Address{}) &&
+				(it.swap.Sender == manager || (it.swap.Fee == 0 && fee > 0))
+			report.SwapsAnalyzed++
+			if it.byManager {
+				report.ManagerSwaps++
+			}
+		}
+	}
+
+	isLarge := largeSwapRule(timeline)
+	report.Findings = append(report.Findings, feeSpikes(in, timeline, isLarge)...)
+	report.Findings = append(report.Findings, sandwiches(timeline)...)
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		if report.Findings[i].Block != report.Findings[j].Block {
+			return report.Findings[i].Block < report.Findings[j].Block
+		}
+		return report.Findings[i].Kind < report.Findings[j].Kind
+	})
+	return report
+}
+
+func merge(in Input) []*item {
+	timeline := make([]*item, 0, len(in.Swaps)+len(in.Fees)+len(in.Managers))
+	for _, m := range in.Managers {
+		timeline = append(timeline, &item{kind: itemManager, block: m.Block, logIndex: m.LogIndex, manager: m})
+	}
+	for _, f := range in.Fees {
+		timeline = append(timeline, &item{kind: itemFee, block: f.Block, logIndex: f.LogIndex, fee: f})
+	}
+	for _, s := range in.Swaps {
+		timeline = append(timeline, &item{kind: itemSwap, block: s.BlockNumber, logIndex: s.LogIndex, swap: s})
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		if timeline[i].block != timeline[j].block {
+			return timeline[i].block < timeline[j].block
+		}
+		if timeline[i].logIndex != timeline[j].logIndex {
+			return timeline[i].logIndex < timeline[j].logIndex
+		}
+		return timeline[i].kind < timeline[j].kind
+	})
+	return timeline
+}
+
+// largeSwapRule returns a predicate for user swaps that are large relative
+// to the other user swaps in the range
+func largeSwapRule(timeline []*item) func(*poolmanager.Swap) bool {
+	sum := new(big.Int)
+	var n int64
+	for _, it := range timeline {
+		if it.kind == itemSwap && !it.byManager {
+			sum.Add(sum, new(big.Int).Abs(it.swap.Amount0))
+			n++
+		}
+	}
+
+	return func(s *poolmanager.Swap) bool {
+		if n < minSample {
+			return true
+		}
+		// |amount0| >= multiple * sum / n, without division
+		size := new(big.Int).Abs(s.Amount0)
+		size.Mul(size, big.NewInt(n))
+		return size.Cmp(new(big.Int).Mul(sum, big.NewInt(LargeSwapMultiple))) >= 0
+	}
+}
+
+func feeSpikes(in Input, timeline []*item, isLarge func(*poolmanager.Swap) bool) []Finding {
+	var findings []Finding
+	var open *spike
+	manager, fee := in.StartManager, in.StartFee
+
+	for _, it := range timeline {
+		if open != nil && it.block > open.raise.Block+FeeSpikeWindow {
+			open = nil
+		}
+
+		switch it.kind {
+		case itemManager:
+			manager, open = it.manager.Manager, nil
+
+		case itemFee:
+			switch {
+			case it.fee.Fee > fee:
+				open = &spike{raise: it.fee, before: fee, manager: manager}
+			case it.fee.Fee < fee && open != nil:
+				if open.victim != nil {
+					findings = append(findings, Finding{
+						Kind:          FeeSpike,
+						Block:         open.raise.Block,
+						Manager:       open.manager,
+						FrontTx:       open.raise.TxHash,
+						VictimTx:      open.victim.TxHash,
+						BackTx:        it.fee.TxHash,
+						FeeBefore:     open.before,
+						FeeDuring:     open.raise.Fee,
+						VictimAmount0: new(big.Int).Set(open.victim.Amount0),
+					})
+				}
+				open = nil
+			}
+			fee = it.fee.Fee
+
+		case itemSwap:
+			if open == nil || it.byManager || !isLarge(it.swap) {
+				continue
+			}
+			if open.victim == nil || new(big.Int).Abs(it.swap.Amount0).Cmp(new(big.Int).Abs(open.victim.Amount0)) > 0 {
+				open.victim = it.swap
+			}
+		}
+	}
+	return findings
+}
+
+func sandwiches(timeline []*item) []Finding {
+	var findings []Finding
+
+	for i, victim := range timeline {
+		if victim.kind != itemSwap || victim.byManager || victim.swap.Amount0.Sign() == 0 {
+			continue
+		}
+
+		front := adjacentManagerSwap(timeline, i, -1)
+		back := adjacentManagerSwap(timeline, i, +1)
+		if front == nil || back == nil {
+			continue
+		}
+
+		// Front-run in the victim's direction, back-run in the opposite one
+		dir := victim.swap.Amount0.Sign()
+		if front.swap.Amount0.Sign() != dir || back.swap.Amount0.Sign() != -dir {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Kind:          Sandwich,
+			Block:         victim.block,
+			Manager:       victim.managerAt,
+			FrontTx:       front.swap.TxHash,
+			VictimTx:      victim.swap.TxHash,
+			BackTx:        back.swap.TxHash,
+			FeeBefore:     victim.feeAt,
+			FeeDuring:     victim.feeAt,
+			VictimAmount0: new(big.Int).Set(victim.swap.Amount0),
+		})
+	}
+	return findings
+}
+
+// adjacentManagerSwap finds the nearest swap to timeline[i] in direction
+// step within the same block, returning it only if the manager made it
+func adjacentManagerSwap(timeline []*item, i, step int) *item {
+	block := timeline[i].block
+	for j := i + step; j >= 0 && j < len(timeline) && timeline[j].block == block; j += step {
+		if timeline[j].kind != itemSwap {
+			continue
+		}
+		if timeline[j].byManager {
+			return timeline[j]
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package surveillance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	manager = common.HexToAddress("0xbad")
+	router  = common.HexToAddress("0x7007")
+)
+
+func swap(block uint64, index uint, sender common.Address, amount0 int64, fee uint32) *poolmanager.Swap {
+	return &poolmanager.Swap{
+		Sender:      sender,
+		Amount0:     big.NewInt(amount0),
+		Fee:         fee,
+		BlockNumber: block,
+		LogIndex:    index,
+		TxHash:      common.BigToHash(big.NewInt(int64(block*1000) + int64(index))),
+	}
+}
+
+func TestAnalyzeFeeSpike(t *testing.T) {
+	in := Input{
+		StartManager: manager,
+		StartFee:     3000,
+		Swaps: []*poolmanager.Swap{
+			swap(100, 0, router, 10, 3000),
+			swap(101, 0, router, -12, 3000),
+			swap(102, 0, router, 8, 3000),
+			swap(103, 0, router, 11, 3000),
+			swap(105, 1, router, -500, 10000), // the victim, at the raised fee
+			swap(107, 0, router, 9, 3000),
+		},
+		Fees: []FeeChange{
+			{Block: 105, LogIndex: 0, TxHash: common.HexToHash("0x01"), Fee: 10000},
+			{Block: 106, LogIndex: 0, TxHash: common.HexToHash("0x02"), Fee: 3000},
+		},
+	}
+
+	report := Analyze(in)
+	if report.SwapsAnalyzed != 6 || report.ManagerSwaps != 0 {
+		t.Errorf("swap counts = %d/%d, want 6/0", report.SwapsAnalyzed, report.ManagerSwaps)
+	}
+	if len(report.Findings) != 1 {
+		t.Fatalf("expected one finding, got %+v", report.Findings)
+	}
+
+	f := report.Findings[0]
+	if f.Kind != FeeSpike || f.Block != 105 || f.FeeBefore != 3000 || f.FeeDuring != 10000 {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+	if f.VictimAmount0.Int64() != -500 || f.FrontTx != common.HexToHash("0x01") || f.BackTx != common.HexToHash("0x02") {
+		t.Errorf("wrong evidence: %+v", f)
+	}
+
+	// A raise that stays in place is a fee change, not a spike
+	in.Fees = in.Fees[:1]
+	if report := Analyze(in); len(report.Findings) != 0 {
+		t.Errorf("expected no finding for a lasting raise, got %+v", report.Findings)
+	}
+}
+
+func TestAnalyzeSandwich(t *testing.T) {
+	in := Input{
+		StartManager: manager,
+		StartFee:     3000,
+		Swaps: []*poolmanager.Swap{
+			// Manager swaps are recognised by sender, or by paying no fee
+			swap(200, 2, manager, 50, 0),
+			swap(200, 3, router, 40, 3000),
+			swap(200, 4, router, -50, 0),
+			// Same shape, but the back-run is in the victim's direction
+			swap(201, 0, manager, 50, 0),
+			swap(201, 1, router, 40, 3000),
+			swap(201, 2, manager, 10, 0),
+		},
+		Managers: []ManagerChange{{Block: 200, LogIndex: 0, Manager: manager}},
+	}
+
+	report := Analyze(in)
+	if report.ManagerSwaps != 4 {
+		t.Errorf("manager swaps = %d, want 4", report.ManagerSwaps)
+	}
+	if len(report.Findings) != 1 {
+		t.Fatalf("expected one finding, got %+v", report.Findings)
+	}
+	if f := report.Findings[0]; f.Kind != Sandwich || f.Block != 200 || f.Manager != manager || f.VictimAmount0.Int64() != 40 {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
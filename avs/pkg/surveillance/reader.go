@@ -0,0 +1,94 @@
+package surveillance
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/auctionpoolhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Backend is what the reader needs from an L2 client
+type Backend interface {
+	bind.ContractCaller
+	bind.BlockHashContractCaller
+	ethereum.LogFilterer
+}
+
+// Read gathers the analysis input for poolId over [from, to]. start is the
+// hash of block from-1, whose state the range opens with; it is unused when
+// from is 0.
+func Read(ctx context.Context, backend Backend, hook, poolManager common.Address, poolId common.Hash, start common.Hash, from, to uint64) (Input, error) {
+	caller, err := auctionpoolhook.NewAuctionPoolHookCaller(hook, backend)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to bind AuctionPoolHook: %w", err)
+	}
+	filterer, err := auctionpoolhook.NewAuctionPoolHookFilterer(hook, backend)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to bind AuctionPoolHook: %w", err)
+	}
+	parsed, err := auctionpoolhook.AuctionPoolHookMetaData.GetAbi()
+	if err != nil {
+		return Input{}, err
+	}
+
+	var in Input
+
+	if from > 0 {
+		auction, err := caller.PoolAuctions(&bind.CallOpts{Context: ctx, BlockHash: start}, poolId)
+		if err != nil {
+			return Input{}, fmt.Errorf("failed to get pool auction at %d: %w", from-1, err)
+		}
+		in.StartManager, in.StartFee = auction.CurrentManager, uint32(auction.CurrentFee.Uint64())
+	}
+
+	feeUpdated, managerChanged := parsed.Events["FeeUpdated"].ID, parsed.Events["ManagerChanged"].ID
+	logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{hook},
+		Topics:    [][]common.Hash{{feeUpdated, managerChanged}, {poolId}},
+	})
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to filter hook events: %w", err)
+	}
+
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		switch log.Topics[0] {
+		case feeUpdated:
+			ev, err := filterer.ParseFeeUpdated(log)
+			if err != nil {
+				return Input{}, err
+			}
+			in.Fees = append(in.Fees, FeeChange{
+				Block:    log.BlockNumber,
+				LogIndex: log.Index,
+				TxHash:   log.TxHash,
+				Fee:      uint32(ev.NewFee.Uint64()),
+			})
+
+		case managerChanged:
+			ev, err := filterer.ParseManagerChanged(log)
+			if err != nil {
+				return Input{}, err
+			}
+			in.Managers = append(in.Managers, ManagerChange{
+				Block:    log.BlockNumber,
+				LogIndex: log.Index,
+				Manager:  ev.NewManager,
+			})
+		}
+	}
+
+	if in.Swaps, err = poolmanager.Swaps(ctx, backend, poolManager, poolId, from, to); err != nil {
+		return Input{}, err
+	}
+	return in, nil
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FeeDetail is the Result.Detail of a recommend_fee task: the swap window the
+// fee was derived from
+type FeeDetail struct {
+	WindowStart uint64
+	Swaps       uint32
+	MinTick     int32
+	MaxTick     int32
+}
+
+var feeDetailArgs = newArguments(
+	"windowStart", "uint64",
+	"swaps", "uint32",
+	"minTick", "int24",
+	"maxTick", "int24",
+)
+
+// EncodeFeeDetail encodes d for Result.Detail
+func EncodeFeeDetail(d FeeDetail) ([]byte, error) {
+	out, err := feeDetailArgs.Pack(d.WindowStart, d.Swaps, big.NewInt(int64(d.MinTick)), big.NewInt(int64(d.MaxTick)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fee detail: %w", err)
+	}
+	return out, nil
+}
+
+// DecodeFeeDetail parses the Detail of a recommend_fee result
+func DecodeFeeDetail(detail []byte) (FeeDetail, error) {
+	values, err := feeDetailArgs.Unpack(detail)
+	if err != nil {
+		return FeeDetail{}, fmt.Errorf("%w: fee detail: %v", ErrMalformed, err)
+	}
+	return FeeDetail{
+		WindowStart: values[0].(uint64),
+		Swaps:       values[1].(uint32),
+		MinTick:     int32(values[2].(*big.Int).Int64()),
+		MaxTick:     int32(values[3].(*big.Int).Int64()),
+	}, nil
+}
+
+// RentDetail is the Result.Detail of an attest_rent task: the replayed rent
+// accounting over [FromBlock, ToBlock] next to what the hook reports
+type RentDetail struct {
+	FromBlock           uint64
+	ToBlock             uint64
+	StartAccumulated    *big.Int
+	ExpectedAccumulated *big.Int
+	OnChainAccumulated  *big.Int
+	ExpectedTotalShares *big.Int
+	OnChainTotalShares  *big.Int
+	ExpectedRentPaid    *big.Int
+	OnChainRentPaid     *big.Int
+	Collected           *big.Int
+	Distributed         *big.Int
+	Undistributed       *big.Int
+	ManagerChanges      uint32
+}
+
+var rentDetailArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+	{Name: "fromBlock", Type: "uint64"},
+	{Name: "toBlock", Type: "uint64"},
+	{Name: "startAccumulated", Type: "uint256"},
+	{Name: "expectedAccumulated", Type: "uint256"},
+	{Name: "onChainAccumulated", Type: "uint256"},
+	{Name: "expectedTotalShares", Type: "uint256"},
+	{Name: "onChainTotalShares", Type: "uint256"},
+	{Name: "expectedRentPaid", Type: "uint256"},
+	{Name: "onChainRentPaid", Type: "uint256"},
+	{Name: "collected", Type: "uint256"},
+	{Name: "distributed", Type: "uint256"},
+	{Name: "undistributed", Type: "uint256"},
+	{Name: "managerChanges", Type: "uint32"},
+})}}
+
+// EncodeRentDetail encodes d for Result.Detail
+func EncodeRentDetail(d RentDetail) ([]byte, error) {
+	w := d
+	for _, v := range []**big.Int{
+		&w.StartAccumulated, &w.ExpectedAccumulated, &w.OnChainAccumulated,
+		&w.ExpectedTotalShares, &w.OnChainTotalShares, &w.ExpectedRentPaid,
+		&w.OnChainRentPaid, &w.Collected, &w.Distributed, &w.Undistributed,
+	} {
+		*v = bigOrZero(*v)
+	}
+
+	out, err := rentDetailArgs.Pack(w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rent detail: %w", err)
+	}
+	return out, nil
+}
+
+// DecodeRentDetail parses the Detail of an attest_rent result
+func DecodeRentDetail(detail []byte) (RentDetail, error) {
+	values, err := rentDetailArgs.Unpack(detail)
+	if err != nil {
+		return RentDetail{}, fmt.Errorf("%w: rent detail: %v", ErrMalformed, err)
+	}
+	return *abi.ConvertType(values[0], new(RentDetail)).(*RentDetail), nil
+}
+
+// Evidence is one suspicious pattern in an EvidenceDetail. Kind is 1 for a
+// fee spike (FrontTx raised the fee, BackTx lowered it) and 2 for a sandwich
+// (FrontTx and BackTx are the manager's swaps).
+type Evidence struct {
+	Kind          uint8
+	Block         uint64
+	Manager       common.Address
+	FrontTx       [32]byte
+	VictimTx      [32]byte
+	BackTx        [32]byte
+	FeeBefore     *big.Int
+	FeeDuring     *big.Int
+	VictimAmount0 *big.Int
+}
+
+// EvidenceDetail is the Result.Detail of a surveil_manager task
+type EvidenceDetail struct {
+	FromBlock     uint64
+	ToBlock       uint64
+	SwapsAnalyzed uint32
+	ManagerSwaps  uint32
+	Findings      []Evidence
+}
+
+var evidenceDetailArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+	{Name: "fromBlock", Type: "uint64"},
+	{Name: "toBlock", Type: "uint64"},
+	{Name: "swapsAnalyzed", Type: "uint32"},
+	{Name: "managerSwaps", Type: "uint32"},
+	{Name: "findings", Type: "tuple[]", Components: []abi.ArgumentMarshaling{
+		{Name: "kind", Type: "uint8"},
+		{Name: "block", Type: "uint64"},
+		{Name: "manager", Type: "address"},
+		{Name: "frontTx", Type: "bytes32"},
+		{Name: "victimTx", Type: "bytes32"},
+		{Name: "backTx", Type: "bytes32"},
+		{Name: "feeBefore", Type: "uint24"},
+		{Name: "feeDuring", Type: "uint24"},
+		{Name: "victimAmount0", Type: "int256"},
+	}},
+})}}
+
+// EncodeEvidenceDetail encodes d for Result.Detail
+func EncodeEvidenceDetail(d EvidenceDetail) ([]byte, error) {
+	w := d
+	w.Findings = make([]Evidence, len(d.Findings))
+	for i, e := range d.Findings {
+		e.FeeBefore, e.FeeDuring, e.VictimAmount0 = bigOrZero(e.FeeBefore), bigOrZero(e.FeeDuring), bigOrZero(e.VictimAmount0)
+		w.Findings[i] = e
+	}
+
+	out, err := evidenceDetailArgs.Pack(w)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode evidence detail: %w", err)
+	}
+	return out, nil
+}
+
+// DecodeEvidenceDetail parses the Detail of a surveil_manager result
+func DecodeEvidenceDetail(detail []byte) (EvidenceDetail, error) {
+	values, err := evidenceDetailArgs.Unpack(detail)
+	if err != nil {
+		return EvidenceDetail{}, fmt.Errorf("%w: evidence detail: %v", ErrMalformed, err)
+	}
+	return *abi.ConvertType(values[0], new(EvidenceDetail)).(*EvidenceDetail), nil
+}
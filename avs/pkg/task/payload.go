@@ -0,0 +1,331 @@
+// Package task defines the wire format of AuctionPool AVS tasks. Task
+// creators encode payloads with it and performers decode and validate them.
+// The encoding is plain ABI so AuctionPoolTaskHook can decode the same bytes
+// on-chain with abi.decode(payload, (AuctionPoolTaskPayload.Payload)).
+package task
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Version is the payload schema version this package encodes
+const Version uint8 = 1
+
+// Type selects what a performer is asked to do
+type Type uint8
+
+const (
+	TypeEvaluatePool   Type = iota + 1 // decide whether to bid or update the fee
+	TypeRecommendFee                   // recommend a swap fee for the pool
+	TypeAttestRent                     // attest the hook's rent accounting over a block range
+	TypePriceBid                       // price a proposed bid against pool state
+	TypeSurveilManager                 // look for manager fee abuse and sandwiches over a block range
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeEvaluatePool:
+		return "evaluate_pool"
+	case TypeRecommendFee:
+		return "recommend_fee"
+	case TypeAttestRent:
+		return "attest_rent"
+	case TypePriceBid:
+		return "price_bid"
+	case TypeSurveilManager:
+		return "surveil_manager"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// minDepositBlocks mirrors MIN_DEPOSIT_BLOCKS in AuctionPoolHook
+const minDepositBlocks = 100
+
+var (
+	ErrMalformed          = errors.New("malformed task payload")
+	ErrUnsupportedVersion = errors.New("unsupported payload version")
+	ErrUnsupportedType    = errors.New("unsupported task type")
+	ErrInvalidPool        = errors.New("invalid pool")
+	ErrStale              = errors.New("stale task")
+	ErrFutureBlock        = errors.New("reference block is in the future")
+	ErrInvalidParams      = errors.New("invalid task parameters")
+)
+
+// PoolKey is a Uniswap v4 pool key. Fee is uint24 and TickSpacing int24.
+type PoolKey struct {
+	Currency0   common.Address
+	Currency1   common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Hooks       common.Address
+}
+
+// IsZero reports whether no pool key was given. Currency0 is not checked
+// since it is the zero address for native ETH pools.
+func (k PoolKey) IsZero() bool {
+	return k.Currency1 == (common.Address{}) && k.Hooks == (common.Address{})
+}
+
+// ID computes the v4 PoolId, keccak256(abi.encode(key))
+func (k PoolKey) ID() common.Hash {
+	packed, err := poolKeyArgs.Pack(k.Currency0, k.Currency1, bigOrZero(k.Fee), bigOrZero(k.TickSpacing), k.Hooks)
+	if err != nil {
+		// Only reachable with out-of-range fee or tick spacing
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(packed)
+}
+
+// Payload is the decoded form of TaskRequest.Payload
+type Payload struct {
+	Version uint8
+	Type    Type
+	// PoolId identifies the pool; it may be left zero when PoolKey is set
+	PoolId  common.Hash
+	PoolKey PoolKey
+	// ReferenceBlock is the L2 block all state must be read at
+	ReferenceBlock uint64
+	// Params is the ABI-encoded, type-specific parameter block
+	Params []byte
+}
+
+// Pool returns the pool the task refers to, derived from PoolKey if needed
+func (p *Payload) Pool() common.Hash {
+	if p.PoolId == (common.Hash{}) && !p.PoolKey.IsZero() {
+		return p.PoolKey.ID()
+	}
+	return p.PoolId
+}
+
+// wirePayload mirrors the Solidity struct field for field
+type wirePayload struct {
+	Version        uint8
+	TaskType       uint8
+	PoolId         [32]byte
+	PoolKey        PoolKey
+	ReferenceBlock uint64
+	Params         []byte
+}
+
+var (
+	poolKeyComponents = []abi.ArgumentMarshaling{
+		{Name: "currency0", Type: "address"},
+		{Name: "currency1", Type: "address"},
+		{Name: "fee", Type: "uint24"},
+		{Name: "tickSpacing", Type: "int24"},
+		{Name: "hooks", Type: "address"},
+	}
+
+	payloadArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+		{Name: "version", Type: "uint8"},
+		{Name: "taskType", Type: "uint8"},
+		{Name: "poolId", Type: "bytes32"},
+		{Name: "poolKey", Type: "tuple", Components: poolKeyComponents},
+		{Name: "referenceBlock", Type: "uint64"},
+		{Name: "params", Type: "bytes"},
+	})}}
+
+	poolKeyArgs = abi.Arguments{
+		{Type: mustType("address", nil)},
+		{Type: mustType("address", nil)},
+		{Type: mustType("uint24", nil)},
+		{Type: mustType("int24", nil)},
+		{Type: mustType("address", nil)},
+	}
+
+	rangeArgs = abi.Arguments{{Name: "fromBlock", Type: mustType("uint64", nil)}}
+
+	priceBidArgs = abi.Arguments{
+		{Name: "rentPerBlock", Type: mustType("uint256", nil)},
+		{Name: "deposit", Type: mustType("uint256", nil)},
+	}
+)
+
+func mustType(t string, components []abi.ArgumentMarshaling) abi.Type {
+	typ, err := abi.NewType(t, "", components)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// newArguments builds flat arguments from name, type pairs
+func newArguments(pairs ...string) abi.Arguments {
+	args := make(abi.Arguments, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		args = append(args, abi.Argument{Name: pairs[i], Type: mustType(pairs[i+1], nil)})
+	}
+	return args
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
+// Encode ABI-encodes p. A zero Version is encoded as the current Version.
+func Encode(p *Payload) ([]byte, error) {
+	version := p.Version
+	if version == 0 {
+		version = Version
+	}
+
+	key := p.PoolKey
+	key.Fee, key.TickSpacing = bigOrZero(key.Fee), bigOrZero(key.TickSpacing)
+
+	params := p.Params
+	if params == nil {
+		params = []byte{}
+	}
+
+	out, err := payloadArgs.Pack(wirePayload{
+		Version:        version,
+		TaskType:       uint8(p.Type),
+		PoolId:         p.PoolId,
+		PoolKey:        key,
+		ReferenceBlock: p.ReferenceBlock,
+		Params:         params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task payload: %w", err)
+	}
+	return out, nil
+}
+
+// Decode parses an ABI-encoded payload. It only checks the encoding; use
+// Validate to check the contents.
+func Decode(data []byte) (*Payload, error) {
+	values, err := payloadArgs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	w := *abi.ConvertType(values[0], new(wirePayload)).(*wirePayload)
+
+	// Reject trailing bytes so every payload has exactly one encoding
+	if canonical, err := payloadArgs.Pack(w); err != nil || len(canonical) != len(data) {
+		return nil, fmt.Errorf("%w: %d bytes is not a canonical encoding", ErrMalformed, len(data))
+	}
+
+	return &Payload{
+		Version:        w.Version,
+		Type:           Type(w.TaskType),
+		PoolId:         w.PoolId,
+		PoolKey:        w.PoolKey,
+		ReferenceBlock: w.ReferenceBlock,
+		Params:         w.Params,
+	}, nil
+}
+
+// Validate checks p against the current head. Tasks whose reference block is
+// more than maxAge blocks behind head are stale.
+func Validate(p *Payload, head, maxAge uint64) error {
+	if p.Version != Version {
+		return fmt.Errorf("%w: got %d, want %d", ErrUnsupportedVersion, p.Version, Version)
+	}
+
+	switch p.Type {
+	case TypeEvaluatePool, TypeRecommendFee, TypeAttestRent, TypePriceBid, TypeSurveilManager:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, p.Type)
+	}
+
+	if p.PoolId == (common.Hash{}) && p.PoolKey.IsZero() {
+		return fmt.Errorf("%w: neither pool ID nor pool key given", ErrInvalidPool)
+	}
+	if !p.PoolKey.IsZero() && p.PoolId != (common.Hash{}) && p.PoolKey.ID() != p.PoolId {
+		return fmt.Errorf("%w: pool ID %s does not match pool key (%s)", ErrInvalidPool, p.PoolId.Hex(), p.PoolKey.ID().Hex())
+	}
+
+	if p.ReferenceBlock == 0 {
+		return fmt.Errorf("%w: reference block not set", ErrInvalidParams)
+	}
+	if p.ReferenceBlock > head {
+		return fmt.Errorf("%w: block %d, head %d", ErrFutureBlock, p.ReferenceBlock, head)
+	}
+	if head-p.ReferenceBlock > maxAge {
+		return fmt.Errorf("%w: block %d is %d blocks behind head, limit %d", ErrStale, p.ReferenceBlock, head-p.ReferenceBlock, maxAge)
+	}
+
+	return validateParams(p)
+}
+
+func validateParams(p *Payload) error {
+	switch p.Type {
+	case TypeEvaluatePool, TypeRecommendFee:
+		if len(p.Params) != 0 {
+			return fmt.Errorf("%w: %s takes no parameters", ErrInvalidParams, p.Type)
+		}
+
+	case TypeAttestRent, TypeSurveilManager:
+		from, err := DecodeRangeParams(p.Params)
+		if err != nil {
+			return err
+		}
+		if from > p.ReferenceBlock {
+			return fmt.Errorf("%w: range starts at %d, after reference block %d", ErrInvalidParams, from, p.ReferenceBlock)
+		}
+
+	case TypePriceBid:
+		bid, err := DecodePriceBidParams(p.Params)
+		if err != nil {
+			return err
+		}
+		if bid.RentPerBlock.Sign() == 0 {
+			return fmt.Errorf("%w: rent per block is zero", ErrInvalidParams)
+		}
+		minDeposit := new(big.Int).Mul(bid.RentPerBlock, big.NewInt(minDepositBlocks))
+		if bid.Deposit.Cmp(minDeposit) < 0 {
+			return fmt.Errorf("%w: deposit %s below %d blocks of rent", ErrInvalidParams, bid.Deposit, minDepositBlocks)
+		}
+	}
+	return nil
+}
+
+// EncodeRangeParams encodes the start of the block range an attest_rent or
+// surveil_manager task covers. The range ends at the reference block.
+func EncodeRangeParams(fromBlock uint64) []byte {
+	out, _ := rangeArgs.Pack(fromBlock)
+	return out
+}
+
+// DecodeRangeParams returns the start of a task's block range
+func DecodeRangeParams(params []byte) (uint64, error) {
+	values, err := rangeArgs.Unpack(params)
+	if err != nil {
+		return 0, fmt.Errorf("%w: block range: %v", ErrInvalidParams, err)
+	}
+	return values[0].(uint64), nil
+}
+
+// PriceBidParams is the bid a price_bid task asks about
+type PriceBidParams struct {
+	RentPerBlock *big.Int
+	Deposit      *big.Int
+}
+
+// EncodePriceBidParams encodes the bid to price
+func EncodePriceBidParams(bid PriceBidParams) ([]byte, error) {
+	out, err := priceBidArgs.Pack(bigOrZero(bid.RentPerBlock), bigOrZero(bid.Deposit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode price_bid params: %w", err)
+	}
+	return out, nil
+}
+
+// DecodePriceBidParams returns the bid a price_bid task asks about
+func DecodePriceBidParams(params []byte) (PriceBidParams, error) {
+	values, err := priceBidArgs.Unpack(params)
+	if err != nil {
+		return PriceBidParams{}, fmt.Errorf("%w: price_bid: %v", ErrInvalidParams, err)
+	}
+	return PriceBidParams{RentPerBlock: values[0].(*big.Int), Deposit: values[1].(*big.Int)}, nil
+}
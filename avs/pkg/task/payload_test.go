@@ -0,0 +1,107 @@
+package task
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var testKey = PoolKey{
+	Currency0:   common.HexToAddress("0x0000000000000000000000000000000000000001"),
+	Currency1:   common.HexToAddress("0x0000000000000000000000000000000000000002"),
+	Fee:         big.NewInt(0x800000), // dynamic fee flag
+	TickSpacing: big.NewInt(-60),
+	Hooks:       common.HexToAddress("0x00000000000000000000000000000000000000c0"),
+}
+
+func TestPoolKeyID(t *testing.T) {
+	// abi.encode lays the five fields out as 32-byte words; int24 is sign-extended
+	var words []byte
+	words = append(words, common.LeftPadBytes(testKey.Currency0.Bytes(), 32)...)
+	words = append(words, common.LeftPadBytes(testKey.Currency1.Bytes(), 32)...)
+	words = append(words, math.U256Bytes(big.NewInt(0x800000))...)
+	words = append(words, math.U256Bytes(big.NewInt(-60))...)
+	words = append(words, common.LeftPadBytes(testKey.Hooks.Bytes(), 32)...)
+
+	if got, want := testKey.ID(), crypto.Keccak256Hash(words); got != want {
+		t.Fatalf("PoolKey.ID() = %s, want %s", got.Hex(), want.Hex())
+	}
+}
+
+func TestPayloadRoundTrip(t *testing.T) {
+	params, err := EncodePriceBidParams(PriceBidParams{RentPerBlock: big.NewInt(1000), Deposit: big.NewInt(100000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := &Payload{
+		Type:           TypePriceBid,
+		PoolKey:        testKey,
+		ReferenceBlock: 1234,
+		Params:         params,
+	}
+	data, err := Encode(in)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	out, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if out.Version != Version || out.Type != TypePriceBid || out.ReferenceBlock != 1234 {
+		t.Errorf("header fields not preserved: %+v", out)
+	}
+	if out.Pool() != testKey.ID() || out.PoolKey.TickSpacing.Int64() != -60 {
+		t.Errorf("pool key not preserved: %+v", out.PoolKey)
+	}
+	if !bytes.Equal(out.Params, params) {
+		t.Errorf("params not preserved")
+	}
+
+	if _, err := Decode(append(data, 0)); !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected trailing bytes to be rejected, got %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	poolId := common.HexToHash("0x01")
+	bid := func(rent, deposit int64) []byte {
+		out, _ := EncodePriceBidParams(PriceBidParams{RentPerBlock: big.NewInt(rent), Deposit: big.NewInt(deposit)})
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		payload Payload
+		want    error
+	}{
+		{"valid evaluate", Payload{Version: 1, Type: TypeEvaluatePool, PoolId: poolId, ReferenceBlock: 95}, nil},
+		{"valid attest", Payload{Version: 1, Type: TypeAttestRent, PoolKey: testKey, ReferenceBlock: 100, Params: EncodeRangeParams(10)}, nil},
+		{"valid bid", Payload{Version: 1, Type: TypePriceBid, PoolId: poolId, ReferenceBlock: 100, Params: bid(10, 1000)}, nil},
+		{"future version", Payload{Version: 2, Type: TypeEvaluatePool, PoolId: poolId, ReferenceBlock: 100}, ErrUnsupportedVersion},
+		{"unknown type", Payload{Version: 1, Type: 9, PoolId: poolId, ReferenceBlock: 100}, ErrUnsupportedType},
+		{"no pool", Payload{Version: 1, Type: TypeEvaluatePool, ReferenceBlock: 100}, ErrInvalidPool},
+		{"key and id disagree", Payload{Version: 1, Type: TypeEvaluatePool, PoolId: poolId, PoolKey: testKey, ReferenceBlock: 100}, ErrInvalidPool},
+		{"stale", Payload{Version: 1, Type: TypeEvaluatePool, PoolId: poolId, ReferenceBlock: 89}, ErrStale},
+		{"future block", Payload{Version: 1, Type: TypeEvaluatePool, PoolId: poolId, ReferenceBlock: 101}, ErrFutureBlock},
+		{"unexpected params", Payload{Version: 1, Type: TypeRecommendFee, PoolId: poolId, ReferenceBlock: 100, Params: []byte{1}}, ErrInvalidParams},
+		{"inverted range", Payload{Version: 1, Type: TypeAttestRent, PoolId: poolId, ReferenceBlock: 100, Params: EncodeRangeParams(101)}, ErrInvalidParams},
+		{"short deposit", Payload{Version: 1, Type: TypePriceBid, PoolId: poolId, ReferenceBlock: 100, Params: bid(10, 999)}, ErrInvalidParams},
+		{"garbage params", Payload{Version: 1, Type: TypePriceBid, PoolId: poolId, ReferenceBlock: 100, Params: []byte("bid")}, ErrInvalidParams},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.payload, 100, 10)
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package task
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Action is the outcome a performer decided on
+type Action uint8
+
+const (
+	ActionNone           Action = iota // nothing to do
+	ActionSubmitBid                    // bid RecommendedRent for the pool
+	ActionUpdateFee                    // set the swap fee to RecommendedFee
+	ActionBidAccepted                  // the priced bid would be accepted
+	ActionBidRejected                  // the priced bid would be rejected
+	ActionAttestMatch                  // the hook's rent accounting matches the replay
+	ActionAttestMismatch               // the hook's rent accounting diverges from the replay
+	ActionClean                        // no manager misbehavior found
+	ActionAbuseFlagged                 // suspicious manager behavior found, see Detail
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionNone:
+		return "no_action"
+	case ActionSubmitBid:
+		return "submit_bid"
+	case ActionUpdateFee:
+		return "update_fee"
+	case ActionBidAccepted:
+		return "bid_accepted"
+	case ActionBidRejected:
+		return "bid_rejected"
+	case ActionAttestMatch:
+		return "attest_match"
+	case ActionAttestMismatch:
+		return "attest_mismatch"
+	case ActionClean:
+		return "clean"
+	case ActionAbuseFlagged:
+		return "abuse_flagged"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(a))
+}
+
+// Inputs is the hook state a decision was based on
+type Inputs struct {
+	CurrentManager common.Address
+	RentPerBlock   *big.Int
+	ManagerDeposit *big.Int
+	CurrentFee     uint32
+	NextBidder     common.Address
+	NextRent       *big.Int
+	// ExpectedProfit is the performer's profit estimate in wei per block
+	ExpectedProfit *big.Int
+}
+
+// Hash is the state hash committed to in a Result, keccak256(abi.encode(inputs))
+func (in *Inputs) Hash() common.Hash {
+	packed, err := inputsArgs.Pack(in.wire())
+	if err != nil {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(packed)
+}
+
+// Result is the decoded form of TaskResponse.Result. It is what aggregated
+// operator signatures attest to.
+type Result struct {
+	Version        uint8
+	Type           Type
+	Action         Action
+	PoolId         common.Hash
+	ReferenceBlock uint64
+	// BlockHash pins the fork the inputs were read on
+	BlockHash       common.Hash
+	StateHash       common.Hash
+	RecommendedRent *big.Int
+	RecommendedFee  uint32
+	Inputs          Inputs
+	// Detail is an ABI-encoded, type-specific extension
+	Detail []byte
+}
+
+type wireInputs struct {
+	CurrentManager common.Address
+	RentPerBlock   *big.Int
+	ManagerDeposit *big.Int
+	CurrentFee     *big.Int
+	NextBidder     common.Address
+	NextRent       *big.Int
+	ExpectedProfit *big.Int
+}
+
+type wireResult struct {
+	Version         uint8
+	TaskType        uint8
+	Action          uint8
+	PoolId          [32]byte
+	ReferenceBlock  uint64
+	BlockHash       [32]byte
+	StateHash       [32]byte
+	RecommendedRent *big.Int
+	RecommendedFee  *big.Int
+	Inputs          wireInputs
+	Detail          []byte
+}
+
+var (
+	inputsComponents = []abi.ArgumentMarshaling{
+		{Name: "currentManager", Type: "address"},
+		{Name: "rentPerBlock", Type: "uint256"},
+		{Name: "managerDeposit", Type: "uint256"},
+		{Name: "currentFee", Type: "uint24"},
+		{Name: "nextBidder", Type: "address"},
+		{Name: "nextRent", Type: "uint256"},
+		{Name: "expectedProfit", Type: "uint256"},
+	}
+
+	inputsArgs = abi.Arguments{{Type: mustType("tuple", inputsComponents)}}
+
+	resultArgs = abi.Arguments{{Type: mustType("tuple", []abi.ArgumentMarshaling{
+		{Name: "version", Type: "uint8"},
+		{Name: "taskType", Type: "uint8"},
+		{Name: "action", Type: "uint8"},
+		{Name: "poolId", Type: "bytes32"},
+		{Name: "referenceBlock", Type: "uint64"},
+		{Name: "blockHash", Type: "bytes32"},
+		{Name: "stateHash", Type: "bytes32"},
+		{Name: "recommendedRent", Type: "uint256"},
+		{Name: "recommendedFee", Type: "uint24"},
+		{Name: "inputs", Type: "tuple", Components: inputsComponents},
+		{Name: "detail", Type: "bytes"},
+	})}}
+)
+
+func (in *Inputs) wire() wireInputs {
+	return wireInputs{
+		CurrentManager: in.CurrentManager,
+		RentPerBlock:   bigOrZero(in.RentPerBlock),
+		ManagerDeposit: bigOrZero(in.ManagerDeposit),
+		CurrentFee:     new(big.Int).SetUint64(uint64(in.CurrentFee)),
+		NextBidder:     in.NextBidder,
+		NextRent:       bigOrZero(in.NextRent),
+		ExpectedProfit: bigOrZero(in.ExpectedProfit),
+	}
+}
+
+// EncodeResult ABI-encodes r. A zero Version is encoded as the current
+// Version and a zero StateHash as the hash of r.Inputs.
+func EncodeResult(r *Result) ([]byte, error) {
+	version := r.Version
+	if version == 0 {
+		version = Version
+	}
+	stateHash := r.StateHash
+	if stateHash == (common.Hash{}) {
+		stateHash = r.Inputs.Hash()
+	}
+	detail := r.Detail
+	if detail == nil {
+		detail = []byte{}
+	}
+
+	out, err := resultArgs.Pack(wireResult{
+		Version:         version,
+		TaskType:        uint8(r.Type),
+		Action:          uint8(r.Action),
+		PoolId:          r.PoolId,
+		ReferenceBlock:  r.ReferenceBlock,
+		BlockHash:       r.BlockHash,
+		StateHash:       stateHash,
+		RecommendedRent: bigOrZero(r.RecommendedRent),
+		RecommendedFee:  new(big.Int).SetUint64(uint64(r.RecommendedFee)),
+		Inputs:          r.Inputs.wire(),
+		Detail:          detail,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task result: %w", err)
+	}
+	return out, nil
+}
+
+// DecodeResult parses an ABI-encoded result and checks that its state hash
+// matches its inputs
+func DecodeResult(data []byte) (*Result, error) {
+	values, err := resultArgs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	w := *abi.ConvertType(values[0], new(wireResult)).(*wireResult)
+
+	if canonical, err := resultArgs.Pack(w); err != nil || len(canonical) != len(data) {
+		return nil, fmt.Errorf("%w: %d bytes is not a canonical encoding", ErrMalformed, len(data))
+	}
+
+	r := &Result{
+		Version:         w.Version,
+		Type:            Type(w.TaskType),
+		Action:          Action(w.Action),
+		PoolId:          w.PoolId,
+		ReferenceBlock:  w.ReferenceBlock,
+		BlockHash:       w.BlockHash,
+		StateHash:       w.StateHash,
+		RecommendedRent: w.RecommendedRent,
+		RecommendedFee:  uint32(w.RecommendedFee.Uint64()),
+		Inputs: Inputs{
+			CurrentManager: w.Inputs.CurrentManager,
+			RentPerBlock:   w.Inputs.RentPerBlock,
+			ManagerDeposit: w.Inputs.ManagerDeposit,
+			CurrentFee:     uint32(w.Inputs.CurrentFee.Uint64()),
+			NextBidder:     w.Inputs.NextBidder,
+			NextRent:       w.Inputs.NextRent,
+			ExpectedProfit: w.Inputs.ExpectedProfit,
+		},
+		Detail: w.Detail,
+	}
+
+	if r.Version != Version {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrUnsupportedVersion, r.Version, Version)
+	}
+	if r.StateHash != r.Inputs.Hash() {
+		return nil, fmt.Errorf("%w: state hash %s does not match inputs", ErrMalformed, r.StateHash.Hex())
+	}
+	return r, nil
+}
@@ -0,0 +1,153 @@
+package task
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// golden compares got with testdata/name, a hex file. Changing an encoding
+// is a protocol change: update the Solidity side before running -update;
+// contracts/test/AuctionPoolTaskCodec.t.sol decodes the same files there.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		if err := os.WriteFile(path, []byte(hex.EncodeToString(got)+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	want, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil {
+		t.Fatalf("invalid golden file %s: %v", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch:\n got  %x\n want %x", name, got, want)
+	}
+}
+
+func goldenResult() *Result {
+	return &Result{
+		Type:            TypeEvaluatePool,
+		Action:          ActionSubmitBid,
+		PoolId:          common.HexToHash("0xa1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"),
+		ReferenceBlock:  19000000,
+		BlockHash:       common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"),
+		RecommendedRent: big.NewInt(1000000000000100),
+		Inputs: Inputs{
+			CurrentManager: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			RentPerBlock:   big.NewInt(1000000000000000),
+			ManagerDeposit: big.NewInt(100000000000000000),
+			CurrentFee:     3000,
+			ExpectedProfit: big.NewInt(1600000000000000),
+		},
+	}
+}
+
+func TestResultGolden(t *testing.T) {
+	r := goldenResult()
+
+	data, err := EncodeResult(r)
+	if err != nil {
+		t.Fatalf("EncodeResult failed: %v", err)
+	}
+	golden(t, "result_v1.hex", data)
+	golden(t, "inputs_v1_hash.hex", r.Inputs.Hash().Bytes())
+
+	payload, err := Encode(&Payload{Type: TypeEvaluatePool, PoolId: r.PoolId, ReferenceBlock: r.ReferenceBlock})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	golden(t, "payload_v1.hex", payload)
+}
+
+func TestResultRoundTrip(t *testing.T) {
+	in := goldenResult()
+	in.Type, in.Action, in.RecommendedFee = TypeRecommendFee, ActionUpdateFee, 3200
+	in.Detail = []byte{0xde, 0xad}
+
+	data, err := EncodeResult(in)
+	if err != nil {
+		t.Fatalf("EncodeResult failed: %v", err)
+	}
+	out, err := DecodeResult(data)
+	if err != nil {
+		t.Fatalf("DecodeResult failed: %v", err)
+	}
+
+	if out.Action != ActionUpdateFee || out.RecommendedFee != 3200 || out.ReferenceBlock != in.ReferenceBlock {
+		t.Errorf("decision not preserved: %+v", out)
+	}
+	if out.Inputs.CurrentFee != 3000 || out.Inputs.RentPerBlock.Cmp(in.Inputs.RentPerBlock) != 0 {
+		t.Errorf("inputs not preserved: %+v", out.Inputs)
+	}
+	if out.StateHash != in.Inputs.Hash() || !bytes.Equal(out.Detail, in.Detail) {
+		t.Errorf("state hash or detail not preserved")
+	}
+
+	// A result whose inputs were altered no longer matches its state hash
+	tampered := *in
+	tampered.StateHash = in.Inputs.Hash()
+	tampered.Inputs.CurrentFee = 100
+	data, _ = EncodeResult(&tampered)
+	if _, err := DecodeResult(data); !errors.Is(err, ErrMalformed) {
+		t.Errorf("expected tampered inputs to be rejected, got %v", err)
+	}
+}
+
+func TestDetailRoundTrip(t *testing.T) {
+	fee, err := EncodeFeeDetail(FeeDetail{WindowStart: 901, Swaps: 12, MinTick: -300, MaxTick: 45})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := DecodeFeeDetail(fee); err != nil || got.MinTick != -300 || got.Swaps != 12 {
+		t.Errorf("fee detail not preserved: %+v, %v", got, err)
+	}
+
+	rent, err := EncodeRentDetail(RentDetail{FromBlock: 10, ToBlock: 20, Collected: big.NewInt(300), ManagerChanges: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeRentDetail(rent)
+	if err != nil {
+		t.Fatalf("DecodeRentDetail failed: %v", err)
+	}
+	if got.ToBlock != 20 || got.Collected.Int64() != 300 || got.ManagerChanges != 2 || got.OnChainAccumulated.Sign() != 0 {
+		t.Errorf("rent detail not preserved: %+v", got)
+	}
+
+	evidence, err := EncodeEvidenceDetail(EvidenceDetail{
+		FromBlock:     10,
+		ToBlock:       20,
+		SwapsAnalyzed: 7,
+		Findings: []Evidence{
+			{Kind: 2, Block: 15, Manager: common.HexToAddress("0xbad"), VictimAmount0: big.NewInt(-40), FeeBefore: big.NewInt(3000)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev, err := DecodeEvidenceDetail(evidence)
+	if err != nil {
+		t.Fatalf("DecodeEvidenceDetail failed: %v", err)
+	}
+	if ev.SwapsAnalyzed != 7 || len(ev.Findings) != 1 || ev.Findings[0].VictimAmount0.Int64() != -40 || ev.Findings[0].Manager != common.HexToAddress("0xbad") {
+		t.Errorf("evidence detail not preserved: %+v", ev)
+	}
+}
@@ -0,0 +1,169 @@
+// Package taskfee estimates what answering each task type costs a
+// performer and compares it with the fee the task hook charges for it.
+// AuctionPoolTaskHook charges a flat minTaskFee whatever the task type, so
+// block-range tasks over long ranges can cost more to answer than they pay.
+package taskfee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/avstaskhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/strategy"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// ErrUnderpaid is returned for a task whose fee does not cover its cost
+// plus the required margin
+var ErrUnderpaid = errors.New("task fee does not cover its cost")
+
+// RPC calls each part of a task makes; see performer.RPCReader
+const (
+	validateCalls  = 2 // head number and head header
+	poolStateCalls = 5 // reference header, poolAuctions, nextBid, poolManager, getBidHistory
+	rentStateCalls = 3 // poolAuctions, rentPerShareAccumulated, totalShares
+)
+
+// Pricing is what the performer's RPC provider and host charge, in wei
+type Pricing struct {
+	// CallWei is charged per RPC request, eth_getLogs included
+	CallWei uint64 `yaml:"callWei"`
+	// LogBlockWei is charged per block an eth_getLogs request spans
+	LogBlockWei uint64 `yaml:"logBlockWei"`
+	// ComputeWei is charged per task for decoding, replay and signing
+	ComputeWei uint64 `yaml:"computeWei"`
+}
+
+// DefaultPricing is hosted RPC at around $20 per million requests and
+// ETH at $2000
+func DefaultPricing() Pricing {
+	return Pricing{
+		CallWei:     1e10,
+		LogBlockWei: 1e7,
+		ComputeWei:  1e10,
+	}
+}
+
+// Cost is the work a task takes
+type Cost struct {
+	Calls     uint64
+	LogBlocks uint64
+}
+
+// Wei prices c
+func (c Cost) Wei(p Pricing) *big.Int {
+	wei := new(big.Int).Mul(new(big.Int).SetUint64(c.Calls), new(big.Int).SetUint64(p.CallWei))
+	wei.Add(wei, new(big.Int).Mul(new(big.Int).SetUint64(c.LogBlocks), new(big.Int).SetUint64(p.LogBlockWei)))
+	return wei.Add(wei, new(big.Int).SetUint64(p.ComputeWei))
+}
+
+// EstimateType is the worst-case cost of a task of type t. rangeBlocks is
+// the length of the block range attest_rent and surveil_manager cover.
+func EstimateType(t task.Type, rangeBlocks uint64) (Cost, error) {
+	c := Cost{Calls: validateCalls + poolStateCalls}
+	switch t {
+	case task.TypeEvaluatePool, task.TypeRecommendFee:
+		// evaluate_pool only reads the fee window when we are manager;
+		// price it as if we were
+		c.Calls++
+		c.LogBlocks += strategy.FeeWindow
+	case task.TypePriceBid:
+	case task.TypeAttestRent:
+		c.Calls += 2*rentStateCalls + 1
+		c.LogBlocks += rangeBlocks
+	case task.TypeSurveilManager:
+		// the starting auction state, then hook events and swaps
+		c.Calls += 1 + 2
+		c.LogBlocks += 2 * rangeBlocks
+	default:
+		return Cost{}, fmt.Errorf("%w: %s", task.ErrUnsupportedType, t)
+	}
+	return c, nil
+}
+
+// Estimate is the cost of answering p, which must have been validated
+func Estimate(p *task.Payload) (Cost, error) {
+	var rangeBlocks uint64
+	if p.Type == task.TypeAttestRent || p.Type == task.TypeSurveilManager {
+		from, err := task.DecodeRangeParams(p.Params)
+		if err != nil {
+			return Cost{}, err
+		}
+		if from <= p.ReferenceBlock {
+			rangeBlocks = p.ReferenceBlock - from + 1
+		}
+	}
+	return EstimateType(p.Type, rangeBlocks)
+}
+
+// Quote compares a task's fee with its cost
+type Quote struct {
+	Type      task.Type
+	Cost      Cost
+	CostWei   *big.Int
+	Fee       *big.Int
+	MarginBps uint64
+}
+
+// NewQuote prices cost and compares it with fee
+func NewQuote(t task.Type, cost Cost, pricing Pricing, fee *big.Int, marginBps uint64) Quote {
+	return Quote{
+		Type:      t,
+		Cost:      cost,
+		CostWei:   cost.Wei(pricing),
+		Fee:       fee,
+		MarginBps: marginBps,
+	}
+}
+
+// Required is the smallest fee that covers the cost with the margin
+func (q Quote) Required() *big.Int {
+	required := new(big.Int).Mul(q.CostWei, new(big.Int).SetUint64(10000+q.MarginBps))
+	return required.Div(required, big.NewInt(10000))
+}
+
+// Covered reports whether the fee covers the cost with the margin
+func (q Quote) Covered() bool {
+	return q.Fee != nil && q.Fee.Cmp(q.Required()) >= 0
+}
+
+// Err is ErrUnderpaid with the numbers if the fee is not covered
+func (q Quote) Err() error {
+	if q.Covered() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s pays %s wei, costs %s wei, needs %s wei at a %d bps margin",
+		ErrUnderpaid, q.Type, q.Fee, q.CostWei, q.Required(), q.MarginBps)
+}
+
+// Caller is the part of the avstaskhook binding fees are read through.
+// AuctionPoolTaskHook implements IAVSTaskHook, so the generic binding
+// reads it.
+type Caller interface {
+	CalculateTaskFee(opts *bind.CallOpts, params avstaskhook.ITaskMailboxTypesTaskParams) (*big.Int, error)
+}
+
+// Hook reads the fees a task hook charges an executor operator set
+type Hook struct {
+	caller      Caller
+	executorSet avstaskhook.OperatorSet
+}
+
+func NewHook(caller Caller, executorSet avstaskhook.OperatorSet) *Hook {
+	return &Hook{caller: caller, executorSet: executorSet}
+}
+
+// TaskFee is what the task mailbox charges for a task with payload
+func (h *Hook) TaskFee(ctx context.Context, payload []byte) (*big.Int, error) {
+	fee, err := h.caller.CalculateTaskFee(&bind.CallOpts{Context: ctx}, avstaskhook.ITaskMailboxTypesTaskParams{
+		ExecutorOperatorSet: h.executorSet,
+		Payload:             payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task fee: %w", err)
+	}
+	return fee, nil
+}
@@ -0,0 +1,102 @@
+package taskfee
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/hourglass-avs-template/contracts/bindings/l2/avstaskhook"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minTaskFee is AuctionPoolTaskHook's default fee, 0.001 ether
+var minTaskFee = big.NewInt(1e15)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload task.Payload
+		want    Cost
+	}{
+		{"evaluate_pool", task.Payload{Type: task.TypeEvaluatePool, ReferenceBlock: 1000}, Cost{Calls: 8, LogBlocks: 100}},
+		{"recommend_fee", task.Payload{Type: task.TypeRecommendFee, ReferenceBlock: 1000}, Cost{Calls: 8, LogBlocks: 100}},
+		{"price_bid", task.Payload{Type: task.TypePriceBid, ReferenceBlock: 1000}, Cost{Calls: 7}},
+		{"attest_rent", task.Payload{Type: task.TypeAttestRent, ReferenceBlock: 1000, Params: task.EncodeRangeParams(901)}, Cost{Calls: 14, LogBlocks: 100}},
+		{"surveil_manager", task.Payload{Type: task.TypeSurveilManager, ReferenceBlock: 1000, Params: task.EncodeRangeParams(1)}, Cost{Calls: 10, LogBlocks: 2000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Estimate(&tt.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Estimate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := EstimateType(9, 0); !errors.Is(err, task.ErrUnsupportedType) {
+		t.Errorf("EstimateType(9) = %v, want %v", err, task.ErrUnsupportedType)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	pricing := DefaultPricing()
+
+	// The flat fee covers every task over a day of blocks
+	for _, typ := range []task.Type{task.TypeEvaluatePool, task.TypeRecommendFee, task.TypeAttestRent, task.TypePriceBid, task.TypeSurveilManager} {
+		cost, err := EstimateType(typ, 7200)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q := NewQuote(typ, cost, pricing, minTaskFee, 2000); !q.Covered() || q.Err() != nil {
+			t.Errorf("%s: %s wei does not cover %s wei", typ, q.Fee, q.Required())
+		}
+	}
+
+	// but not a surveil_manager task over years of blocks
+	cost, _ := EstimateType(task.TypeSurveilManager, 50_000_000)
+	q := NewQuote(task.TypeSurveilManager, cost, pricing, minTaskFee, 0)
+	if q.Covered() || !errors.Is(q.Err(), ErrUnderpaid) {
+		t.Errorf("expected %s wei to be underpaid for %s wei", q.Fee, q.CostWei)
+	}
+
+	q = Quote{CostWei: big.NewInt(10000), Fee: big.NewInt(12000), MarginBps: 2000}
+	if q.Required().Cmp(big.NewInt(12000)) != 0 || !q.Covered() {
+		t.Errorf("Required() = %s, want 12000", q.Required())
+	}
+	q.Fee = big.NewInt(11999)
+	if q.Covered() {
+		t.Errorf("a fee below the margin was covered")
+	}
+}
+
+type fakeCaller struct {
+	params avstaskhook.ITaskMailboxTypesTaskParams
+}
+
+func (f *fakeCaller) CalculateTaskFee(_ *bind.CallOpts, params avstaskhook.ITaskMailboxTypesTaskParams) (*big.Int, error) {
+	f.params = params
+	return minTaskFee, nil
+}
+
+func TestHookTaskFee(t *testing.T) {
+	caller := &fakeCaller{}
+	set := avstaskhook.OperatorSet{Avs: common.HexToAddress("0xa5"), Id: 1}
+
+	fee, err := NewHook(caller, set).TaskFee(context.Background(), []byte{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.Cmp(minTaskFee) != 0 {
+		t.Errorf("TaskFee() = %s, want %s", fee, minTaskFee)
+	}
+	if caller.params.ExecutorOperatorSet != set || string(caller.params.Payload) != "\x01\x02" {
+		t.Errorf("calculateTaskFee called with %+v", caller.params)
+	}
+}
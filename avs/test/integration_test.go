@@ -1,119 +1,136 @@
 package test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
 	"testing"
+	"time"
+
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/accounting"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/performer"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/poolmanager"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/surveillance"
+	"github.com/Layr-Labs/hourglass-avs-template/pkg/task"
+	performerV1 "github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
 )
 
-// TestOperatorStrategy tests the autonomous operator decision-making logic
-func TestOperatorStrategy(t *testing.T) {
-	t.Run("should bid when profitable", func(t *testing.T) {
-		// Mock pool state
-		currentRent := 0.001 // 0.001 ETH per block
-		expectedProfit := 0.015 // 0.015 ETH per block
-
-		// Calculate profitable rent (80% of profit)
-		profitableRent := expectedProfit * 0.8 // 0.012 ETH
-
-		// Should bid if profitable rent > current rent
-		if profitableRent > currentRent {
-			t.Logf("✓ Should submit bid: profitable_rent=%.6f > current_rent=%.6f", profitableRent, currentRent)
-		} else {
-			t.Errorf("Expected to bid but didn't: profitable_rent=%.6f, current_rent=%.6f", profitableRent, currentRent)
-		}
-	})
-
-	t.Run("should not bid when unprofitable", func(t *testing.T) {
-		currentRent := 0.01 // 0.01 ETH per block (high)
-		expectedProfit := 0.005 // 0.005 ETH per block (low)
-
-		profitableRent := expectedProfit * 0.8 // 0.004 ETH
-
-		if profitableRent <= currentRent {
-			t.Logf("✓ Should not bid: profitable_rent=%.6f <= current_rent=%.6f", profitableRent, currentRent)
-		} else {
-			t.Errorf("Expected not to bid but did: profitable_rent=%.6f, current_rent=%.6f", profitableRent, currentRent)
-		}
-	})
-
-	t.Run("should update fee when difference exceeds threshold", func(t *testing.T) {
-		currentFee := uint32(3000)  // 0.3%
-		optimalFee := uint32(3500)  // 0.35%
-		threshold := uint32(100)    // 0.1%
-
-		diff := optimalFee - currentFee
-
-		if diff > threshold {
-			t.Logf("✓ Should update fee: diff=%d > threshold=%d", diff, threshold)
-		} else {
-			t.Errorf("Expected to update fee but didn't: diff=%d, threshold=%d", diff, threshold)
-		}
-	})
-
-	t.Run("should not update fee when difference within threshold", func(t *testing.T) {
-		currentFee := uint32(3000)  // 0.3%
-		optimalFee := uint32(3050)  // 0.305%
-		threshold := uint32(100)    // 0.1%
-
-		diff := optimalFee - currentFee
-
-		if diff <= threshold {
-			t.Logf("✓ Should not update fee: diff=%d <= threshold=%d", diff, threshold)
-		} else {
-			t.Errorf("Expected not to update fee but did: diff=%d, threshold=%d", diff, threshold)
-		}
-	})
-
-	t.Run("should calculate optimal fee based on volatility", func(t *testing.T) {
-		baseFee := uint32(3000) // 0.3%
-		volatility := 0.02 // 2%
-
-		// Higher volatility = higher fee
-		volatilityAdjustment := uint32(volatility * 10000) // +200 bps
-		optimalFee := baseFee + volatilityAdjustment
-
-		// Cap at 1%
-		maxFee := uint32(10000)
-		if optimalFee > maxFee {
-			optimalFee = maxFee
-		}
-
-		// Floor at 0.05%
-		minFee := uint32(500)
-		if optimalFee < minFee {
-			optimalFee = minFee
-		}
-
-		expectedFee := uint32(3200) // 0.32%
-		if optimalFee == expectedFee {
-			t.Logf("✓ Optimal fee calculated correctly: %d (%.2f%%)", optimalFee, float64(optimalFee)/10000)
-		} else {
-			t.Errorf("Incorrect optimal fee: got %d, expected %d", optimalFee, expectedFee)
-		}
-	})
+const head = 5000
+
+var (
+	hook   = common.HexToAddress("0x00c0")
+	poolId = common.HexToHash("0x01")
+	rival  = common.HexToAddress("0x0c")
+
+	// headTime is when the head was mined; blocks are 2s apart
+	headTime = time.Unix(1_700_000_000, 0)
+)
+
+// chain is an L2 whose hook holds one pool managed by a rival at a low
+// rent, with no swaps or rent history
+type chain struct{}
+
+func (chain) BlockNumber(context.Context) (uint64, error) { return head, nil }
+
+func (chain) Block(_ context.Context, number uint64) (performer.Block, error) {
+	return performer.Block{
+		Number: number,
+		Hash:   common.BigToHash(new(big.Int).SetUint64(number)),
+		Time:   uint64(headTime.Unix()) - 2*(head-number),
+	}, nil
 }
 
-// TestProfitEstimation tests profit calculation logic
-func TestProfitEstimation(t *testing.T) {
-	t.Run("should estimate profit from swap fees and arb", func(t *testing.T) {
-		swapVolumePerBlock := 1000.0 // $1000 per block
-		averageFee := 0.003          // 0.3%
-		swapFeeRevenue := swapVolumePerBlock * averageFee // $3
+func (chain) PoolState(context.Context, common.Address, common.Hash, performer.Block) (*performer.HookState, error) {
+	return &performer.HookState{
+		PoolManager:    common.HexToAddress("0x0900"),
+		Manager:        rival,
+		RentPerBlock:   big.NewInt(1000),
+		ManagerDeposit: big.NewInt(100_000),
+		CurrentFee:     3000,
+		NextRent:       new(big.Int),
+	}, nil
+}
 
-		volatility := 0.02 // 2%
-		arbProfit := volatility * swapVolumePerBlock * 0.01 // $0.2
+func (chain) Swaps(context.Context, common.Address, common.Hash, uint64, performer.Block) ([]*poolmanager.Swap, error) {
+	return nil, nil
+}
 
-		totalProfitPerBlock := swapFeeRevenue + arbProfit // $3.2
+func (chain) RentState(context.Context, common.Address, common.Hash, performer.Block) (accounting.State, error) {
+	return accounting.State{}, nil
+}
+
+func (chain) RentEvents(context.Context, common.Address, common.Hash, uint64, performer.Block) ([]accounting.Event, error) {
+	return nil, nil
+}
 
-		// Convert to ETH (assuming $2000 ETH price)
-		ethPrice := 2000.0
-		profitInEth := totalProfitPerBlock / ethPrice
+func (chain) Surveillance(context.Context, common.Address, common.Address, common.Hash, uint64, performer.Block) (surveillance.Input, error) {
+	return surveillance.Input{}, nil
+}
 
-		expectedProfit := 0.0016 // 0.0016 ETH
+type clock time.Time
+
+func (c clock) Now() time.Time { return time.Time(c) }
+
+func request(t *testing.T, referenceBlock uint64) *performerV1.TaskRequest {
+	t.Helper()
+	payload, err := task.Encode(&task.Payload{Type: task.TypeEvaluatePool, PoolId: poolId, ReferenceBlock: referenceBlock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &performerV1.TaskRequest{TaskId: []byte("task-1"), Payload: payload}
+}
 
-		if profitInEth == expectedProfit {
-			t.Logf("✓ Profit estimated correctly: %.6f ETH per block", profitInEth)
-		} else {
-			t.Errorf("Incorrect profit: got %.6f, expected %.6f", profitInEth, expectedProfit)
-		}
-	})
+// TestTaskLifecycle takes an encoded task through validation and handling
+// as the executor does, and checks the result is one the aggregator and
+// the task hook accept. Strategy outcomes are tested in pkg/strategy and
+// pkg/performer.
+func TestTaskLifecycle(t *testing.T) {
+	cfg := performer.DefaultConfig()
+	cfg.HookAddress = hook
+	cfg.MaxHeadLag = time.Minute
+	tw := performer.New(zap.NewNop(), chain{}, clock(headTime.Add(10*time.Second)), cfg)
+
+	req := request(t, head-5)
+	if err := tw.ValidateTask(req); err != nil {
+		t.Fatalf("ValidateTask rejected a current task: %v", err)
+	}
+	resp, err := tw.HandleTask(req)
+	if err != nil {
+		t.Fatalf("HandleTask failed: %v", err)
+	}
+	if !bytes.Equal(resp.TaskId, req.TaskId) {
+		t.Errorf("response task ID = %q, want %q", resp.TaskId, req.TaskId)
+	}
+
+	result, err := task.DecodeResult(resp.Result)
+	if err != nil {
+		t.Fatalf("DecodeResult failed: %v", err)
+	}
+	if result.Version != task.Version || result.Type != task.TypeEvaluatePool || result.PoolId != poolId {
+		t.Errorf("result envelope = v%d %s %s, want v%d %s %s",
+			result.Version, result.Type, result.PoolId.Hex(), task.Version, task.TypeEvaluatePool, poolId.Hex())
+	}
+
+	// The result commits to the state it was decided on, at the task's block
+	ref, _ := chain{}.Block(context.Background(), head-5)
+	if result.ReferenceBlock != ref.Number || result.BlockHash != ref.Hash {
+		t.Errorf("result pinned to %d %s, want %d %s", result.ReferenceBlock, result.BlockHash.Hex(), ref.Number, ref.Hash.Hex())
+	}
+	if result.StateHash != result.Inputs.Hash() {
+		t.Errorf("state hash %s does not commit to the inputs", result.StateHash.Hex())
+	}
+	if result.Inputs.CurrentManager != rival || result.Inputs.RentPerBlock.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("inputs = %+v, want the rival's rent of 1000", result.Inputs)
+	}
+	if result.Action != task.ActionSubmitBid || result.RecommendedRent.Cmp(big.NewInt(1000)) <= 0 {
+		t.Errorf("action = %s at %s, want a bid above the rival's rent", result.Action, result.RecommendedRent)
+	}
+
+	// A task older than the performer takes is turned away before any work
+	if err := tw.ValidateTask(request(t, head-cfg.MaxTaskAge-1)); !errors.Is(err, task.ErrStale) {
+		t.Errorf("ValidateTask of a stale task = %v, want ErrStale", err)
+	}
 }
@@ -153,8 +153,9 @@ print_header "STEP 5: INITIALIZE POOL"
 print_step "Creating pool with liquidity..."
 
 # The deployment script should have initialized the pool
-# Get pool ID
-POOL_ID=$(cast call $HOOK_ADDRESS "poolAuctions(bytes32)" "0x0000000000000000000000000000000000000000000000000000000000000001" --rpc-url $RPC_URL | head -c 66)
+# Derive the pool ID from its key
+POOL_KEY="($TOKEN0_ADDRESS,$TOKEN1_ADDRESS,3000,60,$HOOK_ADDRESS)"
+POOL_ID=$(cd operator && go run . pool-id "$POOL_KEY")
 
 print_success "Pool initialized"
 print_info "  Pool ID: $POOL_ID"
@@ -179,14 +180,12 @@ cd operator
 
 export OPERATOR_PRIVATE_KEY="0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d" # Anvil account #1
 export HOOK_ADDRESS
-export POOL_ID
-export TOKEN0=$TOKEN0_ADDRESS
-export TOKEN1=$TOKEN1_ADDRESS
+export POOL_KEY
 
 print_step "Starting operator in background..."
 
 # Start operator in background
-go run main_new.go > /tmp/operator.log 2>&1 &
+go run . > /tmp/operator.log 2>&1 &
 OPERATOR_PID=$!
 
 cd ..
@@ -224,8 +223,6 @@ print_info "  Rent/block: $RENT_PER_BLOCK wei"
 print_info "  Deposit: $DEPOSIT wei"
 
 # Submit bid using cast
-POOL_KEY="($TOKEN0_ADDRESS,$TOKEN1_ADDRESS,3000,60,$HOOK_ADDRESS)"
-
 cast send $HOOK_ADDRESS \
     "submitBid((address,address,uint24,int24,address),uint256)" \
     "$POOL_KEY" \
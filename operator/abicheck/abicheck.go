@@ -0,0 +1,388 @@
+// Package abicheck detects drift between the copies of the AuctionPoolHook
+// ABI the operator ships, the forge build artifact of src/AuctionPoolHook.sol
+// and the contract deployed on chain.
+package abicheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrDrift is wrapped by Err when any drift was found
+var ErrDrift = errors.New("ABI drift")
+
+// absent stands in for the side of a Drift that has no entry
+const absent = "absent"
+
+// Drift is one difference between a copy and the reference
+type Drift struct {
+	Copy string
+	// Kind is function, event, error, struct, outputs, mutability or
+	// metadata
+	Kind string
+	// Name is the signature of the entry that differs
+	Name string
+	Want string
+	Got  string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: %s %s: want %s, got %s", d.Copy, d.Kind, d.Name, d.Want, d.Got)
+}
+
+// Err is nil without drifts and otherwise lists them, wrapping ErrDrift
+func Err(drifts []Drift) error {
+	if len(drifts) == 0 {
+		return nil
+	}
+	lines := make([]string, len(drifts))
+	for i, d := range drifts {
+		lines[i] = d.String()
+	}
+	return fmt.Errorf("%w:\n  %s", ErrDrift, strings.Join(lines, "\n  "))
+}
+
+// Copy is one copy of the ABI
+type Copy struct {
+	Name string
+	ABI  abi.ABI
+	// Partial copies only declare what their user calls, so entries they
+	// leave out are not drift
+	Partial bool
+}
+
+func parse(name string, data []byte) (Copy, error) {
+	parsed, err := abi.JSON(strings.NewReader(string(data)))
+	if err != nil {
+		return Copy{}, fmt.Errorf("%s: failed to parse ABI: %w", name, err)
+	}
+	return Copy{Name: name, ABI: parsed}, nil
+}
+
+// LoadJSON reads an ABI JSON file
+func LoadJSON(name, path string) (Copy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Copy{}, err
+	}
+	return parse(name, data)
+}
+
+// FromMetaData reads the ABI of an abigen binding
+func FromMetaData(name string, md *bind.MetaData) (Copy, error) {
+	return parse(name, []byte(md.ABI))
+}
+
+// LoadGo reads an ABI embedded in Go source, either as the string constant
+// or variable ident or as the ABI field of a bind.MetaData literal assigned
+// to ident. The file is parsed rather than compiled, so build constraints
+// and the package it belongs to don't matter.
+func LoadGo(name, path, ident string) (Copy, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return Copy{}, err
+	}
+
+	var lit *ast.BasicLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || lit != nil {
+			return lit == nil
+		}
+		for i, id := range spec.Names {
+			if id.Name == ident && i < len(spec.Values) {
+				lit = abiLiteral(spec.Values[i])
+			}
+		}
+		return false
+	})
+	if lit == nil {
+		return Copy{}, fmt.Errorf("%s: no ABI string assigned to %s", path, ident)
+	}
+
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return Copy{}, fmt.Errorf("%s: %s: %w", path, ident, err)
+	}
+	return parse(name, []byte(value))
+}
+
+// abiLiteral finds the ABI string in "..." or &bind.MetaData{ABI: "..."}
+func abiLiteral(expr ast.Expr) *ast.BasicLit {
+	if u, ok := expr.(*ast.UnaryExpr); ok {
+		expr = u.X
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			return e
+		}
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if key, isIdent := kv.Key.(*ast.Ident); ok && isIdent && key.Name == "ABI" {
+				return abiLiteral(kv.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// Artifact is a forge build artifact, out/<Contract>.sol/<Contract>.json
+type Artifact struct {
+	ABI abi.ABI
+	// Code is the runtime bytecode, with zeroes where immutables go
+	Code []byte
+}
+
+// LoadArtifact reads a forge build artifact
+func LoadArtifact(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		ABI              json.RawMessage `json:"abi"`
+		DeployedBytecode struct {
+			Object string `json:"object"`
+		} `json:"deployedBytecode"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse artifact %s: %w", path, err)
+	}
+
+	c, err := parse(path, raw.ABI)
+	if err != nil {
+		return nil, err
+	}
+	code, err := hexutil.Decode(raw.DeployedBytecode.Object)
+	if err != nil {
+		return nil, fmt.Errorf("artifact %s: deployed bytecode: %w", path, err)
+	}
+	return &Artifact{ABI: c.ABI, Code: code}, nil
+}
+
+// Compare reports every way c differs from want: missing or unexpected
+// selectors and event topics, struct fields renamed or reordered, and
+// changed outputs or mutability
+func Compare(want abi.ABI, c Copy) []Drift {
+	var drifts []Drift
+	add := func(kind, name, w, g string) {
+		drifts = append(drifts, Drift{Copy: c.Name, Kind: kind, Name: name, Want: w, Got: g})
+	}
+
+	for _, sig := range union(keys(want.Methods, methodSig), keys(c.ABI.Methods, methodSig)) {
+		w, inWant := findMethod(want, sig)
+		g, inCopy := findMethod(c.ABI, sig)
+		switch {
+		case !inCopy:
+			if !c.Partial {
+				add("function", sig, hexutil.Encode(w.ID), absent)
+			}
+		case !inWant:
+			add("function", sig, absent, hexutil.Encode(g.ID))
+		default:
+			if wl, gl := argsLayout(w.Inputs, false), argsLayout(g.Inputs, false); wl != gl {
+				add("struct", sig, wl, gl)
+			}
+			if wl, gl := argsLayout(w.Outputs, true), argsLayout(g.Outputs, true); wl != gl {
+				add("outputs", sig, wl, gl)
+			}
+			if w.StateMutability != g.StateMutability {
+				add("mutability", sig, w.StateMutability, g.StateMutability)
+			}
+		}
+	}
+
+	for _, sig := range union(keys(want.Events, eventSig), keys(c.ABI.Events, eventSig)) {
+		w, inWant := findEvent(want, sig)
+		g, inCopy := findEvent(c.ABI, sig)
+		switch {
+		case !inCopy:
+			if !c.Partial {
+				add("event", sig, w.ID.Hex(), absent)
+			}
+		case !inWant:
+			add("event", sig, absent, g.ID.Hex())
+		default:
+			if wl, gl := argsLayout(w.Inputs, true), argsLayout(g.Inputs, true); wl != gl {
+				add("struct", sig, wl, gl)
+			}
+		}
+	}
+
+	for _, sig := range union(keys(want.Errors, errorSig), keys(c.ABI.Errors, errorSig)) {
+		w, inWant := findError(want, sig)
+		g, inCopy := findError(c.ABI, sig)
+		switch {
+		case !inCopy:
+			if !c.Partial {
+				add("error", sig, hexutil.Encode(w.ID[:4]), absent)
+			}
+		case !inWant:
+			add("error", sig, absent, hexutil.Encode(g.ID[:4]))
+		}
+	}
+	return drifts
+}
+
+// CheckCode reports the functions and events of want that runtime code
+// can't dispatch or emit. Solidity pushes every selector its dispatcher
+// matches and every event topic it emits as a constant, so one that is
+// missing from the code means the ABI does not describe the contract.
+func CheckCode(code []byte, want abi.ABI) []Drift {
+	if len(code) == 0 {
+		return []Drift{{Copy: "deployed code", Kind: "code", Name: "runtime bytecode", Want: "a contract", Got: "no code"}}
+	}
+
+	selectors, topics := pushes(code)
+
+	var drifts []Drift
+	for _, sig := range keys(want.Methods, methodSig) {
+		m, _ := findMethod(want, sig)
+		if !selectors[uint32(m.ID[0])<<24|uint32(m.ID[1])<<16|uint32(m.ID[2])<<8|uint32(m.ID[3])] {
+			drifts = append(drifts, Drift{Copy: "deployed code", Kind: "function", Name: sig, Want: hexutil.Encode(m.ID), Got: absent})
+		}
+	}
+	for _, sig := range keys(want.Events, eventSig) {
+		e, _ := findEvent(want, sig)
+		if !e.Anonymous && !topics[e.ID] {
+			drifts = append(drifts, Drift{Copy: "deployed code", Kind: "event", Name: sig, Want: e.ID.Hex(), Got: absent})
+		}
+	}
+	return drifts
+}
+
+// pushes collects the values of PUSH1-PUSH4 and PUSH32 instructions in
+// code. Selectors with leading zero bytes are pushed with fewer bytes.
+func pushes(code []byte) (map[uint32]bool, map[common.Hash]bool) {
+	const push1, push32 = 0x60, 0x7f
+
+	small, words := map[uint32]bool{}, map[common.Hash]bool{}
+	for pc := 0; pc < len(code); pc++ {
+		op := code[pc]
+		if op < push1 || op > push32 {
+			continue
+		}
+		n := int(op-push1) + 1
+		if pc+n >= len(code) {
+			break
+		}
+		data := code[pc+1 : pc+1+n]
+		switch {
+		case n <= 4:
+			var v uint32
+			for _, b := range data {
+				v = v<<8 | uint32(b)
+			}
+			small[v] = true
+		case n == 32:
+			words[common.BytesToHash(data)] = true
+		}
+		pc += n
+	}
+	return small, words
+}
+
+// argsLayout describes args including tuple field names, which decide how
+// values unpack into Go structs but don't change selectors. Top-level
+// names matter for outputs and events.
+func argsLayout(args abi.Arguments, names bool) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = typeLayout(arg.Type)
+		if names && arg.Name != "" {
+			parts[i] += " " + arg.Name
+		}
+		if arg.Indexed {
+			parts[i] += " indexed"
+		}
+	}
+	return "(" + strings.Join(parts, ",") + ")"
+}
+
+func typeLayout(t abi.Type) string {
+	switch t.T {
+	case abi.TupleTy:
+		fields := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			fields[i] = typeLayout(*elem) + " " + t.TupleRawNames[i]
+		}
+		return "(" + strings.Join(fields, ",") + ")"
+	case abi.SliceTy:
+		return typeLayout(*t.Elem) + "[]"
+	case abi.ArrayTy:
+		return fmt.Sprintf("%s[%d]", typeLayout(*t.Elem), t.Size)
+	}
+	return t.String()
+}
+
+// Entries are matched by signature rather than by Go name, since abi.JSON
+// renames overloads in declaration order
+
+func methodSig(m abi.Method) string { return m.Sig }
+func eventSig(e abi.Event) string   { return e.Sig }
+func errorSig(e abi.Error) string   { return e.Sig }
+
+func keys[T any](entries map[string]T, sig func(T) string) []string {
+	out := make([]string, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, sig(e))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func union(a, b []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range append(a, b...) {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func findMethod(a abi.ABI, sig string) (abi.Method, bool) {
+	for _, m := range a.Methods {
+		if m.Sig == sig {
+			return m, true
+		}
+	}
+	return abi.Method{}, false
+}
+
+func findEvent(a abi.ABI, sig string) (abi.Event, bool) {
+	for _, e := range a.Events {
+		if e.Sig == sig {
+			return e, true
+		}
+	}
+	return abi.Event{}, false
+}
+
+func findError(a abi.ABI, sig string) (abi.Error, bool) {
+	for _, e := range a.Errors {
+		if e.Sig == sig {
+			return e, true
+		}
+	}
+	return abi.Error{}, false
+}
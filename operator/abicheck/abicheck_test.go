@@ -0,0 +1,196 @@
+package abicheck
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// artifactPath is where forge build (run from the repository root) writes
+// the hook's artifact
+var artifactPath = filepath.Join("..", "..", "out", "AuctionPoolHook.sol", "AuctionPoolHook.json")
+
+// copies loads every copy of the hook ABI in the repository
+func copies(t *testing.T) []Copy {
+	t.Helper()
+
+	var out []Copy
+	load := func(c Copy, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, c)
+	}
+
+	load(LoadJSON("operator/abi", filepath.Join("..", "abi", "AuctionPoolHook.abi.json")))
+	load(LoadJSON("operator/operator/abi", filepath.Join("..", "operator", "abi", "AuctionPoolHook.abi.json")))
+	load(FromMetaData("operator/contracts", contracts.AuctionPoolHookMetaData))
+	load(LoadGo("avs binding", filepath.Join("..", "..", "avs", "contracts", "bindings", "l2", "auctionpoolhook", "auctionpoolhook.go"), "AuctionPoolHookMetaData"))
+
+	// main.go only declares what the legacy operator calls
+	legacy, err := LoadGo("operator/main.go", filepath.Join("..", "main.go"), "hookABIJSON")
+	legacy.Partial = true
+	load(legacy, err)
+	return out
+}
+
+// TestNoDrift checks every copy of the ABI against the forge artifact
+// when one has been built, and otherwise against operator/abi
+func TestNoDrift(t *testing.T) {
+	all := copies(t)
+
+	want := all[0].ABI
+	if art, err := LoadArtifact(artifactPath); err == nil {
+		want = art.ABI
+	} else if errors.Is(err, os.ErrNotExist) {
+		t.Logf("no forge artifact at %s, comparing against operator/abi; run forge build to check the Solidity source", artifactPath)
+	} else {
+		t.Fatal(err)
+	}
+
+	for _, c := range all {
+		if err := Err(Compare(want, c)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func mustABI(t *testing.T, data string) abi.ABI {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestCompare(t *testing.T) {
+	const bid = `{"type":"function","name":"nextBid","inputs":[{"name":"poolId","type":"bytes32"}],"outputs":[
+		{"name":"bidder","type":"address"},{"name":"rentPerBlock","type":"uint256"}],"stateMutability":"view"}`
+	const fee = `{"type":"event","name":"FeeUpdated","inputs":[{"name":"poolId","type":"bytes32","indexed":true},{"name":"newFee","type":"uint24","indexed":false}]}`
+	const submit = `{"type":"function","name":"submitBid","inputs":[{"name":"key","type":"tuple","components":[
+		{"name":"currency0","type":"address"},{"name":"currency1","type":"address"}]}],"outputs":[],"stateMutability":"payable"}`
+	want := mustABI(t, "["+bid+","+fee+","+submit+"]")
+
+	tests := []struct {
+		name    string
+		copy    string
+		partial bool
+		kinds   []string
+	}{
+		{"identical", "[" + bid + "," + fee + "," + submit + "]", false, nil},
+		{"partial", "[" + bid + "]", true, nil},
+		{"missing entries", "[" + bid + "]", false, []string{"function", "event"}},
+		{"changed selector", "[" + bid + "," + fee + "," + strings.Replace(submit, `"address"}]`, `"uint160"}]`, 1) + "]", false, []string{"function", "function"}},
+		{"renamed struct field", "[" + bid + "," + fee + "," + strings.Replace(submit, "currency1", "token1", 1) + "]", false, []string{"struct"}},
+		{"renamed output", "[" + strings.Replace(bid, "rentPerBlock", "rent", 1) + "," + fee + "," + submit + "]", false, []string{"outputs"}},
+		{"mutability", "[" + bid + "," + fee + "," + strings.Replace(submit, "payable", "nonpayable", 1) + "]", false, []string{"mutability"}},
+		{"unindexed topic", "[" + bid + "," + strings.Replace(fee, `"indexed":true`, `"indexed":false`, 1) + "," + submit + "]", false, []string{"struct"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drifts := Compare(want, Copy{Name: "copy", ABI: mustABI(t, tt.copy), Partial: tt.partial})
+			var kinds []string
+			for _, d := range drifts {
+				kinds = append(kinds, d.Kind)
+			}
+			if strings.Join(kinds, ",") != strings.Join(tt.kinds, ",") {
+				t.Errorf("Compare() = %v, want kinds %v", drifts, tt.kinds)
+			}
+			if (len(drifts) > 0) != errors.Is(Err(drifts), ErrDrift) {
+				t.Errorf("Err() does not match drifts %v", drifts)
+			}
+		})
+	}
+}
+
+// fakeCode is runtime code that dispatches every function and emits every
+// event of a, followed by trailer
+func fakeCode(a abi.ABI, trailer []byte) []byte {
+	var code []byte
+	for _, m := range a.Methods {
+		// selectors with a leading zero byte are pushed with PUSH3
+		if m.ID[0] == 0 {
+			code = append(code, 0x62, m.ID[1], m.ID[2], m.ID[3], 0x14)
+		} else {
+			code = append(append(append(code, 0x63), m.ID...), 0x14)
+		}
+	}
+	for _, e := range a.Events {
+		code = append(append(append(code, 0x7f), e.ID.Bytes()...), 0xa2)
+	}
+	return append(code, trailer...)
+}
+
+func trailer(ipfs byte, solc [3]byte) []byte {
+	md := []byte{0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, 0x22}
+	md = append(md, bytes.Repeat([]byte{ipfs}, 34)...)
+	md = append(md, 0x64, 's', 'o', 'l', 'c', 0x43, solc[0], solc[1], solc[2])
+	return append(md, 0x00, byte(len(md)))
+}
+
+func TestCheckCode(t *testing.T) {
+	binding, err := FromMetaData("operator/contracts", contracts.AuctionPoolHookMetaData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := fakeCode(binding.ABI, trailer(1, [3]byte{0, 8, 26}))
+	if drifts := CheckCode(code, binding.ABI); len(drifts) != 0 {
+		t.Errorf("CheckCode() = %v for matching code", drifts)
+	}
+
+	// A selector only found inside another push's data doesn't count
+	submit := binding.ABI.Methods["submitBid"]
+	without := binding.ABI
+	without.Methods = map[string]abi.Method{}
+	for name, m := range binding.ABI.Methods {
+		if name != "submitBid" {
+			without.Methods[name] = m
+		}
+	}
+	hidden := append(append([]byte{0x7f}, submit.ID...), make([]byte, 28)...)
+	drifts := CheckCode(append(hidden, fakeCode(without, nil)...), binding.ABI)
+	if len(drifts) != 1 || drifts[0].Name != submit.Sig {
+		t.Errorf("CheckCode() = %v, want only submitBid missing", drifts)
+	}
+
+	if drifts := CheckCode(nil, binding.ABI); len(drifts) != 1 || drifts[0].Kind != "code" {
+		t.Errorf("CheckCode(nil) = %v", drifts)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	code := append([]byte{0x60, 0x80}, trailer(7, [3]byte{0, 8, 26})...)
+	md, err := ParseMetadata(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.Solc != "0.8.26" || !bytes.Equal(md.IPFS, bytes.Repeat([]byte{7}, 34)) {
+		t.Errorf("ParseMetadata() = %+v", md)
+	}
+
+	if _, err := ParseMetadata([]byte{0x60, 0x80, 0x00}); !errors.Is(err, ErrNoMetadata) {
+		t.Errorf("ParseMetadata() = %v for code without a trailer", err)
+	}
+
+	if drifts := CompareMetadata(code, code); len(drifts) != 0 {
+		t.Errorf("CompareMetadata() = %v for the same build", drifts)
+	}
+	other := append([]byte{0x60, 0x80}, trailer(8, [3]byte{0, 8, 27})...)
+	if drifts := CompareMetadata(code, other); len(drifts) != 2 {
+		t.Errorf("CompareMetadata() = %v, want ipfs and solc drift", drifts)
+	}
+	if drifts := CompareMetadata(code, common.FromHex("0x6080")); len(drifts) != 1 || drifts[0].Copy != "deployed code" {
+		t.Errorf("CompareMetadata() = %v for deployed code without a trailer", drifts)
+	}
+}
@@ -0,0 +1,121 @@
+package abicheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrNoMetadata is returned for code without a Solidity metadata trailer
+var ErrNoMetadata = errors.New("no solidity metadata in bytecode")
+
+// Metadata is the CBOR trailer solc appends to runtime code. IPFS is the
+// hash of the metadata JSON, which covers the source and ABI, so two
+// builds with the same IPFS hash have the same interface.
+type Metadata struct {
+	IPFS []byte
+	// Solc is the compiler version, e.g. 0.8.26
+	Solc string
+}
+
+// ParseMetadata decodes the metadata trailer of runtime code: a CBOR map
+// followed by its length as two big-endian bytes
+func ParseMetadata(code []byte) (Metadata, error) {
+	if len(code) < 2 {
+		return Metadata{}, ErrNoMetadata
+	}
+	n := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if n == 0 || n > len(code)-2 {
+		return Metadata{}, ErrNoMetadata
+	}
+	r := bytes.NewReader(code[len(code)-2-n : len(code)-2])
+
+	head, err := r.ReadByte()
+	if err != nil || head&0xe0 != 0xa0 {
+		return Metadata{}, ErrNoMetadata
+	}
+
+	var md Metadata
+	for pairs := int(head & 0x1f); pairs > 0; pairs-- {
+		key, err := readCBOR(r)
+		if err != nil {
+			return Metadata{}, err
+		}
+		value, err := readCBOR(r)
+		if err != nil {
+			return Metadata{}, err
+		}
+		switch string(key) {
+		case "ipfs":
+			md.IPFS = value
+		case "solc":
+			// release builds store major, minor and patch as three bytes
+			if len(value) == 3 {
+				md.Solc = fmt.Sprintf("%d.%d.%d", value[0], value[1], value[2])
+			} else {
+				md.Solc = string(value)
+			}
+		}
+	}
+	if md.IPFS == nil {
+		return Metadata{}, ErrNoMetadata
+	}
+	return md, nil
+}
+
+// readCBOR reads the byte string, text string or simple value solc writes
+// into the metadata map, returning its bytes
+func readCBOR(r *bytes.Reader) ([]byte, error) {
+	head, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("%w: truncated", ErrNoMetadata)
+	}
+
+	switch major := head >> 5; major {
+	case 2, 3: // byte string, text string
+		n := int(head & 0x1f)
+		if n == 24 {
+			b, err := r.ReadByte()
+			if err != nil {
+				return nil, fmt.Errorf("%w: truncated", ErrNoMetadata)
+			}
+			n = int(b)
+		} else if n > 24 {
+			return nil, fmt.Errorf("%w: unsupported length encoding", ErrNoMetadata)
+		}
+		out := make([]byte, n)
+		if _, err := io.ReadFull(r, out); err != nil {
+			return nil, fmt.Errorf("%w: truncated", ErrNoMetadata)
+		}
+		return out, nil
+	case 7: // false, true
+		return []byte{head}, nil
+	}
+	return nil, fmt.Errorf("%w: unexpected CBOR item %#x", ErrNoMetadata, head)
+}
+
+// CompareMetadata reports whether deployed code was built from a
+// different source or compiler than the artifact
+func CompareMetadata(artifact, deployed []byte) []Drift {
+	want, err := ParseMetadata(artifact)
+	if err != nil {
+		return []Drift{{Copy: "artifact", Kind: "metadata", Name: "trailer", Want: "solc metadata", Got: err.Error()}}
+	}
+	got, err := ParseMetadata(deployed)
+	if err != nil {
+		return []Drift{{Copy: "deployed code", Kind: "metadata", Name: "trailer", Want: "solc metadata", Got: err.Error()}}
+	}
+
+	var drifts []Drift
+	if !bytes.Equal(want.IPFS, got.IPFS) {
+		drifts = append(drifts, Drift{Copy: "deployed code", Kind: "metadata", Name: "ipfs", Want: hexutil.Encode(want.IPFS), Got: hexutil.Encode(got.IPFS)})
+	}
+	if want.Solc != got.Solc {
+		drifts = append(drifts, Drift{Copy: "deployed code", Kind: "metadata", Name: "solc", Want: want.Solc, Got: got.Solc})
+	}
+	return drifts
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/ledger"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/store"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// ledgerMaxRange caps how many blocks of hook events one tick books
+const ledgerMaxRange = 1000
+
+// hookEvent is one of the hook events that moves our money
+type hookEvent struct {
+	raw            types.Log
+	managerChanged *managerChange
+	rentCollected  *big.Int
+	feesWithdrawn  *big.Int
+	rentClaimed    *big.Int
+}
+
+type managerChange struct {
+	oldManager, newManager common.Address
+	rentPerBlock           *big.Int
+}
+
+// currentTenure is the tenure costs are booked against: our term as
+// manager if we hold the pool, otherwise the bid we have in flight
+func (op *Operator) currentTenure() common.Hash {
+	poolId := common.Hash(op.poolId)
+	if t := op.store.OpenTenure(poolId); t != nil {
+		return t.Id
+	}
+	if bid := op.store.ActiveBid(poolId); bid != nil {
+		return bid.TxHash
+	}
+	return common.Hash{}
+}
+
+// scanLedger books the hook events of final blocks since the last scan:
+// tenures starting and ending, rent taken from our deposit, and withdrawal
+// fees and LP rent paid out to us
+func (op *Operator) scanLedger(ctx context.Context) error {
+	head, err := op.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if head < op.confirmations {
+		return nil
+	}
+	to := head - op.confirmations
+
+	last := op.store.LedgerBlock()
+	if last == 0 {
+		// Nothing booked yet: start from here, adopting a tenure in progress
+		if err := op.adoptTenure(ctx, to); err != nil {
+			return err
+		}
+		return op.store.SetLedgerBlock(to)
+	}
+	if to <= last {
+		return nil
+	}
+	from := last + 1
+	if to-from >= ledgerMaxRange {
+		to = from + ledgerMaxRange - 1
+	}
+
+	events, err := op.hookEvents(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		if err := op.book(ev); err != nil {
+			return err
+		}
+	}
+
+	return op.store.SetLedgerBlock(to)
+}
+
+// adoptTenure opens a tenure for a term as manager that started before
+// the ledger did
+func (op *Operator) adoptTenure(ctx context.Context, block uint64) error {
+	poolId := common.Hash(op.poolId)
+	if op.store.OpenTenure(poolId) != nil {
+		return nil
+	}
+
+	state, err := op.criticalHook.PoolAuctions(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(block)}, op.poolId)
+	if err != nil {
+		return fmt.Errorf("failed to get pool state: %w", err)
+	}
+	if state.CurrentManager != op.address {
+		return nil
+	}
+
+	id := op.currentTenure()
+	if id == (common.Hash{}) {
+		op.log().Warn("Managing the pool without a record of our bid, its deposit is not on the books")
+		return nil
+	}
+	return op.store.PutTenure(&store.Tenure{
+		Id:            id,
+		PoolId:        poolId,
+		RentPerBlock:  state.RentPerBlock,
+		StartBlock:    block,
+		LastRentBlock: state.LastRentBlock.Uint64(),
+	})
+}
+
+// hookEvents reads the events that concern our books in [from, to], in
+// chain order
+func (op *Operator) hookEvents(ctx context.Context, from, to uint64) ([]hookEvent, error) {
+	opts := &bind.FilterOpts{Start: from, End: &to, Context: ctx}
+	pool := [][32]byte{op.poolId}
+	var events []hookEvent
+
+	changes, err := op.hook.FilterManagerChanged(opts, pool, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter ManagerChanged: %w", err)
+	}
+	for changes.Next() {
+		ev := changes.Event
+		events = append(events, hookEvent{raw: ev.Raw, managerChanged: &managerChange{ev.OldManager, ev.NewManager, ev.RentPerBlock}})
+	}
+	if err := changes.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read ManagerChanged: %w", err)
+	}
+
+	rents, err := op.hook.FilterRentCollected(opts, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter RentCollected: %w", err)
+	}
+	for rents.Next() {
+		events = append(events, hookEvent{raw: rents.Event.Raw, rentCollected: rents.Event.Amount})
+	}
+	if err := rents.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read RentCollected: %w", err)
+	}
+
+	fees, err := op.hook.FilterManagerFeesWithdrawn(opts, pool, []common.Address{op.address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter ManagerFeesWithdrawn: %w", err)
+	}
+	for fees.Next() {
+		events = append(events, hookEvent{raw: fees.Event.Raw, feesWithdrawn: fees.Event.Amount})
+	}
+	if err := fees.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read ManagerFeesWithdrawn: %w", err)
+	}
+
+	claims, err := op.hook.FilterRentClaimed(opts, pool, []common.Address{op.address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter RentClaimed: %w", err)
+	}
+	for claims.Next() {
+		events = append(events, hookEvent{raw: claims.Event.Raw, rentClaimed: claims.Event.Amount})
+	}
+	if err := claims.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read RentClaimed: %w", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].raw.BlockNumber != events[j].raw.BlockNumber {
+			return events[i].raw.BlockNumber < events[j].raw.BlockNumber
+		}
+		return events[i].raw.Index < events[j].raw.Index
+	})
+	return events, nil
+}
+
+// book applies one hook event to the ledger
+func (op *Operator) book(ev hookEvent) error {
+	poolId := common.Hash(op.poolId)
+	block, txHash := ev.raw.BlockNumber, ev.raw.TxHash
+	open := op.store.OpenTenure(poolId)
+
+	switch {
+	case ev.rentCollected != nil:
+		// RentCollected names no manager; it is ours while our tenure is open
+		if open == nil {
+			return nil
+		}
+		op.recordEvent(store.EntryRentPaid, ev.rentCollected, ev.raw, open.Id)
+		open.LastRentBlock = block
+		return op.store.PutTenure(open)
+
+	case ev.feesWithdrawn != nil:
+		tenure := common.Hash{}
+		if open != nil {
+			tenure = open.Id
+		} else if all := op.tenuresIn(poolId); len(all) > 0 {
+			// Fees can be withdrawn after the tenure that earned them
+			tenure = all[len(all)-1].Id
+		}
+		op.recordEvent(store.EntryFeesWithdrawn, ev.feesWithdrawn, ev.raw, tenure)
+		return nil
+
+	case ev.rentClaimed != nil:
+		// LP rent belongs to no tenure of ours
+		op.recordEvent(store.EntryRentClaimed, ev.rentClaimed, ev.raw, common.Hash{})
+		return nil
+	}
+
+	change := ev.managerChanged
+	if change.oldManager == op.address && open != nil {
+		held, err := ledger.DepositHeld(op.store.Accounting(), open.Id)
+		if err != nil {
+			return err
+		}
+		rent, refund := ledger.Handover(open, held, block)
+		if rent.Sign() > 0 {
+			op.recordEvent(store.EntryRentPaid, rent, ev.raw, open.Id)
+		}
+		if refund.Sign() > 0 {
+			op.recordEvent(store.EntryDepositRefunded, refund, ev.raw, open.Id)
+		}
+		op.log().Info("Tenure ended", logging.Block(block), logging.Tx(open.Id),
+			zap.Stringer("final_rent", rent), zap.String("final_rent_fmt", units.FormatWei(rent)),
+			zap.Stringer("refund", refund), zap.String("refund_fmt", units.FormatWei(refund)))
+		if change.newManager != op.address {
+			op.alert(fmt.Sprintf("lost_manager:%d", block), alert.Critical, "Lost manager status",
+				fmt.Sprintf("%s took over pool %s in block %d (tx %s); final rent %s, %s refunded",
+					change.newManager.Hex(), poolId.Hex(), block, txHash.Hex(), units.FormatWei(rent), units.FormatWei(refund)))
+		}
+
+		open.EndBlock = block
+		if err := op.store.PutTenure(open); err != nil {
+			return err
+		}
+	}
+
+	if change.newManager == op.address {
+		// Keyed to the bid that won, which may have queued behind our own
+		// tenure ended above
+		id := txHash
+		if bid := op.store.ActiveBid(poolId); bid != nil && bid.Locked() && bid.TxHash != (common.Hash{}) && bid.RentPerBlock.Cmp(change.rentPerBlock) == 0 {
+			id = bid.TxHash
+		}
+		// Booked already by a scan that died before saving its cursor
+		for _, t := range op.tenuresIn(poolId) {
+			if t.Id == id && t.StartBlock == block {
+				return nil
+			}
+		}
+		op.log().Info("Tenure started", logging.Block(block), logging.Tx(id),
+			zap.Stringer("rent_per_block", change.rentPerBlock), zap.String("rent_per_block_fmt", op.rent.Format(change.rentPerBlock)))
+		return op.store.PutTenure(&store.Tenure{
+			Id:            id,
+			PoolId:        poolId,
+			RentPerBlock:  change.rentPerBlock,
+			StartBlock:    block,
+			LastRentBlock: block,
+		})
+	}
+	return nil
+}
+
+func (op *Operator) tenuresIn(poolId common.Hash) []*store.Tenure {
+	var out []*store.Tenure
+	for _, t := range op.store.Tenures() {
+		if t.PoolId == poolId {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"auction-pool/operator/contracts"
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+var (
+	us    = common.HexToAddress("0xa11ce")
+	rival = common.HexToAddress("0xb0b")
+	pool  = common.HexToHash("0x01")
+
+	// bidTx is the submitBid that won us the tenure
+	bidTx = common.HexToHash("0xb1d")
+)
+
+// testOperator is an operator for pool with its state in a fresh store
+// and no chain behind it
+func testOperator(t *testing.T) *Operator {
+	t.Helper()
+
+	st, err := store.Open(filepath.Join(t.TempDir(), "operator_state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return &Operator{address: us, poolId: pool, store: st, logger: zap.NewNop()}
+}
+
+// withBid records our bid as sent and its deposit as locked
+func withBid(t *testing.T, op *Operator, rent, deposit int64) {
+	t.Helper()
+
+	if err := op.store.PutActiveBid(&store.ActiveBid{
+		PoolId:       pool,
+		TxHash:       bidTx,
+		RentPerBlock: big.NewInt(rent),
+		Deposit:      big.NewInt(deposit),
+		Status:       store.BidQueued,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	op.recordAccounting(store.EntryDepositLocked, big.NewInt(deposit), bidTx, 90, bidTx)
+}
+
+func eventLog(block uint64, index uint) types.Log {
+	return types.Log{BlockNumber: block, Index: index, TxHash: common.BigToHash(new(big.Int).SetUint64(block*100 + uint64(index)))}
+}
+
+func managerChanged(block uint64, from, to common.Address, rent int64) hookEvent {
+	return hookEvent{raw: eventLog(block, 0), managerChanged: &managerChange{from, to, big.NewInt(rent)}}
+}
+
+func rentCollected(block uint64, amount int64) hookEvent {
+	return hookEvent{raw: eventLog(block, 1), rentCollected: big.NewInt(amount)}
+}
+
+func feesWithdrawn(block uint64, amount int64) hookEvent {
+	return hookEvent{raw: eventLog(block, 2), feesWithdrawn: big.NewInt(amount)}
+}
+
+// entry is the part of an accounting entry the ledger reports on
+type entry struct {
+	kind   string
+	amount int64
+	tenure common.Hash
+}
+
+func TestBook(t *testing.T) {
+	won := managerChanged(100, rival, us, 10)
+	tests := []struct {
+		name    string
+		events  []hookEvent
+		want    []entry
+		open    bool
+		started uint64
+		ended   uint64
+	}{
+		{
+			name:    "we win",
+			events:  []hookEvent{won},
+			open:    true,
+			started: 100,
+		},
+		{
+			name:    "rent collected",
+			events:  []hookEvent{won, rentCollected(120, 200)},
+			want:    []entry{{store.EntryRentPaid, 200, bidTx}},
+			open:    true,
+			started: 100,
+		},
+		{
+			name:   "outbid",
+			events: []hookEvent{won, rentCollected(120, 200), managerChanged(130, us, rival, 20)},
+			want: []entry{
+				{store.EntryRentPaid, 200, bidTx},
+				// Ten blocks of rent since the collection, the rest refunded
+				{store.EntryRentPaid, 100, bidTx},
+				{store.EntryDepositRefunded, 700, bidTx},
+			},
+			started: 100,
+			ended:   130,
+		},
+		{
+			name:    "depleted",
+			events:  []hookEvent{won, managerChanged(300, us, rival, 20)},
+			want:    []entry{{store.EntryRentPaid, 1000, bidTx}},
+			started: 100,
+			ended:   300,
+		},
+		{
+			name:   "fees withdrawn after the tenure",
+			events: []hookEvent{won, managerChanged(130, us, rival, 20), feesWithdrawn(200, 50)},
+			want: []entry{
+				{store.EntryRentPaid, 300, bidTx},
+				{store.EntryDepositRefunded, 700, bidTx},
+				{store.EntryFeesWithdrawn, 50, bidTx},
+			},
+			started: 100,
+			ended:   130,
+		},
+		{
+			name: "scan replayed after a crash",
+			events: []hookEvent{
+				won, rentCollected(120, 200), managerChanged(130, us, rival, 20),
+				won, rentCollected(120, 200), managerChanged(130, us, rival, 20),
+			},
+			want: []entry{
+				{store.EntryRentPaid, 200, bidTx},
+				{store.EntryRentPaid, 100, bidTx},
+				{store.EntryDepositRefunded, 700, bidTx},
+			},
+			started: 100,
+			ended:   130,
+		},
+		{
+			name:   "a rival's tenure",
+			events: []hookEvent{managerChanged(100, common.Address{}, rival, 10), rentCollected(120, 200), feesWithdrawn(130, 50)},
+			// Fees we withdraw with no tenure on the books are booked to none
+			want: []entry{{store.EntryFeesWithdrawn, 50, common.Hash{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := testOperator(t)
+			withBid(t, op, 10, 1000)
+			for _, ev := range tt.events {
+				if err := op.book(ev); err != nil {
+					t.Fatalf("book(%+v) failed: %v", ev.raw, err)
+				}
+			}
+
+			var got []entry
+			for _, e := range op.store.Accounting()[1:] {
+				got = append(got, entry{e.Kind, e.Amount.Int64(), e.Tenure})
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("booked %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+
+			if open := op.store.OpenTenure(pool); (open != nil) != tt.open {
+				t.Errorf("OpenTenure() = %+v, want open %v", open, tt.open)
+			}
+			tenures := op.store.Tenures()
+			if tt.started == 0 {
+				if len(tenures) != 0 {
+					t.Errorf("Tenures() = %+v, want none", tenures)
+				}
+				return
+			}
+			if len(tenures) != 1 || tenures[0].Id != bidTx || tenures[0].StartBlock != tt.started || tenures[0].EndBlock != tt.ended {
+				t.Errorf("Tenures() = %+v, want %s from %d to %d", tenures, bidTx.Hex(), tt.started, tt.ended)
+			}
+		})
+	}
+}
+
+// poolAuctionsCaller answers poolAuctions with a fixed pool state
+type poolAuctionsCaller struct {
+	manager    common.Address
+	rent, last int64
+	calls      int
+}
+
+func (c *poolAuctionsCaller) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *poolAuctionsCaller) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	c.calls++
+	hookABI, err := contracts.AuctionPoolHookMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return hookABI.Methods["poolAuctions"].Outputs.Pack(c.manager, big.NewInt(c.rent), big.NewInt(1000), big.NewInt(c.last), big.NewInt(3000), big.NewInt(0))
+}
+
+func TestAdoptTenure(t *testing.T) {
+	tests := []struct {
+		name    string
+		manager common.Address
+		bid     bool
+		adopted bool
+	}{
+		{name: "we manage", manager: us, bid: true, adopted: true},
+		{name: "a rival manages", manager: rival, bid: true},
+		{name: "we manage without a bid on record", manager: us},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := testOperator(t)
+			caller := &poolAuctionsCaller{manager: tt.manager, rent: 10, last: 480}
+			hook, err := contracts.NewAuctionPoolHookCaller(common.HexToAddress("0x4004"), caller)
+			if err != nil {
+				t.Fatal(err)
+			}
+			op.criticalHook = hook
+			if tt.bid {
+				withBid(t, op, 10, 1000)
+			}
+
+			if err := op.adoptTenure(context.Background(), 500); err != nil {
+				t.Fatal(err)
+			}
+			open := op.store.OpenTenure(pool)
+			if !tt.adopted {
+				if open != nil {
+					t.Fatalf("adopted %+v", open)
+				}
+				return
+			}
+			if open == nil || open.Id != bidTx || open.StartBlock != 500 || open.LastRentBlock != 480 || open.RentPerBlock.Int64() != 10 {
+				t.Fatalf("OpenTenure() = %+v, want %s from 500, rent last taken at 480", open, bidTx.Hex())
+			}
+
+			// An open tenure is kept without asking the chain again
+			if err := op.adoptTenure(context.Background(), 600); err != nil {
+				t.Fatal(err)
+			}
+			if caller.calls != 1 || op.store.OpenTenure(pool).StartBlock != 500 {
+				t.Errorf("adopting again made %d calls and left %+v", caller.calls, op.store.OpenTenure(pool))
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package alert routes operator alerts to webhooks, email and files. A
+// router matches each alert against per-pool rules, drops repeats of the
+// same alert within a dedup window and caps how often each sink is sent to.
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Severity ranks alerts; rules route alerts at or above a minimum
+type Severity int
+
+const (
+	Info Severity = iota
+	Warning
+	Critical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Critical:
+		return "critical"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ParseSeverity reads info, warning or critical
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "info":
+		return Info, nil
+	case "warning", "warn":
+		return Warning, nil
+	case "critical", "crit":
+		return Critical, nil
+	}
+	return Info, fmt.Errorf("unknown severity %q (want info, warning or critical)", s)
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(b []byte) error {
+	v, err := ParseSeverity(string(b))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// Alert is one operator event worth a human's attention
+type Alert struct {
+	// Key identifies the condition; alerts with the same key, pool and
+	// severity are duplicates
+	Key      string      `json:"key"`
+	Severity Severity    `json:"severity"`
+	PoolId   common.Hash `json:"poolId"`
+	Title    string      `json:"title"`
+	Detail   string      `json:"detail,omitempty"`
+	Time     time.Time   `json:"time"`
+}
+
+// Subject is the one-line form of an alert, for mail subjects and chat
+func (a Alert) Subject() string {
+	return fmt.Sprintf("[%s] %s (pool %s)", strings.ToUpper(a.Severity.String()), a.Title, a.PoolId.TerminalString())
+}
+
+// Sink delivers alerts somewhere
+type Sink interface {
+	Send(ctx context.Context, a Alert) error
+}
+
+// Redact names the URL in a transport error by its host alone. Webhook and
+// RPC URLs often carry a token or API key in their path or query, and the
+// *url.Error an HTTP client returns spells the whole URL out.
+func Redact(err error) error {
+	var ue *url.Error
+	if !errors.As(err, &ue) {
+		return err
+	}
+	host := "<redacted>"
+	if u, perr := url.Parse(ue.URL); perr == nil && u.Host != "" {
+		host = u.Host
+	}
+	return &redacted{msg: strings.ReplaceAll(err.Error(), ue.URL, host), err: err}
+}
+
+// redacted is an error shown with its URL redacted that still unwraps to
+// the original
+type redacted struct {
+	msg string
+	err error
+}
+
+func (r *redacted) Error() string { return r.msg }
+func (r *redacted) Unwrap() error { return r.err }
@@ -0,0 +1,218 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var (
+	poolA = common.HexToHash("0xa")
+	poolB = common.HexToHash("0xb")
+)
+
+// lines decodes the alerts a file sink wrote
+func lines(t *testing.T, buf *bytes.Buffer) []Alert {
+	t.Helper()
+	var out []Alert
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if l == "" {
+			continue
+		}
+		var a Alert
+		if err := json.Unmarshal([]byte(l), &a); err != nil {
+			t.Fatalf("bad alert line %q: %v", l, err)
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []Severity{Info, Warning, Critical} {
+		if got, err := ParseSeverity(s.String()); err != nil || got != s {
+			t.Errorf("ParseSeverity(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseSeverity("loud"); err == nil {
+		t.Error("ParseSeverity accepted an unknown severity")
+	}
+}
+
+func TestRouting(t *testing.T) {
+	var all, critical bytes.Buffer
+	r, err := NewRouter(
+		map[string]Sink{"all": NewFile(&all), "critical": NewFile(&critical)},
+		[]Rule{
+			{Pool: poolA, MinSeverity: Info, Sinks: []string{"all"}},
+			{MinSeverity: Critical, Sinks: []string{"critical", "all"}},
+		},
+		0, RateLimit{}, zap.NewNop(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, a := range []Alert{
+		{Key: "rival", Severity: Warning, PoolId: poolA, Title: "rival bid pending"},
+		{Key: "runway", Severity: Warning, PoolId: poolB, Title: "runway low"},
+		{Key: "lost", Severity: Critical, PoolId: poolA, Title: "lost manager"},
+		{Key: "lost", Severity: Critical, PoolId: poolB, Title: "lost manager"},
+	} {
+		if err := r.Send(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Pool B's warning matches no rule; the critical rule adds "all" once
+	got := lines(t, &all)
+	if len(got) != 3 || got[0].Key != "rival" || got[1].PoolId != poolA || got[2].PoolId != poolB {
+		t.Errorf("all sink got %+v", got)
+	}
+	if got := lines(t, &critical); len(got) != 2 || got[0].Severity != Critical {
+		t.Errorf("critical sink got %+v", got)
+	}
+
+	if _, err := NewRouter(nil, []Rule{{Sinks: []string{"missing"}}}, 0, RateLimit{}, zap.NewNop()); err == nil {
+		t.Error("NewRouter accepted a rule naming an unknown sink")
+	}
+}
+
+func TestDedupAndRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	r, err := NewRouter(
+		map[string]Sink{"file": NewFile(&buf)},
+		[]Rule{{Sinks: []string{"file"}}},
+		10*time.Minute, RateLimit{Count: 2, Per: time.Hour}, zap.NewNop(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	r.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	send := func(key string, sev Severity) {
+		if err := r.Send(ctx, Alert{Key: key, Severity: sev, PoolId: poolA, Title: key}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	send("rival", Warning)
+	send("rival", Warning)  // duplicate
+	send("rival", Critical) // escalation is not a duplicate
+	if n := len(lines(t, &buf)); n != 2 {
+		t.Fatalf("sent %d alerts, want 2 after dedup", n)
+	}
+
+	now = now.Add(11 * time.Minute)
+	send("rival", Warning) // past the dedup window but over the rate limit
+	if n := len(lines(t, &buf)); n != 2 {
+		t.Fatalf("sent %d alerts, want 2 under the rate limit", n)
+	}
+
+	now = now.Add(time.Hour)
+	send("runway", Warning)
+	if got := lines(t, &buf); len(got) != 3 || got[2].Key != "runway" || !got[2].Time.Equal(now) {
+		t.Errorf("after the rate limit window got %+v", got)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var got struct {
+		Alert
+		Text string `json:"text"`
+	}
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth = req.Header.Get("Authorization")
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	hook := &Webhook{URL: srv.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+	a := Alert{Key: "tx_failed", Severity: Critical, PoolId: poolA, Title: "submitBid reverted", Time: time.Unix(1_700_000_000, 0).UTC()}
+	if err := hook.Send(context.Background(), a); err != nil {
+		t.Fatal(err)
+	}
+	if got.Key != a.Key || got.Severity != Critical || !got.Time.Equal(a.Time) || !strings.HasPrefix(got.Text, "[CRITICAL] submitBid reverted") {
+		t.Errorf("webhook received %+v", got)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("webhook Authorization = %q", auth)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if err := (&Webhook{URL: failing.URL}).Send(context.Background(), a); err == nil {
+		t.Error("webhook ignored a 502")
+	}
+
+	// The token in an unreachable webhook's URL stays out of the error
+	failing.Close()
+	err := (&Webhook{URL: failing.URL + "/services/T0KEN?key=s3cret"}).Send(context.Background(), a)
+	if err == nil || strings.Contains(err.Error(), "T0KEN") || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("webhook error %v leaks the URL", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "alerts.jsonl")
+	t.Setenv("ALERT_TEST_PATH", out)
+
+	path := filepath.Join(dir, "alerts.json")
+	cfg := `{
+		"dedup": "5m",
+		"rateLimit": {"count": 10, "per": "1h"},
+		"sinks": {
+			"log": {"type": "file", "path": "${ALERT_TEST_PATH}"},
+			"mail": {"type": "smtp", "addr": "localhost:25", "from": "op@example.com", "to": ["oncall@example.com"]}
+		},
+		"rules": [
+			{"pool": "` + poolA.Hex() + `", "minSeverity": "warning", "sinks": ["log"]},
+			{"minSeverity": "critical", "sinks": ["mail"]}
+		]
+	}`
+	if err := os.WriteFile(path, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Load(path, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.dedup != 5*time.Minute || r.limit != (RateLimit{Count: 10, Per: time.Hour}) || len(r.rules) != 2 || r.rules[0].MinSeverity != Warning {
+		t.Errorf("Load() = %+v", r)
+	}
+
+	if err := r.Send(context.Background(), Alert{Key: "runway", Severity: Warning, PoolId: poolA, Title: "runway low"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("file sink wrote %q, %v", data, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"sinks": {"x": {"type": "pager"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, zap.NewNop()); err == nil {
+		t.Error("Load accepted an unknown sink type")
+	}
+}
@@ -0,0 +1,122 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// config is the JSON layout of an alert config file. Environment variables
+// in the file are expanded, so secrets can stay out of it.
+type config struct {
+	Dedup     string `json:"dedup"`
+	RateLimit struct {
+		Count int    `json:"count"`
+		Per   string `json:"per"`
+	} `json:"rateLimit"`
+	Sinks map[string]sinkConfig `json:"sinks"`
+	Rules []struct {
+		Pool        common.Hash `json:"pool"`
+		MinSeverity Severity    `json:"minSeverity"`
+		Sinks       []string    `json:"sinks"`
+	} `json:"rules"`
+}
+
+type sinkConfig struct {
+	Type string `json:"type"`
+
+	// webhook
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Timeout string            `json:"timeout"`
+
+	// smtp
+	Addr     string   `json:"addr"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+
+	// file
+	Path string `json:"path"`
+}
+
+// Load builds a router from the config file at path, logging to logger
+func Load(path string, logger *zap.Logger) (*Router, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert config: %w", err)
+	}
+	var cfg config
+	if err := json.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse alert config %s: %w", path, err)
+	}
+
+	dedup, err := duration(cfg.Dedup, 10*time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("alert config dedup: %w", err)
+	}
+	limit := RateLimit{Count: cfg.RateLimit.Count}
+	if limit.Per, err = duration(cfg.RateLimit.Per, time.Hour); err != nil {
+		return nil, fmt.Errorf("alert config rateLimit: %w", err)
+	}
+
+	sinks := make(map[string]Sink, len(cfg.Sinks))
+	for name, sc := range cfg.Sinks {
+		s, err := sc.sink()
+		if err != nil {
+			return nil, fmt.Errorf("alert sink %s: %w", name, err)
+		}
+		sinks[name] = s
+	}
+
+	rules := make([]Rule, len(cfg.Rules))
+	for i, rc := range cfg.Rules {
+		rules[i] = Rule{Pool: rc.Pool, MinSeverity: rc.MinSeverity, Sinks: rc.Sinks}
+	}
+	return NewRouter(sinks, rules, dedup, limit, logger)
+}
+
+func (sc sinkConfig) sink() (Sink, error) {
+	switch sc.Type {
+	case "webhook":
+		if sc.URL == "" {
+			return nil, fmt.Errorf("webhook needs a url")
+		}
+		timeout, err := duration(sc.Timeout, 10*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		header := make(http.Header)
+		for k, v := range sc.Headers {
+			header.Set(k, v)
+		}
+		return &Webhook{URL: sc.URL, Header: header, Client: &http.Client{Timeout: timeout}}, nil
+
+	case "smtp":
+		if sc.Addr == "" || sc.From == "" || len(sc.To) == 0 {
+			return nil, fmt.Errorf("smtp needs addr, from and to")
+		}
+		return &SMTP{Addr: sc.Addr, From: sc.From, To: sc.To, Username: sc.Username, Password: sc.Password}, nil
+
+	case "file":
+		if sc.Path == "" {
+			return nil, fmt.Errorf("file needs a path (or \"-\" for stdout)")
+		}
+		return OpenFile(sc.Path)
+	}
+	return nil, fmt.Errorf("unknown sink type %q (want webhook, smtp or file)", sc.Type)
+}
+
+// duration parses s, or returns def for an empty s
+func duration(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,183 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"auction-pool/operator/logging"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Rule sends a pool's alerts at or above MinSeverity to the named sinks. A
+// zero Pool matches every pool.
+type Rule struct {
+	Pool        common.Hash
+	MinSeverity Severity
+	Sinks       []string
+}
+
+func (r Rule) matches(a Alert) bool {
+	return (r.Pool == common.Hash{} || r.Pool == a.PoolId) && a.Severity >= r.MinSeverity
+}
+
+// RateLimit caps each sink at Count alerts per Per; a zero Count is no cap
+type RateLimit struct {
+	Count int
+	Per   time.Duration
+}
+
+// queueSize bounds the alerts Notify buffers for Run
+const queueSize = 64
+
+// Router fans alerts out to sinks according to rules
+type Router struct {
+	sinks map[string]Sink
+	rules []Rule
+	dedup time.Duration
+	limit RateLimit
+
+	// logger reports alerts that could not be delivered
+	logger *zap.Logger
+
+	// now is the clock, replaced in tests
+	now func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+	sent map[string][]time.Time
+
+	queue chan Alert
+}
+
+// NewRouter routes to sinks by rules. Alerts repeating within dedup are
+// dropped, and those that cannot be delivered are logged to logger.
+func NewRouter(sinks map[string]Sink, rules []Rule, dedup time.Duration, limit RateLimit, logger *zap.Logger) (*Router, error) {
+	for _, r := range rules {
+		for _, name := range r.Sinks {
+			if _, ok := sinks[name]; !ok {
+				return nil, fmt.Errorf("rule for pool %s names unknown sink %q", r.Pool.Hex(), name)
+			}
+		}
+	}
+	return &Router{
+		sinks:  sinks,
+		rules:  rules,
+		dedup:  dedup,
+		limit:  limit,
+		logger: logger,
+		now:    time.Now,
+		seen:   make(map[string]time.Time),
+		sent:   make(map[string][]time.Time),
+		queue:  make(chan Alert, queueSize),
+	}, nil
+}
+
+// Notify queues an alert for Run without blocking. When the queue is full
+// the alert is logged and dropped.
+func (r *Router) Notify(a Alert) {
+	if a.Time.IsZero() {
+		a.Time = r.now()
+	}
+	select {
+	case r.queue <- a:
+	default:
+		r.log(a).Warn("Alert queue full, dropping it")
+	}
+}
+
+// Run sends queued alerts until ctx is cancelled
+func (r *Router) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case a := <-r.queue:
+			if err := r.Send(ctx, a); err != nil {
+				r.log(a).Error("Failed to send alert", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Send routes an alert now, returning the errors of the sinks that failed
+func (r *Router) Send(ctx context.Context, a Alert) error {
+	if a.Time.IsZero() {
+		a.Time = r.now()
+	}
+
+	var errs []error
+	for _, name := range r.route(a) {
+		if err := r.sinks[name].Send(ctx, a); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// route records the alert and returns the sinks it should go to, after
+// dedup and rate limiting
+func (r *Router) route(a Alert) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+
+	key := fmt.Sprintf("%s/%s/%s", a.PoolId.Hex(), a.Key, a.Severity)
+	if last, ok := r.seen[key]; ok && now.Sub(last) < r.dedup {
+		return nil
+	}
+	r.seen[key] = now
+	for k, t := range r.seen {
+		if now.Sub(t) >= r.dedup {
+			delete(r.seen, k)
+		}
+	}
+
+	var names []string
+	picked := make(map[string]bool)
+	for _, rule := range r.rules {
+		if !rule.matches(a) {
+			continue
+		}
+		for _, name := range rule.Sinks {
+			if picked[name] {
+				continue
+			}
+			picked[name] = true
+			if r.allow(name, now) {
+				names = append(names, name)
+			} else {
+				r.log(a).Warn("Alert sink over its rate limit, dropping the alert", zap.String("sink", name))
+			}
+		}
+	}
+	return names
+}
+
+// allow takes a slot in the sink's rate limit window if one is free
+func (r *Router) allow(name string, now time.Time) bool {
+	if r.limit.Count <= 0 {
+		return true
+	}
+	recent := r.sent[name][:0]
+	for _, t := range r.sent[name] {
+		if now.Sub(t) < r.limit.Per {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= r.limit.Count {
+		r.sent[name] = recent
+		return false
+	}
+	r.sent[name] = append(recent, now)
+	return true
+}
+
+// log is the logger for one alert
+func (r *Router) log(a Alert) *zap.Logger {
+	return r.logger.With(logging.Pool(a.PoolId), zap.String("alert", a.Key), zap.String("title", a.Title))
+}
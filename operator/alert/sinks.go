@@ -0,0 +1,131 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Webhook POSTs each alert as JSON
+type Webhook struct {
+	URL    string
+	Header http.Header
+	Client *http.Client
+}
+
+func (w *Webhook) Send(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(struct {
+		Alert
+		Text string `json:"text"`
+	}{a, a.Subject()})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range w.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", req.URL.Host, Redact(err))
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s answered %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
+
+// SMTP mails each alert. Username and Password, when set, authenticate
+// with PLAIN auth, which net/smtp only allows over TLS or to localhost.
+type SMTP struct {
+	Addr     string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+func (m *SMTP) Send(_ context.Context, a Alert) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp address %q: %w", m.Addr, err)
+		}
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", a.Subject())
+	fmt.Fprintf(&msg, "Date: %s\r\n", a.Time.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\nPool:     %s\r\nSeverity: %s\r\nTime:     %s\r\n",
+		a.Title, a.PoolId.Hex(), a.Severity, a.Time.UTC().Format("2006-01-02 15:04:05 MST"))
+	if a.Detail != "" {
+		fmt.Fprintf(&msg, "\r\n%s\r\n", a.Detail)
+	}
+
+	if err := smtp.SendMail(m.Addr, auth, m.From, m.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("smtp %s: %w", m.Addr, err)
+	}
+	return nil
+}
+
+// File writes each alert as a line of JSON
+type File struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFile writes alerts to w
+func NewFile(w io.Writer) *File {
+	return &File{w: w}
+}
+
+// OpenFile appends alerts to the file at path, or writes them to stdout
+// for "-"
+func OpenFile(path string) (*File, error) {
+	if path == "-" {
+		return NewFile(os.Stdout), nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open alert file: %w", err)
+	}
+	return NewFile(f), nil
+}
+
+func (f *File) Send(_ context.Context, a Alert) error {
+	line, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, err = f.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// alert hands an event about our pool to the alert router, if configured.
+// Alerts with the same key are deduplicated by the router.
+func (op *Operator) alert(key string, severity alert.Severity, title, detail string) {
+	if op.alerts == nil {
+		return
+	}
+	op.alerts.Notify(alert.Alert{
+		Key:      key,
+		Severity: severity,
+		PoolId:   common.Hash(op.poolId),
+		Title:    title,
+		Detail:   detail,
+		Time:     time.Now(),
+	})
+}
+
+// checkRPC alerts when endpoints drop out of rotation, critically when
+// none is left
+func (op *Operator) checkRPC() {
+	degraded := op.client.Degraded()
+	if len(degraded) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, st := range degraded {
+		// Endpoint URLs often carry API keys; name only the host
+		host := st.URL
+		if u, err := url.Parse(st.URL); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		line := fmt.Sprintf("%s: head %d, %d consecutive failure(s)", host, st.Head, st.ConsecutiveFailures)
+		if st.LastError != nil {
+			line += ", last error: " + alert.Redact(st.LastError).Error()
+		}
+		lines = append(lines, line)
+	}
+
+	severity, total := alert.Warning, len(op.client.Status())
+	if len(degraded) == total {
+		severity = alert.Critical
+	}
+	op.log().Warn("RPC endpoints degraded", zap.Int("degraded", len(degraded)), zap.Int("total", total), zap.Strings("endpoints", lines))
+	op.alert("rpc_degraded", severity, fmt.Sprintf("%d of %d RPC endpoints degraded", len(degraded), total), strings.Join(lines, "\n"))
+}
+
+// checkRunway alerts when our deposit as manager covers fewer than
+// runwayAlert more blocks of rent
+func (op *Operator) checkRunway(view *chainView, head uint64) {
+	if view.manager != op.address || op.runwayAlert == 0 {
+		return
+	}
+	left := runway(view, head)
+	if left >= op.runwayAlert {
+		return
+	}
+	op.log().Warn("Deposit runway low", logging.Block(head), zap.Uint64("runway_blocks", left), zap.Uint64("threshold", op.runwayAlert))
+	op.alert("runway", alert.Warning, fmt.Sprintf("Deposit runs out in %d blocks", left),
+		fmt.Sprintf("Manager deposit %s at %s leaves %d block(s) of rent as of block %d; below the %d block threshold",
+			units.FormatWei(view.managerDeposit), op.rent.Format(view.rentPerBlock), left, head, op.runwayAlert))
+}
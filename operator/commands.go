@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"auction-pool/operator/contracts"
+	"auction-pool/operator/history"
+	"auction-pool/operator/ledger"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/rivals"
+	"auction-pool/operator/rpcclient"
+	"auction-pool/operator/store"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// runCommand runs a subcommand instead of the bare operator loop
+func runCommand(name string, args []string) error {
+	switch name {
+	case "report":
+		return report(args)
+	case "book-swap":
+		return bookSwap(args)
+	case "history":
+		return tenureHistory(args)
+	case "rivals":
+		return rivalProfiles(args)
+	case "status":
+		return status(args)
+	case "bid":
+		return manualBidCommand(args)
+	case "set-fee":
+		return manualFeeCommand(args)
+	case "claim-rent":
+		return claimRentCommand(args)
+	case "withdraw-fees":
+		return withdrawFeesCommand(args)
+	case "discover":
+		return discover(args)
+	case "pool-id":
+		return printPoolId(args)
+	}
+	return fmt.Errorf("unknown command %q (want report, book-swap, history, rivals, status, bid, set-fee, claim-rent, withdraw-fees, discover or pool-id)", name)
+}
+
+// dialHook connects to RPC_URLS and binds HOOK_ADDRESS for the pool
+// resolvePool finds
+func dialHook(ctx context.Context) (*rpcclient.Client, *contracts.AuctionPoolHook, [32]byte, error) {
+	var poolId [32]byte
+	hookAddress := os.Getenv("HOOK_ADDRESS")
+	if hookAddress == "" {
+		return nil, nil, poolId, fmt.Errorf("HOOK_ADDRESS environment variable required")
+	}
+	id, _, err := resolvePool(common.HexToAddress(hookAddress))
+	if err != nil {
+		return nil, nil, poolId, err
+	}
+	poolId = id
+
+	client, err := rpcclient.Dial(ctx, rpcclient.Config{
+		URLs: strings.Split(getEnvOrDefault("RPC_URLS", getEnvOrDefault("RPC_URL", "http://localhost:8545")), ","),
+	})
+	if err != nil {
+		return nil, nil, poolId, fmt.Errorf("failed to connect to Ethereum client: %w", err)
+	}
+
+	hook, err := contracts.NewAuctionPoolHook(common.HexToAddress(hookAddress), client)
+	if err != nil {
+		client.Close()
+		return nil, nil, poolId, fmt.Errorf("failed to create hook binding: %w", err)
+	}
+	return client, hook, poolId, nil
+}
+
+// report prints the P&L of the ledger per pool or per tenure
+func report(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	by := fs.String("by", "pool", "group by pool or tenure")
+	format := fs.String("format", "csv", "csv or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Only reads, so it runs beside the operator without its lock
+	st, err := store.Load(getEnvOrDefault("STATE_FILE", "operator_state.json"))
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %w", err)
+	}
+
+	var rows []ledger.PnL
+	switch *by {
+	case "pool":
+		rows, err = ledger.ByPool(st.Accounting())
+	case "tenure":
+		rows, err = ledger.ByTenure(st.Accounting(), st.Tenures())
+	default:
+		return fmt.Errorf("invalid -by %q (want pool or tenure)", *by)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "csv":
+		return ledger.WriteCSV(os.Stdout, rows)
+	case "json":
+		return ledger.WriteJSON(os.Stdout, rows)
+	}
+	return fmt.Errorf("invalid -format %q (want csv or json)", *format)
+}
+
+// bookSwap records profit from swaps made at the manager's zero fee. Those
+// swaps are made outside the operator, so their profit is booked by hand.
+func bookSwap(args []string) error {
+	fs := flag.NewFlagSet("book-swap", flag.ContinueOnError)
+	amount := fs.String("amount", "", "profit, e.g. 0.05eth or 1000000gwei")
+	txHash := fs.String("tx", "", "swap transaction hash")
+	block := fs.Uint64("block", 0, "block the swap was mined in")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	profit, err := units.ParseWei(*amount)
+	if err != nil {
+		return fmt.Errorf("invalid -amount: %w", err)
+	}
+	if profit.Sign() <= 0 {
+		return fmt.Errorf("invalid -amount %q: must be positive", *amount)
+	}
+	poolIdHex := os.Getenv("POOL_ID")
+	if poolIdHex == "" {
+		return fmt.Errorf("POOL_ID environment variable required")
+	}
+	poolId := common.HexToHash(poolIdHex)
+
+	// Writes the ledger, so it refuses to run beside the operator
+	st, err := store.Open(getEnvOrDefault("STATE_FILE", "operator_state.json"))
+	if errors.Is(err, store.ErrLocked) {
+		return fmt.Errorf("%w; stop the operator to book swaps", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open state store: %w", err)
+	}
+	defer st.Close()
+
+	// Swaps at zero fee are only possible while we are manager
+	tenure := st.OpenTenure(poolId)
+	if tenure == nil {
+		return fmt.Errorf("no open tenure in pool %s", poolId.Hex())
+	}
+
+	return st.AppendAccounting(&store.AccountingEntry{
+		Time:   time.Now(),
+		PoolId: poolId,
+		Kind:   store.EntrySwapProfit,
+		Amount: profit,
+		TxHash: common.HexToHash(*txHash),
+		Block:  *block,
+		Tenure: tenure.Id,
+	})
+}
+
+// tenureHistory rebuilds every manager tenure of POOL_ID from the hook's
+// logs
+func tenureHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	from := fs.Uint64("from", 0, "first block to read, e.g. the hook's deployment")
+	to := fs.Uint64("to", 0, "last block to read (default: head)")
+	step := fs.Uint64("step", 10000, "blocks per log query")
+	format := fs.String("format", "table", "table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("invalid -format %q (want table or json)", *format)
+	}
+
+	ctx := context.Background()
+	client, hook, poolId, err := dialHook(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if *to == 0 {
+		if *to, err = client.BlockNumber(ctx); err != nil {
+			return fmt.Errorf("failed to get block number: %w", err)
+		}
+	}
+
+	// The state before the first block accounts for a tenure already running
+	var start *history.State
+	if *from > 0 {
+		state, err := hook.PoolAuctions(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(*from - 1)}, poolId)
+		if err != nil {
+			zap.L().Warn("Could not read pool state, the first tenure will be incomplete", logging.Block(*from-1), zap.Error(err))
+		} else {
+			start = &history.State{
+				Manager:        state.CurrentManager,
+				RentPerBlock:   state.RentPerBlock,
+				ManagerDeposit: state.ManagerDeposit,
+				LastRentBlock:  state.LastRentBlock.Uint64(),
+			}
+		}
+	}
+
+	events, err := history.Fetch(ctx, &hook.AuctionPoolHookFilterer, poolId, *from, *to, *step)
+	if err != nil {
+		return err
+	}
+	tenures := history.Reconstruct(start, events)
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tenures)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MANAGER\tSTART\tEND\tRENT/BLOCK\tDEPOSIT\tRENT PAID\tENDED\tFEE CHANGES\tWITHDRAWAL FEES")
+	for _, t := range tenures {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.Manager.Hex(), blockOrDash(t.StartBlock), blockOrDash(t.EndBlock),
+			weiOrDash(t.RentPerBlock), weiOrDash(t.Deposit), units.FormatWei(t.RentPaid),
+			orDash(t.EndReason), feeChanges(t.FeeChanges), units.FormatWei(t.WithdrawalFees))
+	}
+	return tw.Flush()
+}
+
+func feeChanges(changes []history.FeeChange) string {
+	if len(changes) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(changes))
+	for i, c := range changes {
+		parts[i] = fmt.Sprintf("%s@%d", units.FormatFee(c.Fee), c.Block)
+	}
+	return strings.Join(parts, ",")
+}
+
+func blockOrDash(n uint64) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
+
+func weiOrDash(v *big.Int) string {
+	if v == nil {
+		return "-"
+	}
+	return units.FormatWei(v)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// rivalProfiles profiles every other bidder in POOL_ID's bid history.
+// OPERATOR_ADDRESS, when set, is left out and reaction latency is measured
+// against its bids.
+func rivalProfiles(args []string) error {
+	fs := flag.NewFlagSet("rivals", flag.ContinueOnError)
+	format := fs.String("format", "table", "table or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "table" && *format != "json" {
+		return fmt.Errorf("invalid -format %q (want table or json)", *format)
+	}
+
+	ctx := context.Background()
+	client, hook, poolId, err := dialHook(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	bids, err := hook.GetBidHistory(&bind.CallOpts{Context: ctx}, poolId)
+	if err != nil {
+		return fmt.Errorf("failed to get bid history: %w", err)
+	}
+	profiles := rivals.Build(bids, common.HexToAddress(os.Getenv("OPERATOR_ADDRESS")))
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(profiles)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BIDDER\tBIDS\tLAST BLOCK\tINTERVAL\tEXCESS\tLATENCY\tDEPOSIT\tMAX RENT\tCEILING")
+	for _, p := range profiles {
+		latency := "-"
+		if p.Reactions > 0 {
+			latency = fmt.Sprintf("%d (%dx)", p.Latency, p.Reactions)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%.1fx\t%s\t%s\n",
+			p.Bidder.Hex(), p.Bids, p.LastBlock, blockOrDash(p.Interval), units.FormatWei(p.Excess),
+			latency, p.DepositMultiple(), units.FormatWei(p.MaxRent), units.FormatWei(p.Ceiling()))
+	}
+	return tw.Flush()
+}
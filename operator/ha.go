@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/leader"
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// errStandby refuses to sign while another operator holds the lease
+var errStandby = errors.New("not the leader; another operator holds the lease")
+
+// openState opens the store at STATE_FILE. The store is ours alone until
+// we exit, so a manual command run against a live operator stops here. So
+// does the second operator of an HA pair started on the first's state file:
+// a standby books the ledger every tick and needs a store of its own.
+func openState(paired bool) (*store.Store, error) {
+	path := getEnvOrDefault("STATE_FILE", "operator_state.json")
+	st, err := store.Open(path)
+	switch {
+	case errors.Is(err, store.ErrLocked) && paired:
+		return nil, fmt.Errorf("%w; each operator of an HA pair needs its own STATE_FILE", err)
+	case errors.Is(err, store.ErrLocked):
+		return nil, fmt.Errorf("%w; stop the running operator, or act from its status view", err)
+	}
+	return st, err
+}
+
+// newLease sets up the election LEADER_ELECTION names, or returns nil for
+// an operator running alone. The lease lasts LEADER_LEASE_BLOCKS blocks
+// and is renewed every block, so a standby takes over within that many
+// blocks of the leader going quiet.
+func newLease(ctx context.Context, address common.Address, blockTime time.Duration) (*leader.Lease, string, error) {
+	backend := os.Getenv("LEADER_ELECTION")
+	if backend == "" {
+		return nil, "", nil
+	}
+
+	blocks, err := strconv.ParseUint(getEnvOrDefault("LEADER_LEASE_BLOCKS", "3"), 10, 64)
+	if err != nil || blocks < 2 {
+		return nil, "", fmt.Errorf("invalid LEADER_LEASE_BLOCKS %q: want at least 2", os.Getenv("LEADER_LEASE_BLOCKS"))
+	}
+	ttl := time.Duration(blocks) * blockTime
+	holder := getEnvOrDefault("LEADER_ID", leader.DefaultHolder())
+	name := getEnvOrDefault("LEADER_KEY", "auction-pool-operator:"+address.Hex())
+
+	var elector leader.Elector
+	var where string
+	switch backend {
+	case "file":
+		where = getEnvOrDefault("LEADER_LOCK_FILE", "operator.lock")
+		elector = leader.NewFileLock(where, holder)
+
+	case "redis":
+		url := os.Getenv("LEADER_REDIS_URL")
+		if url == "" {
+			return nil, "", fmt.Errorf("LEADER_REDIS_URL required for redis election")
+		}
+		opts, err := redis.ParseURL(url)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid LEADER_REDIS_URL: %w", err)
+		}
+		where = opts.Addr
+		elector = leader.NewRedis(redis.NewClient(opts), name, holder, ttl)
+
+	case "sql":
+		driver, dsn := getEnvOrDefault("LEADER_SQL_DRIVER", "postgres"), os.Getenv("LEADER_SQL_DSN")
+		if dsn == "" {
+			return nil, "", fmt.Errorf("LEADER_SQL_DSN required for sql election")
+		}
+		db, err := sql.Open(driver, dsn)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open lease database: %w", err)
+		}
+		if elector, err = leader.NewSQL(ctx, db, name, holder, ttl); err != nil {
+			db.Close()
+			return nil, "", err
+		}
+		where = driver
+
+	default:
+		return nil, "", fmt.Errorf("unknown LEADER_ELECTION %q (want file, redis or sql)", backend)
+	}
+
+	desc := fmt.Sprintf("%s election via %s as %s, %d block lease", backend, where, holder, blocks)
+	return leader.NewLease(elector, ttl), desc, nil
+}
+
+// elect renews our lease and reports changes of role. A new leader first
+// waits for the key's in-flight transactions, which the old leader may
+// have sent, so it doesn't act on a state they are about to change.
+func (op *Operator) elect(ctx context.Context) {
+	if op.lease == nil {
+		return
+	}
+	leading, err := op.lease.Renew(ctx)
+	if err != nil {
+		op.log().Error("Failed to renew leader lease", zap.Error(err))
+	}
+	if leading == op.leading {
+		return
+	}
+
+	op.leading, op.handover = leading, leading
+	if leading {
+		op.log().Info("Took over as leader")
+		op.alert("leader", alert.Warning, "Operator took over as leader", op.address.Hex()+" now signs from this instance")
+	} else {
+		op.log().Warn("Lost the leader lease, standing by")
+		op.alert("standby", alert.Warning, "Operator stepped down to standby", fmt.Sprint(err))
+	}
+}
+
+// acting reports whether this tick may bid and set fees: we lead, and any
+// handover has settled. A standby still reads the chain and books the
+// ledger every tick, so it is current when it takes over.
+func (op *Operator) acting(ctx context.Context) bool {
+	if op.lease == nil {
+		return true
+	}
+	if !op.lease.Held() {
+		op.log().Info("Standby: another operator leads, not bidding or setting fees")
+		return false
+	}
+	if !op.handover {
+		return true
+	}
+
+	pending, err := op.client.PendingNonceAt(ctx, op.address)
+	if err != nil {
+		op.log().Error("Failed to get pending nonce", zap.Error(err))
+		return false
+	}
+	mined, err := op.client.NonceAt(ctx, op.address, nil)
+	if err != nil {
+		op.log().Error("Failed to get nonce", zap.Error(err))
+		return false
+	}
+	if pending > mined {
+		op.log().Info("Waiting for in-flight transactions from the previous leader", zap.Uint64("count", pending-mined))
+		return false
+	}
+	op.handover = false
+	return true
+}
+
+// mayTransact refuses to sign unless we hold the lease
+func (op *Operator) mayTransact() error {
+	if op.lease != nil && !op.lease.Held() {
+		return errStandby
+	}
+	return nil
+}
+
+// releaseLease hands the lease back so a standby needn't wait it out
+func (op *Operator) releaseLease() {
+	if op.lease == nil || !op.leading {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), op.tickTimeout)
+	defer cancel()
+	if err := op.lease.Release(ctx); err != nil {
+		op.log().Error("Failed to release leader lease", zap.Error(err))
+	}
+	op.leading = false
+}
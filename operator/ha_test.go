@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"auction-pool/operator/store"
+)
+
+// chdir runs the rest of the test from dir, where the default relative
+// paths land
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// TestPairOnOneHost starts both operators of a file-lock pair side by side
+// with the default paths, then with a state file each
+func TestPairOnOneHost(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("LEADER_ELECTION", "file")
+	t.Setenv("LEADER_LOCK_FILE", "")
+	t.Setenv("STATE_FILE", "")
+	ctx := context.Background()
+
+	// start elects and opens the state store as newOperator does
+	start := func(t *testing.T, wantLead bool) (*store.Store, error) {
+		t.Helper()
+		lease, _, err := newLease(ctx, us, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leading, err := lease.Renew(ctx)
+		if err != nil || leading != wantLead {
+			t.Fatalf("Renew() = %v, %v, want %v", leading, err, wantLead)
+		}
+		t.Cleanup(func() { lease.Release(ctx) })
+		return openState(lease != nil)
+	}
+
+	first, err := start(t, true)
+	if err != nil {
+		t.Fatalf("first operator: %v", err)
+	}
+	// The second stands by, and is told its state file is taken
+	_, err = start(t, false)
+	if !errors.Is(err, store.ErrLocked) || !strings.Contains(err.Error(), "needs its own STATE_FILE") {
+		t.Fatalf("second operator on the default state file: %v, want ErrLocked naming STATE_FILE", err)
+	}
+
+	t.Setenv("STATE_FILE", "standby_state.json")
+	second, err := start(t, false)
+	if err != nil {
+		t.Fatalf("second operator on a state file of its own: %v", err)
+	}
+	first.Close()
+	second.Close()
+}
@@ -0,0 +1,118 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Fetch reads the pool's events in [from, to] in chunks of step blocks,
+// since many RPC providers cap the range of one log query
+func Fetch(ctx context.Context, hook *contracts.AuctionPoolHookFilterer, poolId [32]byte, from, to, step uint64) ([]Event, error) {
+	if step == 0 {
+		return nil, fmt.Errorf("block step must be positive")
+	}
+
+	var events []Event
+	for start := from; start <= to; start += step {
+		end := start + step - 1
+		if end > to || end < start {
+			end = to
+		}
+		chunk, err := fetchRange(ctx, hook, poolId, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+		events = append(events, chunk...)
+		if end == to {
+			break
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Block != events[j].Block {
+			return events[i].Block < events[j].Block
+		}
+		return events[i].Index < events[j].Index
+	})
+	return events, nil
+}
+
+func fetchRange(ctx context.Context, hook *contracts.AuctionPoolHookFilterer, poolId [32]byte, from, to uint64) ([]Event, error) {
+	opts := &bind.FilterOpts{Start: from, End: &to, Context: ctx}
+	pool := [][32]byte{poolId}
+	var events []Event
+	at := func(raw types.Log) Event { return Event{Block: raw.BlockNumber, Index: raw.Index} }
+
+	bids, err := hook.FilterBidSubmitted(opts, pool, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter BidSubmitted: %w", err)
+	}
+	for bids.Next() {
+		ev := at(bids.Event.Raw)
+		ev.BidSubmitted = &Bid{Bidder: bids.Event.Bidder, RentPerBlock: bids.Event.RentPerBlock, Deposit: bids.Event.Deposit}
+		events = append(events, ev)
+	}
+	if err := bids.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read BidSubmitted: %w", err)
+	}
+
+	changes, err := hook.FilterManagerChanged(opts, pool, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter ManagerChanged: %w", err)
+	}
+	for changes.Next() {
+		ev := at(changes.Event.Raw)
+		ev.ManagerChanged = &ManagerChange{OldManager: changes.Event.OldManager, NewManager: changes.Event.NewManager, RentPerBlock: changes.Event.RentPerBlock}
+		events = append(events, ev)
+	}
+	if err := changes.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read ManagerChanged: %w", err)
+	}
+
+	rents, err := hook.FilterRentCollected(opts, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter RentCollected: %w", err)
+	}
+	for rents.Next() {
+		ev := at(rents.Event.Raw)
+		ev.RentCollected = rents.Event.Amount
+		events = append(events, ev)
+	}
+	if err := rents.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read RentCollected: %w", err)
+	}
+
+	fees, err := hook.FilterFeeUpdated(opts, pool, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter FeeUpdated: %w", err)
+	}
+	for fees.Next() {
+		ev := at(fees.Event.Raw)
+		ev.FeeUpdated = &FeeChange{Block: ev.Block, Fee: fees.Event.NewFee.Uint64()}
+		events = append(events, ev)
+	}
+	if err := fees.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read FeeUpdated: %w", err)
+	}
+
+	withdrawals, err := hook.FilterWithdrawalFeeCharged(opts, pool, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter WithdrawalFeeCharged: %w", err)
+	}
+	for withdrawals.Next() {
+		ev := at(withdrawals.Event.Raw)
+		ev.WithdrawalFee = withdrawals.Event.Fee
+		events = append(events, ev)
+	}
+	if err := withdrawals.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read WithdrawalFeeCharged: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,210 @@
+// Package history rebuilds the manager tenures of a pool from the hook's
+// event logs.
+package history
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ActivationDelay mirrors ACTIVATION_DELAY in AuctionPoolHook
+const ActivationDelay = 5
+
+// Why a tenure ended
+const (
+	EndOutbid   = "outbid"   // a higher queued bid reached its activation block
+	EndDepleted = "depleted" // rent owed reached the manager's deposit
+	EndUnknown  = "unknown"  // the logs don't tell the two apart
+)
+
+// Event is one hook log of the pool. Exactly one of the payload fields is
+// set.
+type Event struct {
+	Block uint64
+	Index uint
+
+	BidSubmitted   *Bid
+	ManagerChanged *ManagerChange
+	RentCollected  *big.Int
+	FeeUpdated     *FeeChange
+	WithdrawalFee  *big.Int
+}
+
+// Bid is a BidSubmitted log
+type Bid struct {
+	Bidder       common.Address
+	RentPerBlock *big.Int
+	Deposit      *big.Int
+}
+
+// ManagerChange is a ManagerChanged log
+type ManagerChange struct {
+	OldManager   common.Address
+	NewManager   common.Address
+	RentPerBlock *big.Int
+}
+
+// FeeChange is a FeeUpdated log
+type FeeChange struct {
+	Block uint64 `json:"block"`
+	Fee   uint64 `json:"fee"`
+}
+
+// State is the pool's auction state when the logs start, so a tenure
+// already running there can be accounted for
+type State struct {
+	Manager        common.Address
+	RentPerBlock   *big.Int
+	ManagerDeposit *big.Int
+	LastRentBlock  uint64
+}
+
+// Tenure is one manager's term
+type Tenure struct {
+	Manager      common.Address `json:"manager"`
+	RentPerBlock *big.Int       `json:"rentPerBlock"`
+	// Deposit is nil when the winning bid predates the logs
+	Deposit *big.Int `json:"deposit"`
+	// StartBlock is zero for a tenure that began before the logs
+	StartBlock uint64 `json:"startBlock"`
+	// EndBlock is zero while the tenure lasts
+	EndBlock uint64 `json:"endBlock,omitempty"`
+	// RentPaid includes the rent taken silently on handover when the
+	// deposit is known
+	RentPaid       *big.Int    `json:"rentPaid"`
+	EndReason      string      `json:"endReason,omitempty"`
+	FeeChanges     []FeeChange `json:"feeChanges"`
+	WithdrawalFees *big.Int    `json:"withdrawalFees"`
+
+	// remaining is the deposit left in the hook, nil if unknown
+	remaining *big.Int
+	lastRent  uint64
+}
+
+func newTenure(manager common.Address, rent, deposit *big.Int, start uint64) *Tenure {
+	t := &Tenure{
+		Manager:        manager,
+		RentPerBlock:   rent,
+		StartBlock:     start,
+		RentPaid:       new(big.Int),
+		FeeChanges:     []FeeChange{},
+		WithdrawalFees: new(big.Int),
+		lastRent:       start,
+	}
+	if deposit != nil {
+		t.Deposit = new(big.Int).Set(deposit)
+		t.remaining = new(big.Int).Set(deposit)
+	}
+	return t
+}
+
+// Reconstruct replays events, in chain order, into the tenures they
+// describe. start is the state before the first event, or nil if unknown.
+func Reconstruct(start *State, events []Event) []*Tenure {
+	var (
+		tenures []*Tenure
+		current *Tenure
+		queued  *Bid
+		queueAt uint64
+	)
+
+	if start != nil && start.Manager != (common.Address{}) {
+		current = newTenure(start.Manager, start.RentPerBlock, nil, 0)
+		if start.ManagerDeposit != nil {
+			current.remaining = new(big.Int).Set(start.ManagerDeposit)
+		}
+		current.lastRent = start.LastRentBlock
+		tenures = append(tenures, current)
+	}
+
+	for _, ev := range events {
+		switch {
+		case ev.BidSubmitted != nil:
+			queued, queueAt = ev.BidSubmitted, ev.Block
+
+		case ev.RentCollected != nil:
+			if current == nil {
+				continue
+			}
+			current.RentPaid.Add(current.RentPaid, ev.RentCollected)
+			if current.remaining != nil {
+				current.remaining.Sub(current.remaining, ev.RentCollected)
+			}
+			current.lastRent = ev.Block
+
+		case ev.FeeUpdated != nil:
+			if current != nil {
+				current.FeeChanges = append(current.FeeChanges, *ev.FeeUpdated)
+			}
+
+		case ev.WithdrawalFee != nil:
+			if current != nil {
+				current.WithdrawalFees.Add(current.WithdrawalFees, ev.WithdrawalFee)
+			}
+
+		case ev.ManagerChanged != nil:
+			change := ev.ManagerChanged
+			if current == nil && change.OldManager != (common.Address{}) {
+				// The old tenure began before the logs and no state was given
+				current = newTenure(change.OldManager, nil, nil, 0)
+				tenures = append(tenures, current)
+			}
+			if current != nil {
+				current.end(ev.Block, change.NewManager, queued, queueAt)
+			}
+
+			var deposit *big.Int
+			if queued != nil && queued.Bidder == change.NewManager && queued.RentPerBlock.Cmp(change.RentPerBlock) == 0 {
+				deposit = queued.Deposit
+			}
+			queued = nil
+
+			current = nil
+			if change.NewManager != (common.Address{}) {
+				current = newTenure(change.NewManager, change.RentPerBlock, deposit, ev.Block)
+				tenures = append(tenures, current)
+			}
+		}
+	}
+	return tenures
+}
+
+// end closes t at block, booking the rent the hook takes on handover and
+// inferring which _updateAuction condition fired
+func (t *Tenure) end(block uint64, next common.Address, queued *Bid, queuedAt uint64) {
+	t.EndBlock = block
+
+	var owed *big.Int
+	if t.RentPerBlock != nil && block > t.lastRent {
+		owed = new(big.Int).Mul(new(big.Int).SetUint64(block-t.lastRent), t.RentPerBlock)
+	}
+
+	depleted := false
+	if t.remaining != nil && owed != nil {
+		depleted = owed.Cmp(t.remaining) >= 0
+		final := owed
+		if depleted {
+			final = t.remaining
+		}
+		t.RentPaid.Add(t.RentPaid, final)
+	}
+
+	switch {
+	case next == (common.Address{}):
+		// Only depletion hands the pool over with no bid queued
+		t.EndReason = EndDepleted
+	case queued != nil && block < queuedAt+ActivationDelay:
+		// The queued bid wasn't active yet, so it can only be depletion
+		t.EndReason = EndDepleted
+	case queued != nil:
+		t.EndReason = EndOutbid
+	case depleted:
+		t.EndReason = EndDepleted
+	case t.remaining != nil && owed != nil:
+		// Deposit known and not exhausted: the queued bid activated
+		t.EndReason = EndOutbid
+	default:
+		t.EndReason = EndUnknown
+	}
+}
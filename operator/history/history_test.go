@@ -0,0 +1,112 @@
+package history
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	alice = common.HexToAddress("0xa1")
+	bob   = common.HexToAddress("0xb0")
+	carol = common.HexToAddress("0xc0")
+)
+
+func bid(block uint64, bidder common.Address, rent, deposit int64) Event {
+	return Event{Block: block, BidSubmitted: &Bid{Bidder: bidder, RentPerBlock: big.NewInt(rent), Deposit: big.NewInt(deposit)}}
+}
+
+func change(block uint64, from, to common.Address, rent int64) Event {
+	return Event{Block: block, Index: 1, ManagerChanged: &ManagerChange{OldManager: from, NewManager: to, RentPerBlock: big.NewInt(rent)}}
+}
+
+func rent(block uint64, amount int64) Event {
+	return Event{Block: block, Index: 2, RentCollected: big.NewInt(amount)}
+}
+
+func TestReconstruct(t *testing.T) {
+	events := []Event{
+		bid(10, alice, 10, 1000),
+		change(20, common.Address{}, alice, 10),
+		{Block: 25, FeeUpdated: &FeeChange{Block: 25, Fee: 500}},
+		rent(30, 100),
+		{Block: 35, WithdrawalFee: big.NewInt(7)},
+		bid(40, bob, 20, 400),
+		// Alice owes 150 of her remaining 900 when Bob's bid activates
+		change(45, alice, bob, 20),
+		rent(50, 100),
+		// Bob owes 300 of his remaining 300: depleted, no bid queued
+		change(65, bob, common.Address{}, 0),
+		bid(70, carol, 30, 3000),
+		change(80, common.Address{}, carol, 30),
+	}
+
+	tenures := Reconstruct(nil, events)
+	if len(tenures) != 3 {
+		t.Fatalf("Reconstruct() returned %d tenures, want 3", len(tenures))
+	}
+
+	a, b, c := tenures[0], tenures[1], tenures[2]
+	if a.Manager != alice || a.StartBlock != 20 || a.EndBlock != 45 || a.EndReason != EndOutbid {
+		t.Errorf("alice = %+v", a)
+	}
+	if a.RentPaid.Int64() != 250 || a.WithdrawalFees.Int64() != 7 || len(a.FeeChanges) != 1 || a.FeeChanges[0].Fee != 500 {
+		t.Errorf("alice paid %s, earned %s, fee changes %v", a.RentPaid, a.WithdrawalFees, a.FeeChanges)
+	}
+	if b.Deposit.Int64() != 400 || b.RentPaid.Int64() != 400 || b.EndReason != EndDepleted {
+		t.Errorf("bob = %+v", b)
+	}
+	if c.Manager != carol || c.EndBlock != 0 || c.EndReason != "" || c.Deposit.Int64() != 3000 {
+		t.Errorf("carol = %+v", c)
+	}
+}
+
+func TestEndReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		start  *State
+		events []Event
+		want   string
+	}{
+		{
+			"queued bid not yet active",
+			&State{Manager: alice, RentPerBlock: big.NewInt(10), ManagerDeposit: big.NewInt(100), LastRentBlock: 10},
+			[]Event{bid(18, bob, 20, 400), change(20, alice, bob, 20)},
+			EndDepleted,
+		},
+		{
+			"depleted before the queued bid activates is told from the state",
+			&State{Manager: alice, RentPerBlock: big.NewInt(10), ManagerDeposit: big.NewInt(100), LastRentBlock: 10},
+			[]Event{change(20, alice, bob, 20)},
+			EndDepleted,
+		},
+		{
+			"deposit left over",
+			&State{Manager: alice, RentPerBlock: big.NewInt(10), ManagerDeposit: big.NewInt(1000), LastRentBlock: 10},
+			[]Event{change(20, alice, bob, 20)},
+			EndOutbid,
+		},
+		{
+			"no state and no bid",
+			nil,
+			[]Event{change(20, alice, bob, 20)},
+			EndUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenures := Reconstruct(tt.start, tt.events)
+			if len(tenures) != 2 {
+				t.Fatalf("Reconstruct() returned %d tenures, want 2", len(tenures))
+			}
+			if got := tenures[0].EndReason; got != tt.want {
+				t.Errorf("EndReason = %q, want %q", got, tt.want)
+			}
+			if tenures[0].StartBlock != 0 || tenures[0].Manager != alice {
+				t.Errorf("tenure before the logs = %+v", tenures[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/treasury"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// errFunding holds a bid back while the treasury tops the hot wallet up
+var errFunding = errors.New("hot wallet is being topped up from the treasury")
+
+// hotWallet is the treasury behind the operator's bidding key. Top-ups
+// are signed by the treasury's own signer, sweeps by the hot key.
+type hotWallet struct {
+	treasury treasury.Signer
+	hot      treasury.Signer
+	policy   treasury.Policy
+
+	// sweepDue is set when refunds or fee proceeds land in the hot wallet,
+	// and at start for whatever an earlier run left there. A mined sweep
+	// clears it unless more landed while the sweep was in flight: landed
+	// counts arrivals, and swept is the count the last sweep was sent at.
+	sweepDue      bool
+	landed, swept uint64
+
+	// kept is the bid the last top-up was sent to fund, until a bid is
+	// sent. Sweeps leave that much be, so a bid called off after its
+	// top-up lands doesn't send the funds straight back and pay for both
+	// transfers; they wait for the next bid instead.
+	kept *big.Int
+}
+
+// proceedsLanded notes refunds or fee proceeds arriving in the hot wallet
+func (w *hotWallet) proceedsLanded() {
+	w.sweepDue = true
+	w.landed++
+}
+
+// newHotWallet sets up the treasury TREASURY_ADDRESS names, or returns nil
+// when the operator's key holds all its capital. The treasury signs through
+// TREASURY_SIGNER: an encrypted keystore file, or a Clef-compatible
+// external signer; never the hot key.
+func newHotWallet(hotKey *ecdsa.PrivateKey) (*hotWallet, string, error) {
+	addr := os.Getenv("TREASURY_ADDRESS")
+	if addr == "" {
+		return nil, "", nil
+	}
+	if !common.IsHexAddress(addr) {
+		return nil, "", fmt.Errorf("invalid TREASURY_ADDRESS %q", addr)
+	}
+	treasuryAddr := common.HexToAddress(addr)
+
+	var signer treasury.Signer
+	switch backend := os.Getenv("TREASURY_SIGNER"); backend {
+	case "keystore":
+		path := os.Getenv("TREASURY_KEYSTORE")
+		passFile := os.Getenv("TREASURY_PASSWORD_FILE")
+		if path == "" || passFile == "" {
+			return nil, "", errors.New("TREASURY_KEYSTORE and TREASURY_PASSWORD_FILE required for keystore signing")
+		}
+		pass, err := os.ReadFile(passFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read treasury password: %w", err)
+		}
+		if signer, err = treasury.LoadKeystore(path, strings.TrimRight(string(pass), "\r\n")); err != nil {
+			return nil, "", err
+		}
+
+	case "clef":
+		url := os.Getenv("TREASURY_SIGNER_URL")
+		if url == "" {
+			return nil, "", errors.New("TREASURY_SIGNER_URL required for clef signing")
+		}
+		var err error
+		if signer, err = treasury.NewExternal(url, treasuryAddr); err != nil {
+			return nil, "", err
+		}
+
+	default:
+		return nil, "", fmt.Errorf("TREASURY_SIGNER must be keystore or clef, not %q", backend)
+	}
+
+	hot := treasury.NewKeySigner(hotKey)
+	if signer.Address() != treasuryAddr {
+		return nil, "", fmt.Errorf("treasury signer holds %s, not TREASURY_ADDRESS %s", signer.Address().Hex(), treasuryAddr.Hex())
+	}
+	if treasuryAddr == hot.Address() {
+		return nil, "", errors.New("TREASURY_ADDRESS must not be the operator's own key")
+	}
+
+	capStr := os.Getenv("HOT_BALANCE_CAP")
+	if capStr == "" {
+		return nil, "", errors.New("HOT_BALANCE_CAP required with a treasury")
+	}
+	balanceCap, err := units.ParseWei(capStr)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid HOT_BALANCE_CAP: %w", err)
+	}
+	reserve, err := units.ParseWei(getEnvOrDefault("HOT_GAS_RESERVE", "0.02eth"))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid HOT_GAS_RESERVE: %w", err)
+	}
+	sweepMin, err := units.ParseWei(getEnvOrDefault("SWEEP_MIN", "0.01eth"))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid SWEEP_MIN: %w", err)
+	}
+
+	w := &hotWallet{
+		treasury: signer,
+		hot:      hot,
+		policy:   treasury.Policy{Cap: balanceCap, GasReserve: reserve, SweepMin: sweepMin},
+		sweepDue: true,
+	}
+	desc := fmt.Sprintf("%s via %s, hot wallet capped at %s with %s for gas",
+		treasuryAddr.Hex(), os.Getenv("TREASURY_SIGNER"), units.FormatWei(balanceCap), units.FormatWei(reserve))
+	return w, desc, nil
+}
+
+// fund makes sure the hot wallet can pay need plus gas, topping it up from
+// the treasury if not. It returns errFunding while a top-up is in flight.
+func (op *Operator) fund(ctx context.Context, need *big.Int) error {
+	w := op.wallet
+	if w == nil {
+		return nil
+	}
+	if op.tracker.HasPending(txmgr.KindTreasuryTopUp, op.poolId) {
+		return errFunding
+	}
+
+	balance, err := op.client.BalanceAt(ctx, op.address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get hot wallet balance: %w", err)
+	}
+	amount, err := w.policy.TopUp(balance, need)
+	if err != nil {
+		return err
+	}
+	if amount.Sign() == 0 {
+		return nil
+	}
+
+	if err := op.mayTransact(); err != nil {
+		return err
+	}
+	fees, err := treasury.SuggestFees(ctx, op.client)
+	if err != nil {
+		return err
+	}
+	tx, err := treasury.Transfer(ctx, op.client, w.treasury, op.address, amount, fees)
+	if err != nil {
+		return fmt.Errorf("failed to top up hot wallet: %w", err)
+	}
+
+	op.log().Info("Topping up hot wallet from the treasury",
+		zap.Stringer("amount", amount),
+		zap.Stringer("balance", balance),
+		zap.Stringer("need", need))
+	op.trackTx(tx, reorg.Anchor{}, txmgr.KindTreasuryTopUp, nil, 0)
+	w.kept = new(big.Int).Set(need)
+	return errFunding
+}
+
+// awaitFunds tops the hot wallet up for need and waits for it to land
+func (op *Operator) awaitFunds(ctx context.Context, need *big.Int) error {
+	for {
+		err := op.fund(ctx, need)
+		if !errors.Is(err, errFunding) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", errFunding, ctx.Err())
+		case <-time.After(op.tickInterval):
+		}
+		op.checkPendingTxs(ctx)
+	}
+}
+
+// sweep sends what refunds and fee proceeds left in the hot wallet back to
+// the treasury, once nothing of ours is in flight
+func (op *Operator) sweep(ctx context.Context) {
+	w := op.wallet
+	if w == nil || len(op.tracker.List()) > 0 {
+		return
+	}
+
+	balance, err := op.client.BalanceAt(ctx, op.address, nil)
+	if err != nil {
+		op.log().Error("Failed to get hot wallet balance", zap.Error(err))
+		return
+	}
+	if !w.sweepDue && balance.Cmp(w.policy.Cap) <= 0 {
+		return
+	}
+	available := new(big.Int).Set(balance)
+	if w.kept != nil {
+		available.Sub(available, w.kept)
+	}
+
+	fees, err := treasury.SuggestFees(ctx, op.client)
+	if err != nil {
+		op.log().Error("Failed to price sweep", zap.Error(err))
+		return
+	}
+	amount := w.policy.Sweep(available, fees.Max())
+	if amount.Sign() == 0 {
+		// Too little to sweep; what a kept bid held back waits for it
+		if w.kept == nil {
+			w.sweepDue = false
+		}
+		return
+	}
+	if err := op.mayTransact(); err != nil {
+		return
+	}
+
+	tx, err := treasury.Transfer(ctx, op.client, w.hot, w.treasury.Address(), amount, fees)
+	if err != nil {
+		op.log().Error("Failed to sweep hot wallet", zap.Error(err))
+		op.alert("sweep", alert.Warning, "Failed to sweep hot wallet to treasury", err.Error())
+		return
+	}
+	w.swept = w.landed
+
+	op.log().Info("Sweeping hot wallet back to the treasury", zap.Stringer("amount", amount), zap.Stringer("balance", balance), zap.Stringer("kept", w.kept))
+	ptx := op.trackTx(tx, reorg.Anchor{}, txmgr.KindTreasurySweep, nil, 0)
+	if err := op.settle(ctx, tx, ptx); err != nil {
+		op.txLog(ptx).Error("Sweep failed", zap.Error(err))
+	}
+}
+
+// onSweep settles the sweep due once a sweep is mined
+func (op *Operator) onSweep() {
+	if w := op.wallet; w != nil && w.swept == w.landed {
+		w.sweepDue = false
+	}
+}
+
+// topUpsSpent stops keeping funds back once the bid they were for is sent
+func (op *Operator) topUpsSpent() {
+	if op.wallet != nil {
+		op.wallet.kept = nil
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"auction-pool/operator/rpcclient"
+	"auction-pool/operator/store"
+	"auction-pool/operator/treasury"
+	"auction-pool/operator/txmgr"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var chainID = big.NewInt(1337)
+
+func eth(milli int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(milli), big.NewInt(1e15))
+}
+
+// fakeChain is a chain of free plain transfers: sent transactions wait
+// until mine includes them. Calls the tests don't make panic through the
+// nil embedded Backend.
+type fakeChain struct {
+	rpcclient.Backend
+
+	mu       sync.Mutex
+	head     uint64
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+	pool     []*types.Transaction
+	sent     []*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+}
+
+func newFakeChain(balances map[common.Address]*big.Int) *fakeChain {
+	return &fakeChain{head: 100, balances: balances, nonces: map[common.Address]uint64{}, receipts: map[common.Hash]*types.Receipt{}}
+}
+
+func (c *fakeChain) credit(addr common.Address, amount *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.balances[addr] = new(big.Int).Add(c.balance(addr), amount)
+}
+
+func (c *fakeChain) balance(addr common.Address) *big.Int {
+	if b, ok := c.balances[addr]; ok {
+		return b
+	}
+	return new(big.Int)
+}
+
+// mine includes every sent transaction in the next block
+func (c *fakeChain) mine() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.head++
+	for _, tx := range c.pool {
+		from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+		if err != nil {
+			panic(err)
+		}
+		c.balances[from] = new(big.Int).Sub(c.balance(from), tx.Value())
+		c.balances[*tx.To()] = new(big.Int).Add(c.balance(*tx.To()), tx.Value())
+		c.nonces[from]++
+		c.receipts[tx.Hash()] = &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			TxHash:            tx.Hash(),
+			GasUsed:           treasury.TransferGas,
+			EffectiveGasPrice: new(big.Int),
+			BlockNumber:       new(big.Int).SetUint64(c.head),
+		}
+	}
+	c.pool = nil
+}
+
+func (c *fakeChain) BalanceAt(_ context.Context, addr common.Address, _ *big.Int) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return new(big.Int).Set(c.balance(addr)), nil
+}
+
+func (c *fakeChain) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+func (c *fakeChain) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &types.Header{Number: new(big.Int).SetUint64(c.head)}, nil
+}
+
+func (c *fakeChain) ChainID(context.Context) (*big.Int, error) {
+	return chainID, nil
+}
+
+func (c *fakeChain) NonceAt(_ context.Context, addr common.Address, _ *big.Int) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nonces[addr], nil
+}
+
+func (c *fakeChain) PendingNonceAt(_ context.Context, addr common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nonce := c.nonces[addr]
+	for _, tx := range c.pool {
+		if from, _ := types.Sender(types.LatestSignerForChainID(chainID), tx); from == addr {
+			nonce++
+		}
+	}
+	return nonce, nil
+}
+
+func (c *fakeChain) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pool = append(c.pool, tx)
+	c.sent = append(c.sent, tx)
+	return nil
+}
+
+func (c *fakeChain) TransactionReceipt(_ context.Context, hash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.receipts[hash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func (c *fakeChain) Close() {}
+
+func (c *fakeChain) sentTxs() []*types.Transaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*types.Transaction(nil), c.sent...)
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// walletOperator is an operator whose hot wallet holds 100 of a 1000 cap,
+// backed by a treasury holding 5000 on a fake chain
+func walletOperator(t *testing.T) (*Operator, *fakeChain, common.Address) {
+	t.Helper()
+
+	op := testOperator(t)
+	op.privateKey = newKey(t)
+	op.address = crypto.PubkeyToAddress(op.privateKey.PublicKey)
+	op.tracker = txmgr.NewTracker(op.store)
+	op.tickInterval, op.tickTimeout = time.Millisecond, time.Second
+
+	vault := treasury.NewKeySigner(newKey(t))
+	chain := newFakeChain(map[common.Address]*big.Int{op.address: eth(100), vault.Address(): eth(5000)})
+	client, err := rpcclient.New(rpcclient.Config{}, []string{"fake"}, map[string]rpcclient.Backend{"fake": chain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	op.client = client
+	op.wallet = &hotWallet{
+		treasury: vault,
+		hot:      treasury.NewKeySigner(op.privateKey),
+		policy:   treasury.Policy{Cap: eth(1000), GasReserve: eth(20), SweepMin: eth(10)},
+	}
+	return op, chain, vault.Address()
+}
+
+// unmined runs fn with a deadline too short for its transaction to be
+// mined, leaving it tracked as pending
+func unmined(fn func(context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	fn(ctx)
+}
+
+func TestFundThenBid(t *testing.T) {
+	op, chain, vault := walletOperator(t)
+	op.wallet.sweepDue = true
+	ctx := context.Background()
+
+	// Short of the bid plus gas reserve: the treasury sends the difference
+	if err := op.fund(ctx, eth(500)); !errors.Is(err, errFunding) {
+		t.Fatalf("fund() = %v, want errFunding", err)
+	}
+	sent := chain.sentTxs()
+	if len(sent) != 1 || *sent[0].To() != op.address || sent[0].Value().Cmp(eth(420)) != 0 {
+		t.Fatalf("sent %+v, want a top-up of 420", sent)
+	}
+	if ptx := op.tracker.List(); len(ptx) != 1 || ptx[0].Kind != txmgr.KindTreasuryTopUp || ptx[0].From != vault {
+		t.Fatalf("tracking %+v, want the top-up from the treasury", ptx)
+	}
+
+	// Nothing more is sent, and nothing swept, while it is in flight
+	if err := op.fund(ctx, eth(500)); !errors.Is(err, errFunding) {
+		t.Fatalf("fund() with the top-up in flight = %v, want errFunding", err)
+	}
+	unmined(op.sweep)
+	if n := len(chain.sentTxs()); n != 1 {
+		t.Fatalf("sent %d transactions with the top-up in flight, want 1", n)
+	}
+
+	// The next tick finds it mined and bids
+	chain.mine()
+	op.checkPendingTxs(ctx)
+	if len(op.tracker.List()) != 0 {
+		t.Fatalf("still tracking %+v", op.tracker.List())
+	}
+	if err := op.fund(ctx, eth(500)); err != nil {
+		t.Fatalf("fund() after the top-up = %v, want nil", err)
+	}
+	if entries := op.store.Accounting(); len(entries) != 2 || entries[1].Kind != store.EntryTreasuryTopUp || entries[1].Amount.Cmp(eth(420)) != 0 {
+		t.Errorf("booked %+v, want the top-up's gas and the top-up", entries)
+	}
+}
+
+func TestSweepKeepsTopUp(t *testing.T) {
+	op, chain, vault := walletOperator(t)
+	op.wallet.sweepDue = true
+	ctx := context.Background()
+
+	if err := op.fund(ctx, eth(500)); !errors.Is(err, errFunding) {
+		t.Fatalf("fund() = %v, want errFunding", err)
+	}
+	chain.mine()
+	op.checkPendingTxs(ctx)
+
+	// The decision flipped to no bid: the funds wait for the next one
+	unmined(op.sweep)
+	if n := len(chain.sentTxs()); n != 1 {
+		t.Fatalf("sent %d transactions, want only the top-up", n)
+	}
+	if !op.wallet.sweepDue {
+		t.Error("sweepDue cleared while the top-up held the sweep back")
+	}
+
+	// Proceeds beyond the kept bid are swept
+	chain.credit(op.address, eth(300))
+	unmined(op.sweep)
+	sent := chain.sentTxs()
+	if len(sent) != 2 || *sent[1].To() != vault || sent[1].Value().Cmp(eth(300)) != 0 {
+		t.Fatalf("sent %+v, want a sweep of 300", sent)
+	}
+
+	// Once the bid is sent, nothing is kept back
+	chain.mine()
+	op.checkPendingTxs(ctx)
+	op.topUpsSpent()
+	if op.wallet.kept != nil {
+		t.Errorf("kept %v after the bid was sent", op.wallet.kept)
+	}
+}
+
+func TestSweepDue(t *testing.T) {
+	op, chain, vault := walletOperator(t)
+	ctx := context.Background()
+	tenure := common.HexToHash("0x7e")
+
+	// Under the cap with nothing landed, nothing is swept
+	unmined(op.sweep)
+	if n := len(chain.sentTxs()); n != 0 {
+		t.Fatalf("swept with nothing due: %d transactions", n)
+	}
+
+	// Rent paid from the deposit lands nothing in the hot wallet
+	op.recordEvent(store.EntryRentPaid, eth(5), eventLog(101, 0), tenure)
+	if op.wallet.sweepDue {
+		t.Fatal("rent paid set sweepDue")
+	}
+
+	// A refund does; the sweep keeps the gas reserve
+	chain.credit(op.address, eth(300))
+	op.recordEvent(store.EntryDepositRefunded, eth(300), eventLog(102, 0), tenure)
+	if !op.wallet.sweepDue {
+		t.Fatal("refund did not set sweepDue")
+	}
+	unmined(op.sweep)
+	sent := chain.sentTxs()
+	if len(sent) != 1 || *sent[0].To() != vault || sent[0].Value().Cmp(eth(380)) != 0 {
+		t.Fatalf("sent %+v, want a sweep of 380", sent)
+	}
+	if !op.wallet.sweepDue {
+		t.Error("sweepDue cleared before the sweep was mined")
+	}
+
+	// Fees withdrawn while the sweep is in flight stay due after it
+	chain.credit(op.address, eth(50))
+	op.recordEvent(store.EntryFeesWithdrawn, eth(50), eventLog(103, 0), tenure)
+	chain.mine()
+	op.checkPendingTxs(ctx)
+	if !op.wallet.sweepDue {
+		t.Fatal("sweepDue cleared with fees landed after the sweep was sent")
+	}
+
+	unmined(op.sweep)
+	chain.mine()
+	op.checkPendingTxs(ctx)
+	sent = chain.sentTxs()
+	if len(sent) != 2 || sent[1].Value().Cmp(eth(50)) != 0 {
+		t.Fatalf("sent %+v, want a second sweep of 50", sent)
+	}
+	if op.wallet.sweepDue {
+		t.Error("sweepDue still set after the sweep was mined")
+	}
+}
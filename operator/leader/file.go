@@ -0,0 +1,63 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileLock elects the process holding an exclusive lock on a file, for a
+// pair on one host. The kernel drops the lock when the holder exits, so a
+// standby takes over on its next attempt.
+type FileLock struct {
+	path   string
+	holder string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileLock elects through the lock file at path
+func NewFileLock(path, holder string) *FileLock {
+	return &FileLock{path: path, holder: holder}
+}
+
+func (l *FileLock) Acquire(context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f != nil {
+		return true, nil
+	}
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return false, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	ok, err := tryLock(f)
+	if err != nil || !ok {
+		f.Close()
+		return false, err
+	}
+
+	// Name the holder for whoever looks at the file
+	if err := f.Truncate(0); err == nil {
+		f.WriteAt([]byte(l.holder+"\n"), 0)
+	}
+	l.f = f
+	return true, nil
+}
+
+func (l *FileLock) Release(context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := unlock(l.f)
+	if cerr := l.f.Close(); err == nil {
+		err = cerr
+	}
+	l.f = nil
+	return err
+}
@@ -0,0 +1,86 @@
+// Package leader elects which operator of an active/passive pair signs
+// transactions. The leader renews its hold on an Elector every block; a
+// standby that finds the lease free or lapsed takes over. The lease stops
+// granting the right to sign before the backend can hand it to the
+// standby, so the pair never signs from the same key at once.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Elector grants leadership to at most one holder at a time
+type Elector interface {
+	// Acquire takes leadership, or renews it if we already hold it, and
+	// reports whether we hold it now
+	Acquire(ctx context.Context) (bool, error)
+
+	// Release gives leadership up if we hold it
+	Release(ctx context.Context) error
+}
+
+// DefaultHolder names this process to the other operator of the pair
+func DefaultHolder() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// Lease tracks our hold on an elector. We act as leader only until a fifth
+// of the TTL before the backend could let the lease lapse, leaving margin
+// for clock drift and for a transaction already being signed.
+type Lease struct {
+	elector Elector
+	ttl     time.Duration
+
+	// now is the clock, replaced in tests
+	now func() time.Time
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+// NewLease holds elector's leadership, renewed at least every ttl
+func NewLease(elector Elector, ttl time.Duration) *Lease {
+	return &Lease{elector: elector, ttl: ttl, now: time.Now}
+}
+
+// Renew takes or keeps leadership and reports whether we hold it. A failed
+// renewal leaves any earlier grant to run out on its own.
+func (l *Lease) Renew(ctx context.Context) (bool, error) {
+	start := l.now()
+	ok, err := l.elector.Acquire(ctx)
+	if err != nil {
+		return l.Held(), err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if ok {
+		l.until = start.Add(l.ttl - l.ttl/5)
+	} else {
+		l.until = time.Time{}
+	}
+	return ok, nil
+}
+
+// Held reports whether we may act as leader right now
+func (l *Lease) Held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.now().Before(l.until)
+}
+
+// Release stops acting as leader and hands the lease back
+func (l *Lease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	l.until = time.Time{}
+	l.mu.Unlock()
+	return l.elector.Release(ctx)
+}
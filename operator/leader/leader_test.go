@@ -0,0 +1,176 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeElector answers Acquire with ok and err
+type fakeElector struct {
+	ok  bool
+	err error
+}
+
+func (f *fakeElector) Acquire(context.Context) (bool, error) { return f.ok, f.err }
+func (f *fakeElector) Release(context.Context) error         { return nil }
+
+func TestLease(t *testing.T) {
+	e := &fakeElector{ok: true}
+	l := NewLease(e, 10*time.Second)
+	now := time.Unix(1_700_000_000, 0)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if ok, err := l.Renew(ctx); !ok || err != nil || !l.Held() {
+		t.Fatalf("Renew() = %v, %v; Held() = %v", ok, err, l.Held())
+	}
+
+	// A failing backend leaves the grant to lapse a fifth of the TTL early
+	e.err = errors.New("connection refused")
+	now = now.Add(7 * time.Second)
+	if ok, err := l.Renew(ctx); !ok || err == nil {
+		t.Errorf("Renew() during an outage = %v, %v, want the earlier grant and the error", ok, err)
+	}
+	now = now.Add(time.Second)
+	if l.Held() {
+		t.Error("Held() after 8s of a 10s lease")
+	}
+
+	e.err, e.ok = nil, false
+	if ok, _ := l.Renew(ctx); ok || l.Held() {
+		t.Error("lease held after the elector refused it")
+	}
+}
+
+func TestFileLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operator.lock")
+	a, b := NewFileLock(path, "a"), NewFileLock(path, "b")
+	ctx := context.Background()
+
+	if ok, err := a.Acquire(ctx); !ok || err != nil {
+		t.Fatalf("a.Acquire() = %v, %v", ok, err)
+	}
+	if ok, err := a.Acquire(ctx); !ok || err != nil {
+		t.Fatalf("a.Acquire() renewal = %v, %v", ok, err)
+	}
+	if ok, err := b.Acquire(ctx); ok || err != nil {
+		t.Fatalf("b.Acquire() while a holds the lock = %v, %v", ok, err)
+	}
+
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := b.Acquire(ctx); !ok || err != nil {
+		t.Fatalf("b.Acquire() after release = %v, %v", ok, err)
+	}
+	b.Release(ctx)
+}
+
+func TestRedis(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+
+	ttl := 3 * time.Second
+	a := NewRedis(client, "operator", "a", ttl)
+	b := NewRedis(client, "operator", "b", ttl)
+	ctx := context.Background()
+
+	if ok, err := a.Acquire(ctx); !ok || err != nil {
+		t.Fatalf("a.Acquire() = %v, %v", ok, err)
+	}
+	if ok, err := b.Acquire(ctx); ok || err != nil {
+		t.Fatalf("b.Acquire() while a holds the lease = %v, %v", ok, err)
+	}
+
+	// Renewal pushes expiry out
+	srv.FastForward(2 * time.Second)
+	if ok, _ := a.Acquire(ctx); !ok {
+		t.Fatal("a could not renew its lease")
+	}
+	srv.FastForward(2 * time.Second)
+	if ok, _ := b.Acquire(ctx); ok {
+		t.Fatal("b took a renewed lease")
+	}
+
+	// b takes over once a stops renewing
+	srv.FastForward(ttl)
+	if ok, _ := b.Acquire(ctx); !ok {
+		t.Fatal("b could not take over a lapsed lease")
+	}
+
+	// a can't release what it no longer holds
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := srv.Get("operator"); got != "b" {
+		t.Errorf("lease holder = %q after a stale release, want b", got)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "leases.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		t.Skipf("sqlite unavailable: %v", err)
+	}
+
+	ttl := 3 * time.Second
+	a, err := NewSQL(ctx, db, "operator", "a", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewSQL(ctx, db, "operator", "b", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	a.now = func() time.Time { return now }
+	b.now = a.now
+
+	if ok, err := a.Acquire(ctx); !ok || err != nil {
+		t.Fatalf("a.Acquire() = %v, %v", ok, err)
+	}
+	if ok, err := b.Acquire(ctx); ok || err != nil {
+		t.Fatalf("b.Acquire() while a holds the lease = %v, %v", ok, err)
+	}
+
+	now = now.Add(2 * time.Second)
+	if ok, _ := a.Acquire(ctx); !ok {
+		t.Fatal("a could not renew its lease")
+	}
+	now = now.Add(2 * time.Second)
+	if ok, _ := b.Acquire(ctx); ok {
+		t.Fatal("b took a renewed lease")
+	}
+
+	now = now.Add(ttl)
+	if ok, _ := b.Acquire(ctx); !ok {
+		t.Fatal("b could not take over a lapsed lease")
+	}
+	if err := a.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := a.Acquire(ctx); ok {
+		t.Error("a's stale release freed b's lease")
+	}
+
+	if err := b.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := a.Acquire(ctx); !ok {
+		t.Error("a could not take a released lease")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package leader
+
+import (
+	"errors"
+	"os"
+)
+
+func tryLock(*os.File) (bool, error) {
+	return false, errors.New("file lock election is not supported on this platform")
+}
+
+func unlock(*os.File) error {
+	return nil
+}
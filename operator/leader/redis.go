@@ -0,0 +1,60 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript sets the lease key to the holder with a TTL if it is free,
+// or extends it if the holder already has it. The server expires the key,
+// so holders' clocks don't matter.
+var acquireScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current == false then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 1
+end
+if current == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Redis elects through a lease key on any Redis-compatible server
+type Redis struct {
+	client redis.Scripter
+	key    string
+	holder string
+	ttl    time.Duration
+}
+
+// NewRedis leases key on client for ttl at a time
+func NewRedis(client redis.Scripter, key, holder string, ttl time.Duration) *Redis {
+	return &Redis{client: client, key: key, holder: holder, ttl: ttl}
+}
+
+func (r *Redis) Acquire(ctx context.Context) (bool, error) {
+	n, err := acquireScript.Run(ctx, r.client, []string{r.key}, r.holder, r.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire redis lease %s: %w", r.key, err)
+	}
+	return n == 1, nil
+}
+
+func (r *Redis) Release(ctx context.Context) error {
+	if err := releaseScript.Run(ctx, r.client, []string{r.key}, r.holder).Err(); err != nil {
+		return fmt.Errorf("failed to release redis lease %s: %w", r.key, err)
+	}
+	return nil
+}
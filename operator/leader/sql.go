@@ -0,0 +1,64 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// leaseTable holds one row per lease. Expiry is in unix milliseconds of the
+// holders' clocks, which must agree to well within the TTL.
+const leaseTable = `CREATE TABLE IF NOT EXISTS operator_leases (
+	name       TEXT PRIMARY KEY,
+	holder     TEXT NOT NULL,
+	expires_at BIGINT NOT NULL
+)`
+
+// acquireLease inserts the lease, or takes it over when it is ours or has
+// lapsed. The upsert syntax is shared by PostgreSQL and SQLite.
+const acquireLease = `INSERT INTO operator_leases (name, holder, expires_at) VALUES ($1, $2, $3)
+ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+WHERE operator_leases.holder = excluded.holder OR operator_leases.expires_at < $4`
+
+const releaseLease = `DELETE FROM operator_leases WHERE name = $1 AND holder = $2`
+
+// SQL elects through a lease row in a shared database
+type SQL struct {
+	db     *sql.DB
+	name   string
+	holder string
+	ttl    time.Duration
+
+	// now is the clock, replaced in tests
+	now func() time.Time
+}
+
+// NewSQL leases name in db for ttl at a time, creating the lease table if
+// needed
+func NewSQL(ctx context.Context, db *sql.DB, name, holder string, ttl time.Duration) (*SQL, error) {
+	if _, err := db.ExecContext(ctx, leaseTable); err != nil {
+		return nil, fmt.Errorf("failed to create lease table: %w", err)
+	}
+	return &SQL{db: db, name: name, holder: holder, ttl: ttl, now: time.Now}, nil
+}
+
+func (s *SQL) Acquire(ctx context.Context) (bool, error) {
+	now := s.now()
+	res, err := s.db.ExecContext(ctx, acquireLease, s.name, s.holder, now.Add(s.ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", s.name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", s.name, err)
+	}
+	return n == 1, nil
+}
+
+func (s *SQL) Release(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, releaseLease, s.name, s.holder); err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", s.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package ledger
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var csvHeader = []string{
+	"pool_id", "tenure", "start_block", "end_block",
+	"deposit_locked", "deposit_refunded", "deposit_held",
+	"rent_paid", "gas", "fees_earned", "swap_profit", "rent_claimed", "net",
+}
+
+// WriteCSV writes rows with a header line. Amounts are wei; a zero tenure
+// or block is left empty.
+func WriteCSV(w io.Writer, rows []PnL) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		tenure := ""
+		if r.Tenure != (common.Hash{}) {
+			tenure = r.Tenure.Hex()
+		}
+		if err := cw.Write([]string{
+			r.PoolId.Hex(), tenure, block(r.StartBlock), block(r.EndBlock),
+			wei(r.DepositLocked), wei(r.DepositRefunded), wei(r.DepositHeld),
+			wei(r.RentPaid), wei(r.Gas), wei(r.FeesEarned), wei(r.SwapProfit), wei(r.RentClaimed), wei(r.Net),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes rows as an indented JSON array
+func WriteJSON(w io.Writer, rows []PnL) error {
+	if rows == nil {
+		rows = []PnL{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+func block(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+func wei(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.String()
+}
@@ -0,0 +1,257 @@
+// Package ledger turns the operator's accounting entries into a
+// double-entry journal and reports profit and loss per pool and per
+// tenure. All amounts are wei.
+package ledger
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Accounts. Assets and expenses carry debit balances, income credit
+// balances. Wallet is the hot bidding wallet; moves between it and the
+// treasury change neither income nor expenses.
+const (
+	Wallet      = "assets:wallet"
+	Treasury    = "assets:treasury"
+	Deposit     = "assets:hook_deposit"
+	RentExpense = "expenses:rent"
+	GasExpense  = "expenses:gas"
+	FeeIncome   = "income:withdrawal_fees"
+	SwapIncome  = "income:swap_profit"
+	RentIncome  = "income:lp_rent"
+)
+
+// rules maps each entry kind to the accounts it debits and credits
+var rules = map[string]struct{ debit, credit string }{
+	store.EntryDepositLocked:   {Deposit, Wallet},
+	store.EntryDepositRefunded: {Wallet, Deposit},
+	store.EntryRentPaid:        {RentExpense, Deposit},
+	store.EntryFeesWithdrawn:   {Wallet, FeeIncome},
+	store.EntryGas:             {GasExpense, Wallet},
+	store.EntrySwapProfit:      {Wallet, SwapIncome},
+	store.EntryRentClaimed:     {Wallet, RentIncome},
+	store.EntryTreasuryTopUp:   {Wallet, Treasury},
+	store.EntryTreasurySweep:   {Treasury, Wallet},
+	store.EntryTreasuryGas:     {GasExpense, Treasury},
+}
+
+// Posting moves Amount into (debit) or out of (credit) an account
+type Posting struct {
+	Account string
+	Debit   bool
+	Amount  *big.Int
+}
+
+// Transaction is one accounting entry as balanced postings
+type Transaction struct {
+	Entry    *store.AccountingEntry
+	Postings [2]Posting
+}
+
+// Journal books every entry, failing on kinds it has no rule for
+func Journal(entries []*store.AccountingEntry) ([]Transaction, error) {
+	txs := make([]Transaction, 0, len(entries))
+	for _, e := range entries {
+		rule, ok := rules[e.Kind]
+		if !ok {
+			return nil, fmt.Errorf("no ledger rule for accounting entry kind %q", e.Kind)
+		}
+		if e.Amount == nil || e.Amount.Sign() < 0 {
+			return nil, fmt.Errorf("%s entry at block %d has invalid amount %v", e.Kind, e.Block, e.Amount)
+		}
+		txs = append(txs, Transaction{
+			Entry: e,
+			Postings: [2]Posting{
+				{Account: rule.debit, Debit: true, Amount: e.Amount},
+				{Account: rule.credit, Amount: e.Amount},
+			},
+		})
+	}
+	return txs, nil
+}
+
+// Balances sums txs per account, debits positive and credits negative.
+// The balances of a journal always sum to zero.
+func Balances(txs []Transaction) map[string]*big.Int {
+	out := map[string]*big.Int{}
+	for _, tx := range txs {
+		for _, p := range tx.Postings {
+			b, ok := out[p.Account]
+			if !ok {
+				b = new(big.Int)
+				out[p.Account] = b
+			}
+			if p.Debit {
+				b.Add(b, p.Amount)
+			} else {
+				b.Sub(b, p.Amount)
+			}
+		}
+	}
+	return out
+}
+
+// DepositHeld is how much of tenure's deposits the hook still holds
+func DepositHeld(entries []*store.AccountingEntry, tenure common.Hash) (*big.Int, error) {
+	var mine []*store.AccountingEntry
+	for _, e := range entries {
+		if e.Tenure == tenure {
+			mine = append(mine, e)
+		}
+	}
+	txs, err := Journal(mine)
+	if err != nil {
+		return nil, err
+	}
+	return balance(Balances(txs), Deposit), nil
+}
+
+func balance(balances map[string]*big.Int, account string) *big.Int {
+	if b, ok := balances[account]; ok {
+		return new(big.Int).Set(b)
+	}
+	return new(big.Int)
+}
+
+// PnL is the profit and loss of one pool or one tenure
+type PnL struct {
+	PoolId common.Hash `json:"poolId"`
+	// Tenure is zero in per-pool reports
+	Tenure     common.Hash `json:"tenure,omitempty"`
+	StartBlock uint64      `json:"startBlock,omitempty"`
+	EndBlock   uint64      `json:"endBlock,omitempty"`
+
+	DepositLocked   *big.Int `json:"depositLocked"`
+	DepositRefunded *big.Int `json:"depositRefunded"`
+	// DepositHeld is locked minus refunded minus rent: still in the hook
+	DepositHeld *big.Int `json:"depositHeld"`
+
+	RentPaid   *big.Int `json:"rentPaid"`
+	Gas        *big.Int `json:"gas"`
+	FeesEarned *big.Int `json:"feesEarned"`
+	SwapProfit *big.Int `json:"swapProfit"`
+	// RentClaimed is rent the pool paid us as an LP
+	RentClaimed *big.Int `json:"rentClaimed"`
+	// Net is income minus expenses
+	Net *big.Int `json:"net"`
+}
+
+func newPnL(poolId, tenure common.Hash, txs []Transaction) PnL {
+	b := Balances(txs)
+	sum := func(kind string) *big.Int {
+		total := new(big.Int)
+		for _, tx := range txs {
+			if tx.Entry.Kind == kind {
+				total.Add(total, tx.Entry.Amount)
+			}
+		}
+		return total
+	}
+
+	// Income has a credit balance, so net is minus income and expenses
+	net := new(big.Int)
+	for _, account := range []string{RentExpense, GasExpense, FeeIncome, SwapIncome, RentIncome} {
+		net.Sub(net, balance(b, account))
+	}
+
+	return PnL{
+		PoolId:          poolId,
+		Tenure:          tenure,
+		DepositLocked:   sum(store.EntryDepositLocked),
+		DepositRefunded: sum(store.EntryDepositRefunded),
+		DepositHeld:     balance(b, Deposit),
+		RentPaid:        balance(b, RentExpense),
+		Gas:             balance(b, GasExpense),
+		FeesEarned:      new(big.Int).Neg(balance(b, FeeIncome)),
+		SwapProfit:      new(big.Int).Neg(balance(b, SwapIncome)),
+		RentClaimed:     new(big.Int).Neg(balance(b, RentIncome)),
+		Net:             net,
+	}
+}
+
+// ByPool reports P&L per pool, ordered by pool ID
+func ByPool(entries []*store.AccountingEntry) ([]PnL, error) {
+	txs, err := Journal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[common.Hash][]Transaction{}
+	for _, tx := range txs {
+		groups[tx.Entry.PoolId] = append(groups[tx.Entry.PoolId], tx)
+	}
+
+	out := make([]PnL, 0, len(groups))
+	for poolId, group := range groups {
+		out = append(out, newPnL(poolId, common.Hash{}, group))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PoolId.Hex() < out[j].PoolId.Hex() })
+	return out, nil
+}
+
+// ByTenure reports P&L per bid: its tenure as manager if it won, or just
+// its gas and refunded deposit if it didn't. Entries booked before tenures
+// were tracked fall under the zero tenure. Rows are ordered by start block,
+// bids that never became manager first.
+func ByTenure(entries []*store.AccountingEntry, tenures []*store.Tenure) ([]PnL, error) {
+	txs, err := Journal(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ pool, tenure common.Hash }
+	groups := map[key][]Transaction{}
+	for _, tx := range txs {
+		k := key{tx.Entry.PoolId, tx.Entry.Tenure}
+		groups[k] = append(groups[k], tx)
+	}
+
+	known := map[common.Hash]*store.Tenure{}
+	for _, t := range tenures {
+		known[t.Id] = t
+	}
+
+	out := make([]PnL, 0, len(groups))
+	for k, group := range groups {
+		row := newPnL(k.pool, k.tenure, group)
+		if t, ok := known[k.tenure]; ok {
+			row.StartBlock, row.EndBlock = t.StartBlock, t.EndBlock
+		}
+		out = append(out, row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].StartBlock != out[j].StartBlock {
+			return out[i].StartBlock < out[j].StartBlock
+		}
+		return out[i].Tenure.Hex() < out[j].Tenure.Hex()
+	})
+	return out, nil
+}
+
+// Handover splits the deposit held for tenure when it ends at block. The
+// hook emits no RentCollected for the rent it takes on handover: it takes
+// what is owed since the last collection, capped at the deposit, and
+// refunds the rest.
+func Handover(t *store.Tenure, held *big.Int, block uint64) (rent, refund *big.Int) {
+	rent = new(big.Int)
+	if block > t.LastRentBlock {
+		rent.Mul(new(big.Int).SetUint64(block-t.LastRentBlock), t.RentPerBlock)
+	}
+	if rent.Cmp(held) > 0 {
+		rent.Set(held)
+	}
+	if rent.Sign() < 0 {
+		rent.SetInt64(0)
+	}
+	refund = new(big.Int).Sub(held, rent)
+	if refund.Sign() < 0 {
+		refund.SetInt64(0)
+	}
+	return rent, refund
+}
@@ -0,0 +1,210 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	poolA  = common.HexToHash("0x0a")
+	poolB  = common.HexToHash("0x0b")
+	won    = common.HexToHash("0x01") // bid that became manager of pool A
+	outbid = common.HexToHash("0x02") // bid displaced from nextBid in pool A
+)
+
+func entry(pool, tenure common.Hash, kind string, amount int64) *store.AccountingEntry {
+	return &store.AccountingEntry{PoolId: pool, Tenure: tenure, Kind: kind, Amount: big.NewInt(amount)}
+}
+
+// history is a won tenure and a lost bid in pool A and gas in pool B
+func history() []*store.AccountingEntry {
+	return []*store.AccountingEntry{
+		entry(poolA, won, store.EntryGas, 5),
+		entry(poolA, won, store.EntryDepositLocked, 1000),
+		entry(poolA, won, store.EntryRentPaid, 300),
+		entry(poolA, won, store.EntrySwapProfit, 450),
+		entry(poolA, won, store.EntryFeesWithdrawn, 40),
+		entry(poolA, won, store.EntryRentPaid, 100),
+		entry(poolA, won, store.EntryDepositRefunded, 600),
+		entry(poolA, outbid, store.EntryGas, 7),
+		entry(poolA, outbid, store.EntryDepositLocked, 500),
+		entry(poolA, outbid, store.EntryDepositRefunded, 500),
+		entry(poolB, common.Hash{}, store.EntryGas, 3),
+		entry(poolB, common.Hash{}, store.EntryRentClaimed, 20),
+	}
+}
+
+func TestJournal(t *testing.T) {
+	txs, err := Journal(history())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	total := new(big.Int)
+	for _, b := range Balances(txs) {
+		total.Add(total, b)
+	}
+	if total.Sign() != 0 {
+		t.Errorf("trial balance is %s, want 0", total)
+	}
+
+	balances := Balances(txs)
+	want := map[string]int64{
+		Wallet:      -1500 + 1100 + 40 - 15 + 450 + 20,
+		Deposit:     0,
+		RentExpense: 400,
+		GasExpense:  15,
+		FeeIncome:   -40,
+		SwapIncome:  -450,
+		RentIncome:  -20,
+	}
+	for account, w := range want {
+		if got := balance(balances, account); got.Cmp(big.NewInt(w)) != 0 {
+			t.Errorf("%s balance = %s, want %d", account, got, w)
+		}
+	}
+
+	if _, err := Journal([]*store.AccountingEntry{entry(poolA, won, "bribe", 1)}); err == nil {
+		t.Error("Journal() accepted an unknown kind")
+	}
+	if _, err := Journal([]*store.AccountingEntry{entry(poolA, won, store.EntryGas, -1)}); err == nil {
+		t.Error("Journal() accepted a negative amount")
+	}
+}
+
+func TestByPool(t *testing.T) {
+	rows, err := ByPool(history())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].PoolId != poolA || rows[1].PoolId != poolB {
+		t.Fatalf("ByPool() = %+v", rows)
+	}
+
+	a := rows[0]
+	// 40 fees + 450 swap profit - 400 rent - 12 gas
+	if a.Net.Int64() != 78 || a.RentPaid.Int64() != 400 || a.Gas.Int64() != 12 || a.DepositHeld.Sign() != 0 {
+		t.Errorf("pool A = %+v", a)
+	}
+	if a.DepositLocked.Int64() != 1500 || a.DepositRefunded.Int64() != 1100 {
+		t.Errorf("pool A deposits = %s locked, %s refunded", a.DepositLocked, a.DepositRefunded)
+	}
+	// 20 rent claimed as an LP - 3 gas
+	if rows[1].Net.Int64() != 17 || rows[1].RentClaimed.Int64() != 20 {
+		t.Errorf("pool B = %+v, want net 17", rows[1])
+	}
+}
+
+func TestTreasury(t *testing.T) {
+	entries := []*store.AccountingEntry{
+		entry(poolA, common.Hash{}, store.EntryTreasuryTopUp, 1000),
+		entry(poolA, common.Hash{}, store.EntryTreasuryGas, 2),
+		entry(poolA, won, store.EntryGas, 3),
+		entry(poolA, common.Hash{}, store.EntryTreasurySweep, 900),
+		entry(poolA, common.Hash{}, store.EntryGas, 1),
+	}
+	txs, err := Journal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances := Balances(txs)
+	for account, w := range map[string]int64{Wallet: 1000 - 3 - 900 - 1, Treasury: -1000 - 2 + 900, GasExpense: 6} {
+		if got := balance(balances, account); got.Cmp(big.NewInt(w)) != 0 {
+			t.Errorf("%s balance = %s, want %d", account, got, w)
+		}
+	}
+
+	// Moving capital is neither income nor expense; only the gas is
+	rows, err := ByPool(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Net.Int64() != -6 || rows[0].Gas.Int64() != 6 {
+		t.Errorf("ByPool() = %+v, want net -6", rows)
+	}
+}
+
+func TestByTenure(t *testing.T) {
+	tenures := []*store.Tenure{{Id: won, PoolId: poolA, RentPerBlock: big.NewInt(10), StartBlock: 100, EndBlock: 140}}
+	rows, err := ByTenure(history(), tenures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("ByTenure() returned %d rows, want 3", len(rows))
+	}
+
+	// Bids that never became manager sort first
+	last := rows[2]
+	if last.Tenure != won || last.StartBlock != 100 || last.EndBlock != 140 || last.Net.Int64() != 85 {
+		t.Errorf("won tenure = %+v", last)
+	}
+	for _, r := range rows[:2] {
+		if r.Tenure == outbid && (r.Net.Int64() != -7 || r.DepositHeld.Sign() != 0) {
+			t.Errorf("outbid bid = %+v", r)
+		}
+	}
+
+	held, err := DepositHeld(history()[:4], won)
+	if err != nil || held.Int64() != 700 {
+		t.Errorf("DepositHeld() = %v, %v, want 700", held, err)
+	}
+}
+
+func TestHandover(t *testing.T) {
+	tenure := &store.Tenure{RentPerBlock: big.NewInt(10), LastRentBlock: 100}
+
+	tests := []struct {
+		name         string
+		held         int64
+		block        uint64
+		rent, refund int64
+	}{
+		{"rent owed since last collection", 1000, 130, 300, 700},
+		{"capped at the deposit", 200, 130, 200, 0},
+		{"same block", 1000, 100, 0, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rent, refund := Handover(tenure, big.NewInt(tt.held), tt.block)
+			if rent.Int64() != tt.rent || refund.Int64() != tt.refund {
+				t.Errorf("Handover() = %s, %s, want %d, %d", rent, refund, tt.rent, tt.refund)
+			}
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	rows, err := ByPool(history())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var csvOut bytes.Buffer
+	if err := WriteCSV(&csvOut, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "pool_id,tenure,") || !strings.HasSuffix(lines[1], ",78") {
+		t.Errorf("WriteCSV() =\n%s", csvOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := WriteJSON(&jsonOut, rows); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []PnL
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[0].Net.Int64() != 78 {
+		t.Errorf("WriteJSON() round trip = %+v", decoded)
+	}
+}
@@ -0,0 +1,157 @@
+// Package logging builds the operator's structured logger. Lines are JSON
+// by default, for log shippers to index; LOG_FORMAT=console lays them out
+// for a terminal. A Trace ties together what one pass of the strategy
+// logs: the state it read, the decision it made, the transactions it sent
+// and, ticks later, their receipts.
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Formats a logger can write
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Field names, shared with the AVS performer (avs/pkg/performer) so one
+// query finds both
+const (
+	KeyPool        = "pool_id"
+	KeyBlock       = "block"
+	KeyTx          = "tx_hash"
+	KeyCorrelation = "correlation_id"
+	KeyDecision    = "decision_id"
+)
+
+// Config is how the operator logs
+type Config struct {
+	Level  zapcore.Level
+	Format string
+}
+
+// FromEnv reads LOG_LEVEL (debug, info, warn or error; default info) and
+// LOG_FORMAT (json or console; default json)
+func FromEnv(getenv func(string) string) (Config, error) {
+	cfg := Config{Level: zapcore.InfoLevel, Format: FormatJSON}
+	if v := getenv("LOG_LEVEL"); v != "" {
+		level, err := zapcore.ParseLevel(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid LOG_LEVEL: %w", err)
+		}
+		cfg.Level = level
+	}
+	if v := getenv("LOG_FORMAT"); v != "" {
+		cfg.Format = v
+	}
+	if cfg.Format != FormatJSON && cfg.Format != FormatConsole {
+		return Config{}, fmt.Errorf("invalid LOG_FORMAT %q: want %s or %s", cfg.Format, FormatJSON, FormatConsole)
+	}
+	return cfg, nil
+}
+
+// New builds a logger writing cfg's format at cfg's level to out
+func New(cfg Config, out io.Writer) *zap.Logger {
+	enc := zap.NewProductionEncoderConfig()
+	var encoder zapcore.Encoder
+	if cfg.Format == FormatConsole {
+		enc.EncodeTime = zapcore.ISO8601TimeEncoder
+		enc.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(enc)
+	} else {
+		encoder = zapcore.NewJSONEncoder(enc)
+	}
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(out), cfg.Level), zap.AddCaller())
+}
+
+// Output is a writer whose destination can change while loggers write to
+// it, as when the status view takes the terminal over
+type Output struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewOutput(w io.Writer) *Output {
+	return &Output{w: w}
+}
+
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.w.Write(p)
+}
+
+// Set sends what is written from now on to w, returning the previous
+// destination
+func (o *Output) Set(w io.Writer) io.Writer {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	prev := o.w
+	o.w = w
+	return prev
+}
+
+// Trace links the log lines and transactions of one pass of the strategy
+type Trace struct {
+	// Correlation is shared by everything one tick or request does
+	Correlation string
+	// Decision is set once the pass decides to act, and is carried by the
+	// transactions sent to carry the decision out
+	Decision string
+}
+
+// NewTrace starts a trace with a fresh correlation ID
+func NewTrace() Trace {
+	return Trace{Correlation: NewID()}
+}
+
+// Fields are the trace's IDs as log fields, leaving out those not set
+func (t Trace) Fields() []zap.Field {
+	var fields []zap.Field
+	if t.Correlation != "" {
+		fields = append(fields, zap.String(KeyCorrelation, t.Correlation))
+	}
+	if t.Decision != "" {
+		fields = append(fields, zap.String(KeyDecision, t.Decision))
+	}
+	return fields
+}
+
+// NewID returns a random 64-bit ID in hex, unique enough to find one pass
+// in a log
+func NewID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Pool is the pool ID field
+func Pool(id common.Hash) zap.Field {
+	return zap.String(KeyPool, id.Hex())
+}
+
+// Block is the block number field
+func Block(n uint64) zap.Field {
+	return zap.Uint64(KeyBlock, n)
+}
+
+// Tx is the transaction hash field
+func Tx(hash common.Hash) zap.Field {
+	return zap.String(KeyTx, hash.Hex())
+}
+
+// Address is a field holding an account
+func Address(key string, addr common.Address) zap.Field {
+	return zap.String(key, addr.Hex())
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestFromEnv(t *testing.T) {
+	cfg, err := FromEnv(env(nil))
+	if err != nil || cfg.Level != zapcore.InfoLevel || cfg.Format != FormatJSON {
+		t.Errorf("FromEnv() defaults = %+v, %v", cfg, err)
+	}
+
+	cfg, err = FromEnv(env(map[string]string{"LOG_LEVEL": "debug", "LOG_FORMAT": "console"}))
+	if err != nil || cfg.Level != zapcore.DebugLevel || cfg.Format != FormatConsole {
+		t.Errorf("FromEnv() = %+v, %v", cfg, err)
+	}
+
+	for _, bad := range []map[string]string{{"LOG_LEVEL": "loud"}, {"LOG_FORMAT": "xml"}} {
+		if _, err := FromEnv(env(bad)); err == nil {
+			t.Errorf("FromEnv(%v) accepted an invalid setting", bad)
+		}
+	}
+}
+
+func TestTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Level: zapcore.InfoLevel, Format: FormatJSON}, &buf)
+
+	pool := common.HexToHash("0x01")
+	tx := common.HexToHash("0x02")
+	trace := NewTrace()
+	trace.Decision = NewID()
+	logger.With(trace.Fields()...).Info("Bid submitted", Pool(pool), Block(7), Tx(tx))
+	logger.Debug("Below the level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want 1: %q", len(lines), buf.String())
+	}
+	var line map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &line); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"level":        "info",
+		"msg":          "Bid submitted",
+		KeyCorrelation: trace.Correlation,
+		KeyDecision:    trace.Decision,
+		KeyPool:        pool.Hex(),
+		KeyBlock:       float64(7),
+		KeyTx:          tx.Hex(),
+	}
+	for k, v := range want {
+		if line[k] != v {
+			t.Errorf("%s = %v, want %v", k, line[k], v)
+		}
+	}
+
+	if len(trace.Correlation) != 16 || trace.Correlation == trace.Decision {
+		t.Errorf("NewID() gave %q and %q", trace.Correlation, trace.Decision)
+	}
+	if fields := (Trace{Correlation: "abc"}).Fields(); len(fields) != 1 {
+		t.Errorf("Fields() of a trace without a decision = %v", fields)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	var first, second bytes.Buffer
+	out := NewOutput(&first)
+	logger := New(Config{Level: zapcore.InfoLevel, Format: FormatConsole}, out)
+
+	logger.Info("before", zap.Int("n", 1))
+	if prev := out.Set(&second); prev != &first {
+		t.Error("Set() did not return the previous destination")
+	}
+	logger.Info("after")
+
+	if !strings.Contains(first.String(), "INFO") || !strings.Contains(first.String(), "before") || strings.Contains(first.String(), "after") {
+		t.Errorf("first output = %q", first.String())
+	}
+	if !strings.Contains(second.String(), "after") {
+		t.Errorf("second output = %q", second.String())
+	}
+}
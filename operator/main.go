@@ -1,3 +1,8 @@
+//go:build ignore
+
+// Legacy raw-ABI operator kept for reference; run with `go run main.go`.
+// The maintained operator lives in main_new.go.
+
 package main
 
 import (
@@ -312,7 +317,7 @@ func (op *Operator) executeStrategy() {
 	if state.CurrentManager == op.address {
 		optimalFee := op.calculateOptimalFee()
 		if shouldUpdateFee(state.CurrentFee, optimalFee) {
-			log.Printf("  \ud83d\udee0\ufe0f  Updating fee from %s to %s", state.CurrentFee.String(), optimalFee.String())
+			log.Printf("  \U0001F6E0\ufe0f  Updating fee from %s to %s", state.CurrentFee.String(), optimalFee.String())
 			err := op.setSwapFee(ctx, optimalFee)
 			if err != nil {
 				log.Printf("  \u274c Failed to set fee: %v", err)
@@ -515,12 +520,12 @@ func (op *Operator) calculateOptimalFee() *big.Int {
 	// Higher volatility = higher fee
 	// Higher volume = lower fee
 
-	// Placeholder: return 0.3% (3000 in hundredths of bps)
+	// Placeholder: return 0.3% (3000 pips)
 	return big.NewInt(3000)
 }
 
 func shouldUpdateFee(currentFee, optimalFee *big.Int) bool {
-	// Update if difference is > 0.1% (100 in hundredths of bps)
+	// Update if difference is > 0.01% (100 pips)
 	threshold := big.NewInt(100)
 
 	diff := new(big.Int).Sub(currentFee, optimalFee)
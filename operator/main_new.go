@@ -3,183 +3,482 @@ package main
 import (
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
+	"auction-pool/operator/alert"
 	"auction-pool/operator/contracts"
+	"auction-pool/operator/leader"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/poolkey"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/response"
+	"auction-pool/operator/rpcclient"
+	"auction-pool/operator/sizing"
+	"auction-pool/operator/store"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
 )
 
 // Improved autonomous operator using generated contract bindings
 type Operator struct {
-	client      *ethclient.Client
+	client      *rpcclient.Client
 	privateKey  *ecdsa.PrivateKey
 	address     common.Address
 	hookAddress common.Address
 	poolId      [32]byte
 	poolKey     contracts.PoolKey
 
-	// Contract bindings
-	hook *contracts.AuctionPoolHook
+	// Contract bindings; criticalHook reads require RPC quorum agreement
+	hook         *contracts.AuctionPoolHook
+	criticalHook *contracts.AuctionPoolHookCaller
+
+	// Persistent state and in-flight transactions, survive restarts
+	store   *store.Store
+	tracker *txmgr.Tracker
+
+	// Loop timing
+	tickInterval time.Duration
+	// rent formats rent per block at the chain's block time
+	rent        units.Rent
+	tickTimeout time.Duration
+
+	// Reorg safety: decisions and receipts must be this many blocks deep
+	confirmations uint64
+	proposals     *reorg.Watcher
 
 	// Strategy parameters
 	profitMargin float64  // Percentage of expected profit to bid
 	minProfit    *big.Int // Minimum profit threshold in wei
+	sizing       sizing.Params
+
+	// Response to a rival queued to replace us: timing, the window seen
+	// this tick and the activation block we last conceded
+	response response.Params
+	window   *response.Window
+	conceded uint64
+
+	// Work handed to the loop by the status view, and whether it paused
+	// the strategy; both are only touched on the loop goroutine
+	requests chan func(context.Context)
+	paused   bool
+
+	// Alerts go to the router when ALERTS_FILE configures one; the deposit
+	// alert fires below runwayAlert blocks
+	alerts      *alert.Router
+	runwayAlert uint64
+
+	// Leader election between an active/passive pair; nil when running
+	// alone. leading is our role at the last renewal, and handover is set
+	// until a new leader has seen the old one's transactions mined.
+	lease    *leader.Lease
+	leading  bool
+	handover bool
+
+	// Treasury that funds the hot key just in time; nil when the key holds
+	// all the capital
+	wallet *hotWallet
+
+	// logger is tagged with the pool. trace links what the pass running
+	// on the loop goroutine logs and sends, and is only touched there.
+	logger *zap.Logger
+	trace  logging.Trace
 }
 
+// logOutput is where the log goes; the status view points it at its pane
+var logOutput = logging.NewOutput(os.Stderr)
+
 func main() {
+	cfg, err := logging.FromEnv(os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	logger := logging.New(cfg, logOutput)
+	defer logger.Sync()
+
+	// Packages that use the standard logger log through ours
+	zap.ReplaceGlobals(logger)
+	zap.RedirectStdLog(logger)
+
+	// Subcommands such as report and history run instead of the loop
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			logger.Fatal("Command failed", zap.String("command", os.Args[1]), zap.Error(err))
+		}
+		return
+	}
+
+	// Cancel everything on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	operator := newOperator(ctx)
+	defer operator.client.Close()
+
+	// Run operator loop until a shutdown signal arrives
+	operator.run(ctx)
+}
+
+// newOperator configures the operator from the environment, connects and
+// reconciles persisted state with the chain, exiting on any failure
+func newOperator(ctx context.Context) *Operator {
+	logger := zap.L()
+
 	// Load configuration from environment
-	rpcURL := os.Getenv("RPC_URL")
-	if rpcURL == "" {
-		rpcURL = "http://localhost:8545"
+	rpcURLs := strings.Split(getEnvOrDefault("RPC_URLS", getEnvOrDefault("RPC_URL", "http://localhost:8545")), ",")
+
+	rpcQuorum, err := strconv.Atoi(getEnvOrDefault("RPC_QUORUM", "1"))
+	if err != nil {
+		logger.Fatal("Invalid RPC_QUORUM", zap.Error(err))
+	}
+
+	rpcMaxLag, err := strconv.ParseUint(getEnvOrDefault("RPC_MAX_LAG", "3"), 10, 64)
+	if err != nil {
+		logger.Fatal("Invalid RPC_MAX_LAG", zap.Error(err))
 	}
 
 	privateKeyHex := os.Getenv("OPERATOR_PRIVATE_KEY")
 	if privateKeyHex == "" {
-		log.Fatal("OPERATOR_PRIVATE_KEY environment variable required")
+		logger.Fatal("OPERATOR_PRIVATE_KEY environment variable required")
 	}
 
 	hookAddress := os.Getenv("HOOK_ADDRESS")
 	if hookAddress == "" {
-		log.Fatal("HOOK_ADDRESS environment variable required")
+		logger.Fatal("HOOK_ADDRESS environment variable required")
+	}
+
+	tickTimeout, err := time.ParseDuration(getEnvOrDefault("TICK_TIMEOUT", "10s"))
+	if err != nil {
+		logger.Fatal("Invalid TICK_TIMEOUT", zap.Error(err))
+	}
+
+	confirmations, err := strconv.ParseUint(getEnvOrDefault("CONFIRMATIONS", "0"), 10, 64)
+	if err != nil {
+		logger.Fatal("Invalid CONFIRMATIONS", zap.Error(err))
 	}
 
-	poolIdHex := os.Getenv("POOL_ID")
-	if poolIdHex == "" {
-		log.Fatal("POOL_ID environment variable required")
+	depositTarget, err := strconv.ParseUint(getEnvOrDefault("DEPOSIT_TARGET_BLOCKS", "300"), 10, 64)
+	if err != nil {
+		logger.Fatal("Invalid DEPOSIT_TARGET_BLOCKS", zap.Error(err))
+	}
+
+	capitalCost, err := strconv.ParseUint(getEnvOrDefault("CAPITAL_COST_BPS", "500"), 10, 64)
+	if err != nil {
+		logger.Fatal("Invalid CAPITAL_COST_BPS", zap.Error(err))
 	}
 
-	// Connect to Ethereum client
-	client, err := ethclient.Dial(rpcURL)
+	inclusionBlocks, err := strconv.ParseUint(getEnvOrDefault("RESPONSE_INCLUSION_BLOCKS", "2"), 10, 64)
 	if err != nil {
-		log.Fatalf("Failed to connect to Ethereum client: %v", err)
+		logger.Fatal("Invalid RESPONSE_INCLUSION_BLOCKS", zap.Error(err))
 	}
 
+	var riskBudget *big.Int
+	if v := os.Getenv("DEPOSIT_RISK_BUDGET"); v != "" {
+		if riskBudget, err = units.ParseWei(v); err != nil || riskBudget.Sign() <= 0 {
+			logger.Fatal("Invalid DEPOSIT_RISK_BUDGET: want an amount such as 5eth", zap.String("value", v))
+		}
+	}
+
+	runwayAlert, err := strconv.ParseUint(getEnvOrDefault("RUNWAY_ALERT_BLOCKS", "50"), 10, 64)
+	if err != nil {
+		logger.Fatal("Invalid RUNWAY_ALERT_BLOCKS", zap.Error(err))
+	}
+
+	var alerts *alert.Router
+	if path := os.Getenv("ALERTS_FILE"); path != "" {
+		if alerts, err = alert.Load(path, logger); err != nil {
+			logger.Fatal("Failed to load alerts", zap.Error(err))
+		}
+		go alerts.Run(ctx)
+	}
+
+	blockTime, err := time.ParseDuration(getEnvOrDefault("BLOCK_TIME", "12s"))
+	if err != nil || blockTime <= 0 {
+		logger.Fatal("Invalid BLOCK_TIME", zap.String("value", os.Getenv("BLOCK_TIME")))
+	}
+
+	// Connect to every configured RPC endpoint
+	client, err := rpcclient.Dial(ctx, rpcclient.Config{
+		URLs:   rpcURLs,
+		Quorum: rpcQuorum,
+		MaxLag: rpcMaxLag,
+		Logger: logger,
+	})
+	if err != nil {
+		logger.Fatal("Failed to connect to Ethereum client", zap.Error(err))
+	}
+	go client.Monitor(ctx)
+
 	// Load private key
 	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
 	privateKey, err := crypto.HexToECDSA(privateKeyHex)
 	if err != nil {
-		log.Fatalf("Failed to load private key: %v", err)
+		logger.Fatal("Failed to load private key", zap.Error(err))
 	}
 
 	publicKey := privateKey.Public()
 	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
 	if !ok {
-		log.Fatal("Error casting public key to ECDSA")
+		logger.Fatal("Error casting public key to ECDSA")
 	}
 
 	address := crypto.PubkeyToAddress(*publicKeyECDSA)
 
-	// Parse pool ID
-	var poolId [32]byte
-	copy(poolId[:], common.FromHex(poolIdHex))
+	wallet, treasuryDesc, err := newHotWallet(privateKey)
+	if err != nil {
+		logger.Fatal("Failed to set up treasury", zap.Error(err))
+	}
+
+	// Only the leader of an HA pair signs with this key
+	lease, election, err := newLease(ctx, address, blockTime)
+	if err != nil {
+		logger.Fatal("Failed to set up leader election", zap.Error(err))
+	}
 
 	// Create contract instance
 	hookAddr := common.HexToAddress(hookAddress)
 	hook, err := contracts.NewAuctionPoolHook(hookAddr, client)
 	if err != nil {
-		log.Fatalf("Failed to create hook binding: %v", err)
+		logger.Fatal("Failed to create hook binding", zap.Error(err))
+	}
+	criticalHook, err := contracts.NewAuctionPoolHookCaller(hookAddr, client.Quorum())
+	if err != nil {
+		logger.Fatal("Failed to create quorum hook binding", zap.Error(err))
 	}
 
-	// Parse pool key from environment
-	poolKey := contracts.PoolKey{
-		Currency0:   common.HexToAddress(getEnvOrDefault("TOKEN0", "0x0000000000000000000000000000000000000000")),
-		Currency1:   common.HexToAddress(getEnvOrDefault("TOKEN1", "0x0000000000000000000000000000000000000000")),
-		Fee:         big.NewInt(3000),
-		TickSpacing: big.NewInt(60),
-		Hooks:       hookAddr,
+	// Refuse to act on a hook the bindings don't describe
+	verifyCtx, cancel := context.WithTimeout(ctx, tickTimeout)
+	codeHash, err := verifyHook(verifyCtx, client.Quorum(), hookAddr, os.Getenv("HOOK_CODE_HASH"), os.Getenv("HOOK_ARTIFACT"))
+	cancel()
+	if err != nil {
+		logger.Fatal("Hook verification failed", zap.Error(err))
 	}
 
+	// Find the pool from POOL_KEY, POOLS_FILE or POOL_ID
+	poolId, poolKey, err := resolvePool(hookAddr)
+	if err != nil {
+		logger.Fatal("Failed to resolve pool", zap.Error(err))
+	}
+
+	// Load state left by a previous run
+	st, err := openState(lease != nil)
+	if err != nil {
+		logger.Fatal("Failed to open state store", zap.Error(err))
+	}
+	tracker := txmgr.NewTracker(st)
+
 	operator := &Operator{
-		client:       client,
-		privateKey:   privateKey,
-		address:      address,
-		hookAddress:  hookAddr,
-		poolId:       poolId,
-		poolKey:      poolKey,
-		hook:         hook,
-		profitMargin: 0.8,              // Bid 80% of expected profit
-		minProfit:    big.NewInt(1e15), // 0.001 ETH minimum
-	}
-
-	log.Printf("=== AuctionPool Autonomous Operator ===")
-	log.Printf("Operator address: %s", address.Hex())
-	log.Printf("Hook address:     %s", hookAddress)
-	log.Printf("Pool ID:          %s", common.Bytes2Hex(poolId[:]))
-	log.Printf("RPC URL:          %s", rpcURL)
-	log.Printf("")
-	log.Printf("Strategy:")
-	log.Printf("  - Profit margin: %.0f%%", operator.profitMargin*100)
-	log.Printf("  - Min profit:    %s wei", operator.minProfit.String())
-	log.Printf("")
-
-	// Run operator loop
-	operator.run()
+		client:        client,
+		privateKey:    privateKey,
+		address:       address,
+		hookAddress:   hookAddr,
+		poolId:        poolId,
+		poolKey:       poolKey,
+		hook:          hook,
+		criticalHook:  criticalHook,
+		store:         st,
+		tracker:       tracker,
+		tickInterval:  blockTime, // Check every block
+		rent:          units.Rent{BlockTime: blockTime},
+		tickTimeout:   tickTimeout,
+		confirmations: confirmations,
+		proposals:     reorg.NewWatcher(confirmations, client),
+		profitMargin:  0.8,              // Bid 80% of expected profit
+		minProfit:     big.NewInt(1e15), // 0.001 ETH minimum
+		sizing: sizing.Params{
+			TargetBlocks:   depositTarget,
+			CapitalCostBps: capitalCost,
+			BlockTime:      blockTime,
+			RiskBudget:     riskBudget,
+		},
+		response: response.Params{
+			InclusionBlocks: inclusionBlocks,
+			Confirmations:   confirmations,
+		},
+		requests:    make(chan func(context.Context)),
+		alerts:      alerts,
+		runwayAlert: runwayAlert,
+		lease:       lease,
+		wallet:      wallet,
+		logger:      logger.With(logging.Pool(common.Hash(poolId))),
+		trace:       logging.NewTrace(),
+	}
+
+	fields := []zap.Field{
+		logging.Address("operator", address),
+		logging.Address("hook", hookAddr),
+		zap.String("hook_code_hash", codeHash.Hex()),
+		zap.String("pool_key", poolkey.String(poolKey)),
+		zap.Strings("rpc_urls", rpcURLs),
+		zap.Int("rpc_quorum", rpcQuorum),
+		zap.Float64("profit_margin", operator.profitMargin),
+		zap.Stringer("min_profit", operator.minProfit),
+		zap.String("min_profit_fmt", units.FormatWei(operator.minProfit)),
+		zap.Duration("tick_timeout", operator.tickTimeout),
+		zap.Uint64("confirmations", operator.confirmations),
+		zap.Uint64("deposit_target_blocks", depositTarget),
+		zap.Uint64("capital_cost_bps", capitalCost),
+		zap.String("state_file", st.Path()),
+	}
+	if riskBudget != nil {
+		fields = append(fields, zap.Stringer("risk_budget", riskBudget), zap.String("risk_budget_fmt", units.FormatWei(riskBudget)))
+	}
+	if alerts != nil {
+		fields = append(fields, zap.String("alerts", os.Getenv("ALERTS_FILE")), zap.Uint64("runway_alert_blocks", runwayAlert))
+	}
+	if lease != nil {
+		fields = append(fields, zap.String("leader_election", election))
+	}
+	if wallet != nil {
+		fields = append(fields, zap.String("treasury", treasuryDesc))
+	}
+	operator.log().Info("AuctionPool autonomous operator starting", fields...)
+
+	if pending := tracker.List(); len(pending) > 0 {
+		operator.log().Info("Resuming in-flight transactions from the previous run", zap.Int("count", len(pending)))
+	}
+
+	// Reconcile persisted state with the chain before acting
+	reconcileCtx, cancel := context.WithTimeout(ctx, tickTimeout)
+	if err := operator.reconcile(reconcileCtx); err != nil {
+		logger.Fatal("Failed to reconcile state with chain", zap.Error(err))
+	}
+	cancel()
+
+	return operator
 }
 
-func (op *Operator) run() {
-	ticker := time.NewTicker(12 * time.Second) // Check every block (~12s)
+func (op *Operator) run(ctx context.Context) {
+	ticker := time.NewTicker(op.tickInterval)
 	defer ticker.Stop()
 
-	log.Println("Starting monitoring loop...")
-	log.Println("")
+	op.log().Info("Starting monitoring loop")
 
 	for {
 		select {
+		case <-ctx.Done():
+			op.shutdown()
+			return
 		case <-ticker.C:
-			op.executeStrategy()
+			op.tick(ctx)
+		case req := <-op.requests:
+			op.trace = logging.NewTrace()
+			reqCtx, cancel := context.WithTimeout(ctx, op.tickTimeout)
+			req(reqCtx)
+			cancel()
 		}
 	}
 }
 
-func (op *Operator) executeStrategy() {
-	ctx := context.Background()
+// do runs fn on the loop goroutine between ticks, so callers outside the
+// loop can read and act on the operator without racing it
+func (op *Operator) do(ctx context.Context, fn func(context.Context)) error {
+	done := make(chan struct{})
+	select {
+	case op.requests <- func(ctx context.Context) { fn(ctx); close(done) }:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tick runs one strategy iteration bounded by the per-tick deadline
+func (op *Operator) tick(ctx context.Context) {
+	tickCtx, cancel := context.WithTimeout(ctx, op.tickTimeout)
+	defer cancel()
 
-	// Get current block number
-	blockNumber, err := op.client.BlockNumber(ctx)
-	if err != nil {
-		log.Printf("Error getting block number: %v", err)
+	op.trace = logging.NewTrace()
+	op.elect(tickCtx)
+	op.checkPendingTxs(tickCtx)
+	if err := op.scanLedger(tickCtx); err != nil {
+		op.log().Error("Failed to book hook events", zap.Error(err))
+	}
+	if op.paused {
+		op.log().Info("Strategy paused, not bidding or setting fees")
+		return
+	}
+	op.executeStrategy(tickCtx)
+}
+
+// shutdown persists in-flight transactions so the next run keeps watching them
+func (op *Operator) shutdown() {
+	op.log().Info("Shutdown signal received, stopping operator")
+	defer op.releaseLease()
+
+	if err := op.tracker.Save(); err != nil {
+		op.log().Error("Failed to persist pending transactions", zap.Error(err))
 		return
 	}
 
-	// Query current pool state using generated bindings
-	state, err := op.hook.PoolAuctions(&bind.CallOpts{Context: ctx}, op.poolId)
+	if pending := op.tracker.List(); len(pending) > 0 {
+		op.log().Info("Persisted in-flight transactions for the next run", zap.Int("count", len(pending)))
+	}
+}
+
+func (op *Operator) executeStrategy(ctx context.Context) {
+	// Pin this tick to one block hash so every read sees the same fork
+	anchor, err := op.headAnchor(ctx)
 	if err != nil {
-		log.Printf("Error getting pool state: %v", err)
+		op.log().Error("Failed to get head block", zap.Error(err))
 		return
 	}
+	blockNumber := anchor.Number
 
-	// Query next pending bid using generated bindings
-	nextBid, err := op.hook.NextBid(&bind.CallOpts{Context: ctx}, op.poolId)
+	// Query current pool state and next pending bid using generated bindings
+	view, err := op.readChainView(ctx, anchor)
 	if err != nil {
-		log.Printf("Error getting next bid: %v", err)
+		op.log().Error("Failed to read hook state", logging.Block(blockNumber), zap.Error(err))
 		return
 	}
 
-	log.Printf("Block %d | Manager: %s | Rent: %s wei/block | Fee: %s",
-		blockNumber,
-		truncateAddress(state.CurrentManager.Hex()),
-		state.RentPerBlock.String(),
-		state.CurrentFee.String())
-
-	// Determine highest current rent to beat
-	highestRent := state.RentPerBlock
-	if nextBid.RentPerBlock.Cmp(highestRent) > 0 {
-		highestRent = nextBid.RentPerBlock
-		log.Printf("  Next bid pending: %s wei/block by %s (activates at block %s)",
-			nextBid.RentPerBlock.String(),
-			truncateAddress(nextBid.Bidder.Hex()),
-			nextBid.ActivationBlock.String())
+	// Drop proposals and re-check in-flight actions whose basis was reorged out
+	op.revalidate(ctx, view)
+
+	op.checkRPC()
+
+	// Keep our persisted bid record in step with the chain
+	op.syncBid(view)
+
+	// The snapshot every decision of this tick is made from
+	op.log().Info("Pool state",
+		logging.Block(blockNumber),
+		zap.String("block_hash", anchor.Hash.Hex()),
+		logging.Address("manager", view.manager),
+		zap.Stringer("rent_per_block", view.rentPerBlock),
+		zap.String("rent_per_block_fmt", op.rent.Format(view.rentPerBlock)),
+		zap.Stringer("manager_deposit", view.managerDeposit),
+		zap.String("manager_deposit_fmt", units.FormatWei(view.managerDeposit)),
+		zap.Uint64("fee", view.currentFee.Uint64()),
+		zap.String("fee_fmt", units.FormatFee(view.currentFee.Uint64())),
+		logging.Address("next_bidder", view.nextBidder),
+		zap.Stringer("next_rent", view.nextRent),
+		zap.String("next_rent_fmt", op.rent.Format(view.nextRent)),
+		zap.Stringer("activation_block", view.activationBlock))
+
+	// A standby stops here, with the chain read and our bid record synced
+	if !op.acting(ctx) {
+		return
 	}
+	op.checkRunway(view, blockNumber)
 
 	// Calculate expected profit
 	expectedProfit := op.estimateProfit()
@@ -187,60 +486,135 @@ func (op *Operator) executeStrategy() {
 	profitableRent.Div(profitableRent, big.NewInt(100))
 
 	// Check if we should bid
-	minBidIncrement := big.NewInt(100) // MIN_BID_INCREMENT from contract
-	requiredBid := new(big.Int).Add(highestRent, minBidIncrement)
-
-	if profitableRent.Cmp(requiredBid) >= 0 && expectedProfit.Cmp(op.minProfit) > 0 {
-		log.Printf("  ✅ Profitable opportunity detected!")
-		log.Printf("    Expected profit: %s wei/block", expectedProfit.String())
-		log.Printf("    Profitable rent: %s wei/block", profitableRent.String())
-		log.Printf("    Required bid:    %s wei/block", requiredBid.String())
-
-		// Submit bid
-		err := op.submitBid(ctx, profitableRent)
-		if err != nil {
-			log.Printf("  ❌ Failed to submit bid: %v", err)
-		} else {
-			log.Printf("  ✓ Bid submitted successfully!")
+	requiredBid := op.requiredBid(view)
+	profitable := profitableRent.Cmp(requiredBid) >= 0 && expectedProfit.Cmp(op.minProfit) > 0
+
+	// A rival queued to replace us leaves until its activation to answer
+	var plan response.Plan
+	op.window = op.responseWindow(view)
+	if op.window != nil {
+		plan = response.Decide(*op.window, blockNumber, op.response, profitable)
+		op.log().Warn("Rival bid queued to replace us",
+			logging.Address("rival", op.window.Rival),
+			zap.Stringer("rent_per_block", op.window.Rent),
+			zap.String("rent_per_block_fmt", op.rent.Format(op.window.Rent)),
+			zap.Uint64("activation_block", op.window.ActivationBlock),
+			zap.Bool("respond", plan.Respond),
+			zap.String("reason", plan.Reason))
+		op.alert(fmt.Sprintf("rival_bid:%d", op.window.ActivationBlock), alert.Warning, "Rival bid queued to replace us",
+			fmt.Sprintf("%s bid %s, activating at block %d: %s", op.window.Rival.Hex(), op.rent.Format(op.window.Rent), op.window.ActivationBlock, plan.Reason))
+		if !plan.Respond {
+			op.concede(anchor, plan)
 		}
 	}
 
+	if op.tracker.HasPending(txmgr.KindSubmitBid, op.poolId) {
+		op.log().Info("Bid transaction still pending, not re-bidding")
+		if op.window != nil && blockNumber >= op.window.Deadline() {
+			op.concede(anchor, response.Plan{Reason: "counter-bid not mined by the deadline"})
+		}
+	} else if op.winning(view) {
+		op.log().Debug("Holding position, no bid needed")
+		op.proposals.Forget(txmgr.KindSubmitBid)
+	} else if profitable && (op.window == nil || plan.Respond) {
+		op.log().Info("Profitable opportunity detected",
+			zap.Stringer("expected_profit", expectedProfit),
+			zap.String("expected_profit_fmt", op.rent.Format(expectedProfit)),
+			zap.Stringer("profitable_rent", profitableRent),
+			zap.String("profitable_rent_fmt", op.rent.Format(profitableRent)),
+			zap.Stringer("required_bid", requiredBid),
+			zap.String("required_bid_fmt", op.rent.Format(requiredBid)))
+		profiles := op.rivalProfiles(ctx, anchor)
+		op.warnRivals(profiles, profitableRent)
+
+		if op.window != nil && plan.Now {
+			op.proposals.Forget(txmgr.KindSubmitBid)
+		}
+		if (op.window != nil && plan.Now) || op.ready(txmgr.KindSubmitBid, anchor) {
+			size, err := sizing.Deposit(op.sizing, profitableRent, expectedProfit, sizing.RivalInterval(profiles))
+			if err != nil {
+				op.log().Warn("Not bidding: deposit could not be sized", zap.Error(err))
+				op.alert("sizing", alert.Warning, "Not bidding: deposit could not be sized", err.Error())
+			} else {
+				op.log().Info("Deposit sized",
+					zap.Stringer("deposit", size.Deposit),
+					zap.String("deposit_fmt", units.FormatWei(size.Deposit)),
+					zap.String("sizing", size.String()))
+				op.recordDecision(anchor, "bid", "profitable rent exceeds required bid; deposit "+size.String(), profitableRent, 0)
+
+				// Submit bid
+				err := op.submitBid(ctx, anchor, profitableRent, size.Deposit)
+				if errors.Is(err, errFunding) {
+					op.log().Info("Waiting for the treasury top-up before bidding")
+				} else if err != nil {
+					op.log().Error("Failed to submit bid", zap.Error(err))
+					op.alert("submit_bid", alert.Warning, "Failed to submit bid", err.Error())
+				}
+			}
+		}
+	} else {
+		op.proposals.Forget(txmgr.KindSubmitBid)
+	}
+
 	// If we're the current manager, optimize fees
-	if state.CurrentManager == op.address {
+	if view.manager == op.address && !op.tracker.HasPending(txmgr.KindSetSwapFee, op.poolId) {
 		optimalFee := op.calculateOptimalFee()
-		if shouldUpdateFee(state.CurrentFee, optimalFee) {
-			log.Printf("  🛠️  Updating fee from %s to %s", state.CurrentFee.String(), optimalFee.String())
-			err := op.setSwapFee(ctx, optimalFee)
+		if !shouldUpdateFee(view.currentFee, optimalFee) {
+			op.proposals.Forget(txmgr.KindSetSwapFee)
+		} else if op.ready(txmgr.KindSetSwapFee, anchor) {
+			op.log().Info("Updating fee",
+				zap.Uint64("fee", view.currentFee.Uint64()),
+				zap.String("fee_fmt", units.FormatFee(view.currentFee.Uint64())),
+				zap.Uint64("new_fee", optimalFee.Uint64()),
+				zap.String("new_fee_fmt", units.FormatFee(optimalFee.Uint64())))
+			op.recordDecision(anchor, "set_fee", "fee drifted past threshold", nil, uint32(optimalFee.Uint64()))
+			err := op.setSwapFee(ctx, anchor, optimalFee)
 			if err != nil {
-				log.Printf("  ❌ Failed to set fee: %v", err)
-			} else {
-				log.Printf("  ✓ Fee updated successfully!")
+				op.log().Error("Failed to set fee", zap.Error(err))
+				op.alert("set_fee", alert.Warning, "Failed to set fee", err.Error())
 			}
 		}
 	}
 
-	log.Println("")
+	// Send refunds and fee proceeds back to the treasury; sweeping is
+	// housekeeping, not part of a decision
+	op.trace.Decision = ""
+	op.sweep(ctx)
 }
 
-func (op *Operator) submitBid(ctx context.Context, rentPerBlock *big.Int) error {
-	// Calculate deposit (rent * 100 blocks minimum)
-	deposit := new(big.Int).Mul(rentPerBlock, big.NewInt(100))
+// transactor signs transactions with the operator's key
+func (op *Operator) transactor(ctx context.Context) (*bind.TransactOpts, error) {
+	if err := op.mayTransact(); err != nil {
+		return nil, err
+	}
 
 	// Get chain ID
 	chainID, err := op.client.ChainID(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %w", err)
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
 	// Create transactor
 	auth, err := bind.NewKeyedTransactorWithChainID(op.privateKey, chainID)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+	auth.Context = ctx
+	return auth, nil
+}
+
+func (op *Operator) submitBid(ctx context.Context, anchor reorg.Anchor, rentPerBlock, deposit *big.Int) error {
+	if err := op.fund(ctx, deposit); err != nil {
+		return err
+	}
+
+	auth, err := op.transactor(ctx)
+	if err != nil {
+		return err
 	}
 
 	// Set transaction value (deposit)
 	auth.Value = deposit
-	auth.Context = ctx
 
 	// Submit bid using generated binding
 	tx, err := op.hook.SubmitBid(auth, op.poolKey, rentPerBlock)
@@ -248,43 +622,115 @@ func (op *Operator) submitBid(ctx context.Context, rentPerBlock *big.Int) error
 		return fmt.Errorf("failed to submit bid: %w", err)
 	}
 
-	log.Printf("  Transaction hash: %s", tx.Hash().Hex())
+	ptx := op.trackTx(tx, anchor, txmgr.KindSubmitBid, rentPerBlock, 0)
+	if prev := op.store.ActiveBid(ptx.PoolId); prev != nil && prev.Status == store.BidQueued {
+		// Our own queued bid is refunded when this one takes its place
+		ptx.Replaces, ptx.ReplacedDeposit = prev.TxHash, prev.Deposit
+		if err := op.tracker.Track(ptx); err != nil {
+			op.txLog(ptx).Error("Failed to persist pending transaction", zap.Error(err))
+		}
+	}
+	op.topUpsSpent()
+	op.putBid(&store.ActiveBid{
+		PoolId:       ptx.PoolId,
+		TxHash:       ptx.Hash,
+		RentPerBlock: rentPerBlock,
+		Deposit:      deposit,
+		Status:       store.BidSent,
+	})
+
+	return op.settle(ctx, tx, ptx)
+}
 
-	// Wait for confirmation
+// settle waits for a tracked transaction and applies its receipt
+func (op *Operator) settle(ctx context.Context, tx *types.Transaction, ptx *txmgr.PendingTx) error {
+	// Wait for confirmation within the tick deadline; if it expires the
+	// tracker keeps watching the tx on later ticks and after a restart
 	receipt, err := bind.WaitMined(ctx, op.client, tx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		op.txLog(ptx).Info("Transaction not mined before the deadline, tracking it as pending")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to wait for transaction: %w", err)
 	}
 
 	if receipt.Status != types.ReceiptStatusSuccessful {
+		op.onReceipt(ptx, receipt)
+		op.resolvePending(ptx)
 		return fmt.Errorf("transaction failed with status %d", receipt.Status)
 	}
 
+	// With a confirmation depth the receipt is only applied once buried,
+	// so a reorg that drops it leaves the tx tracked as pending
+	if op.confirmations == 0 {
+		op.onReceipt(ptx, receipt)
+		op.resolvePending(ptx)
+	} else {
+		op.txLog(ptx).Info("Transaction mined, awaiting confirmations",
+			logging.Block(receipt.BlockNumber.Uint64()),
+			zap.Uint64("confirmations", op.confirmations))
+	}
+
 	return nil
 }
 
-func (op *Operator) setSwapFee(ctx context.Context, newFee *big.Int) error {
-	// Get chain ID
-	chainID, err := op.client.ChainID(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get chain ID: %w", err)
+// trackTx records a broadcast transaction as in flight, under the current
+// trace
+func (op *Operator) trackTx(tx *types.Transaction, anchor reorg.Anchor, kind string, rent *big.Int, fee uint32) *txmgr.PendingTx {
+	ptx := &txmgr.PendingTx{
+		Hash:        tx.Hash(),
+		Kind:        kind,
+		PoolId:      common.Hash(op.poolId),
+		Nonce:       tx.Nonce(),
+		Value:       tx.Value(),
+		Rent:        rent,
+		Fee:         fee,
+		SentAt:      time.Now(),
+		AnchorBlock: anchor.Number,
+		AnchorHash:  anchor.Hash,
+		Correlation: op.trace.Correlation,
+		Decision:    op.trace.Decision,
 	}
+	// Treasury top-ups come from another account, with their own nonces
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil && from != op.address {
+		ptx.From = from
+	}
+	op.txLog(ptx).Info("Transaction sent",
+		zap.Uint64("nonce", ptx.Nonce),
+		zap.Stringer("value", ptx.Value),
+		zap.Uint64("anchor_block", anchor.Number))
+	if err := op.tracker.Track(ptx); err != nil {
+		op.txLog(ptx).Error("Failed to persist pending transaction", zap.Error(err))
+	}
+	return ptx
+}
 
-	// Create transactor
-	auth, err := bind.NewKeyedTransactorWithChainID(op.privateKey, chainID)
+// log is the logger for the pass running on the loop goroutine
+func (op *Operator) log() *zap.Logger {
+	return op.logger.With(op.trace.Fields()...)
+}
+
+// txLog is the logger for a tracked transaction, under the trace it was
+// sent with rather than the current one
+func (op *Operator) txLog(ptx *txmgr.PendingTx) *zap.Logger {
+	trace := logging.Trace{Correlation: ptx.Correlation, Decision: ptx.Decision}
+	return op.logger.With(append(trace.Fields(), logging.Tx(ptx.Hash), zap.String("kind", ptx.Kind))...)
+}
+
+func (op *Operator) setSwapFee(ctx context.Context, anchor reorg.Anchor, newFee *big.Int) error {
+	auth, err := op.transactor(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to create transactor: %w", err)
+		return err
 	}
 
-	auth.Context = ctx
-
 	// Set swap fee using generated binding
 	tx, err := op.hook.SetSwapFee(auth, op.poolKey, newFee)
 	if err != nil {
 		return fmt.Errorf("failed to set swap fee: %w", err)
 	}
 
-	log.Printf("  Transaction hash: %s", tx.Hash().Hex())
+	op.trackTx(tx, anchor, txmgr.KindSetSwapFee, nil, uint32(newFee.Uint64()))
 
 	return nil
 }
@@ -309,12 +755,12 @@ func (op *Operator) calculateOptimalFee() *big.Int {
 	// Higher volatility = higher fee
 	// Higher volume = lower fee
 
-	// Placeholder: return 0.3% (3000 in hundredths of bps)
+	// Placeholder: return 0.3% (3000 pips)
 	return big.NewInt(3000)
 }
 
 func shouldUpdateFee(currentFee, optimalFee *big.Int) bool {
-	// Update if difference is > 0.1% (100 in hundredths of bps)
+	// Update if difference is > 0.01% (100 pips)
 	threshold := big.NewInt(100)
 
 	diff := new(big.Int).Sub(currentFee, optimalFee)
@@ -0,0 +1,500 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"auction-pool/operator/ledger"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/poolkey"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/rivals"
+	"auction-pool/operator/sizing"
+	"auction-pool/operator/store"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// readHead pins a read of our pool to the current head
+func (op *Operator) readHead(ctx context.Context) (reorg.Anchor, *chainView, error) {
+	anchor, err := op.headAnchor(ctx)
+	if err != nil {
+		return anchor, nil, fmt.Errorf("failed to get head block: %w", err)
+	}
+	view, err := op.readChainView(ctx, anchor)
+	return anchor, view, err
+}
+
+// bidPreview is what a manual bid would do, checked against the pool at
+// anchor
+type bidPreview struct {
+	anchor   reorg.Anchor
+	view     *chainView
+	rent     *big.Int
+	required *big.Int
+	deposit  *big.Int
+	// sizing is how the deposit was chosen
+	sizing string
+}
+
+// previewBid checks a bid of rent per block against the pool at head. A nil
+// deposit is sized as the strategy sizes its own.
+func (op *Operator) previewBid(ctx context.Context, rent, deposit *big.Int) (*bidPreview, error) {
+	anchor, view, err := op.readHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.tracker.HasPending(txmgr.KindSubmitBid, op.poolId) {
+		return nil, errors.New("a bid transaction is already pending")
+	}
+	p := &bidPreview{anchor: anchor, view: view, rent: rent, required: op.requiredBid(view), deposit: deposit}
+	if rent.Cmp(p.required) < 0 {
+		return nil, fmt.Errorf("rent %s is below the required bid %s", op.rent.Format(rent), op.rent.Format(p.required))
+	}
+
+	if deposit == nil {
+		profiles := op.rivalProfiles(ctx, anchor)
+		op.warnRivals(profiles, rent)
+		size, err := sizing.Deposit(op.sizing, rent, op.estimateProfit(), sizing.RivalInterval(profiles))
+		if err != nil {
+			return nil, err
+		}
+		p.deposit, p.sizing = size.Deposit, size.String()
+	} else {
+		min := new(big.Int).Mul(rent, big.NewInt(rivals.MinDepositBlocks))
+		if deposit.Cmp(min) < 0 {
+			return nil, fmt.Errorf("deposit %s is below %d blocks of rent, %s", units.FormatWei(deposit), rivals.MinDepositBlocks, units.FormatWei(min))
+		}
+		p.sizing = fmt.Sprintf("%d blocks as given", new(big.Int).Div(deposit, rent))
+	}
+	return p, nil
+}
+
+// describe lays the preview out for confirmation: what we pay, whose
+// deposit is refunded and what the handover does to the manager
+func (p *bidPreview) describe(op *Operator) []string {
+	view := p.view
+	lines := []string{
+		fmt.Sprintf("Bid %s as %s", op.rent.Format(p.rent), op.address.Hex()),
+		fmt.Sprintf("  Required minimum  %s", op.rent.Format(p.required)),
+		fmt.Sprintf("  Deposit           %s, %s", units.FormatWei(p.deposit), p.sizing),
+	}
+
+	switch view.nextBidder {
+	case common.Address{}:
+	case op.address:
+		lines = append(lines, fmt.Sprintf("  Replaces our queued bid at %s; its deposit %s is refunded to us",
+			op.rent.Format(view.nextRent), units.FormatWei(view.nextDeposit)))
+	default:
+		lines = append(lines, fmt.Sprintf("  Displaces %s's queued bid at %s; its deposit %s is refunded to them",
+			view.nextBidder.Hex(), op.rent.Format(view.nextRent), units.FormatWei(view.nextDeposit)))
+	}
+
+	// Mined in the next block at the earliest, so activation is no sooner
+	activation := p.anchor.Number + 1 + activationDelay
+	if view.manager == (common.Address{}) {
+		return append(lines, fmt.Sprintf("  Activates at block %d or later; the pool has no manager", activation))
+	}
+	rent, refund := ledger.Handover(&store.Tenure{RentPerBlock: view.rentPerBlock, LastRentBlock: view.lastRentBlock}, view.managerDeposit, activation)
+	who := view.manager.Hex()
+	if view.manager == op.address {
+		who = "we"
+	}
+	return append(lines, fmt.Sprintf("  Activates at block %d or later, when the manager (%s) pays a final %s of rent and is refunded %s",
+		activation, who, units.FormatWei(rent), units.FormatWei(refund)))
+}
+
+// sendBid submits a previewed bid, recording the request as the decision
+func (op *Operator) sendBid(ctx context.Context, p *bidPreview) error {
+	op.recordDecision(p.anchor, "manual_bid", "operator request; deposit "+p.sizing, p.rent, 0)
+	return op.submitBid(ctx, p.anchor, p.rent, p.deposit)
+}
+
+// manualBid bids rent per block on an operator's request. The deposit is
+// sized and the transaction tracked exactly as for the strategy's bids.
+func (op *Operator) manualBid(ctx context.Context, rent *big.Int) error {
+	p, err := op.previewBid(ctx, rent, nil)
+	if err != nil {
+		return err
+	}
+	return op.sendBid(ctx, p)
+}
+
+// feePreview is a manual fee update checked against the pool at anchor
+type feePreview struct {
+	anchor reorg.Anchor
+	view   *chainView
+	fee    uint64
+}
+
+// previewFee checks that we may set the swap fee, in pips, at head
+func (op *Operator) previewFee(ctx context.Context, fee uint64) (*feePreview, error) {
+	if fee > maxFee {
+		return nil, fmt.Errorf("fee %s is above the hook's maximum of %s", units.FormatFee(fee), units.FormatFee(maxFee))
+	}
+
+	anchor, view, err := op.readHead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if view.manager != op.address {
+		return nil, errors.New("only the pool's manager can set the fee")
+	}
+	if op.tracker.HasPending(txmgr.KindSetSwapFee, op.poolId) {
+		return nil, errors.New("a fee transaction is already pending")
+	}
+	return &feePreview{anchor: anchor, view: view, fee: fee}, nil
+}
+
+func (p *feePreview) describe() []string {
+	return []string{
+		fmt.Sprintf("Set the swap fee from %s to %s", units.FormatFee(p.view.currentFee.Uint64()), units.FormatFee(p.fee)),
+		"  Every swapper but the manager pays it; the manager keeps swapping at 0%",
+	}
+}
+
+func (op *Operator) sendFee(ctx context.Context, p *feePreview) error {
+	op.recordDecision(p.anchor, "manual_fee", "operator request", nil, uint32(p.fee))
+	return op.setSwapFee(ctx, p.anchor, new(big.Int).SetUint64(p.fee))
+}
+
+// manualFee sets the swap fee, in pips, on an operator's request
+func (op *Operator) manualFee(ctx context.Context, fee uint64) error {
+	p, err := op.previewFee(ctx, fee)
+	if err != nil {
+		return err
+	}
+	return op.sendFee(ctx, p)
+}
+
+// claimRent claims the rent owed to us as an LP of the pool
+func (op *Operator) claimRent(ctx context.Context, anchor reorg.Anchor) error {
+	auth, err := op.transactor(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := op.hook.ClaimRent(auth, op.poolKey)
+	if err != nil {
+		return fmt.Errorf("failed to claim rent: %w", err)
+	}
+	return op.settle(ctx, tx, op.trackTx(tx, anchor, txmgr.KindClaimRent, nil, 0))
+}
+
+// withdrawFees withdraws the withdrawal fees the pool owes us as manager
+func (op *Operator) withdrawFees(ctx context.Context, anchor reorg.Anchor) error {
+	auth, err := op.transactor(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := op.hook.WithdrawManagerFees(auth, op.poolKey)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw manager fees: %w", err)
+	}
+	return op.settle(ctx, tx, op.trackTx(tx, anchor, txmgr.KindWithdrawFees, nil, 0))
+}
+
+// manualFlags are the flags shared by the manual override commands
+type manualFlags struct {
+	pool *string
+	key  *string
+	yes  *bool
+	wait *time.Duration
+}
+
+func newManualFlags(fs *flag.FlagSet) *manualFlags {
+	return &manualFlags{
+		pool: fs.String("pool", "", "pool ID (default: POOL_ID)"),
+		key:  fs.String("key", "", "PoolKey as (currency0,currency1,fee,tickSpacing[,hooks]) (default: -pool's key in POOLS_FILE)"),
+		yes:  fs.Bool("yes", false, "send without asking for confirmation"),
+		wait: fs.Duration("wait", 2*time.Minute, "how long to wait for the transaction to be mined"),
+	}
+}
+
+// operator starts the operator as the daemon would and points it at the
+// pool the flags name. It takes the state file's lock, so it refuses to
+// run beside a daemon on the same state.
+//
+// ours reports whether the pool is POOL_ID, the only pool whose hook
+// events the ledger scans.
+func (f *manualFlags) operator(ctx context.Context) (op *Operator, ours bool, err error) {
+	op = newOperator(ctx)
+	configured := common.Hash(op.poolId)
+
+	// In an HA pair only the leader sends; a running daemon holds the lease
+	if op.lease != nil {
+		if op.leading, err = op.lease.Renew(ctx); err != nil || !op.leading {
+			op.client.Close()
+			if err == nil {
+				err = fmt.Errorf("%w; use the status view of the leading operator", errStandby)
+			}
+			return nil, false, err
+		}
+	}
+
+	key := op.poolKey
+	if *f.pool != "" && *f.key == "" {
+		entries, err := poolkey.Load(getEnvOrDefault("POOLS_FILE", "pools.json"))
+		if err != nil {
+			op.client.Close()
+			return nil, false, err
+		}
+		if e, ok := poolkey.Lookup(entries, common.HexToHash(*f.pool)); ok {
+			key = e.Key()
+		}
+	}
+	if *f.key != "" {
+		if key, err = poolkey.Parse(*f.key, op.hookAddress); err != nil {
+			op.client.Close()
+			return nil, false, err
+		}
+		if key.Hooks != op.hookAddress {
+			op.client.Close()
+			return nil, false, fmt.Errorf("pool key's hooks %s is not HOOK_ADDRESS %s", key.Hooks.Hex(), op.hookAddress.Hex())
+		}
+	}
+	id, err := poolkey.ID(key)
+	if err != nil {
+		op.client.Close()
+		return nil, false, err
+	}
+
+	want := configured
+	if *f.pool != "" {
+		want = common.HexToHash(*f.pool)
+	} else if *f.key != "" {
+		want = id
+	}
+	if id != want {
+		op.client.Close()
+		return nil, false, fmt.Errorf("pool key %s has ID %s, not %s; pass the pool's key with -key", poolkey.String(key), id.Hex(), want.Hex())
+	}
+
+	op.poolId, op.poolKey = id, key
+	op.logger = zap.L().With(logging.Pool(id))
+	if id != configured {
+		op.log().Warn("Pool is not POOL_ID; its proceeds are not booked from hook events")
+		return op, false, nil
+	}
+
+	// Bring the ledger up to date first, so a fresh ledger starts before
+	// our transaction rather than after it
+	return op, true, op.catchUpLedger(ctx)
+}
+
+// confirm shows the preview and asks whether to go ahead, unless -yes
+func (f *manualFlags) confirm(preview []string) (bool, error) {
+	fmt.Println(strings.Join(preview, "\n"))
+	if *f.yes {
+		return true, nil
+	}
+
+	fmt.Print("Send? [y/N] ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// finish waits for our in-flight transactions and books the hook events
+// they caused
+func (f *manualFlags) finish(ctx context.Context, op *Operator, ours bool) error {
+	for len(op.tracker.List()) > 0 {
+		op.checkPendingTxs(ctx)
+		if len(op.tracker.List()) == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			op.log().Info("Still pending; the operator will pick it up from its state file", zap.String("state_file", op.store.Path()))
+			return op.tracker.Save()
+		case <-time.After(op.tickInterval):
+		}
+	}
+	if ours {
+		return op.catchUpLedger(ctx)
+	}
+	return nil
+}
+
+// catchUpLedger books hook events until the ledger reaches the final head
+func (op *Operator) catchUpLedger(ctx context.Context) error {
+	for {
+		before := op.store.LedgerBlock()
+		if err := op.scanLedger(ctx); err != nil {
+			return err
+		}
+		if op.store.LedgerBlock() == before {
+			return nil
+		}
+	}
+}
+
+// runManual is the shape of every manual command: start the operator,
+// preview, confirm, send and wait
+func runManual(fs *flag.FlagSet, args []string, check func() error,
+	preview func(ctx context.Context, op *Operator) ([]string, func(ctx context.Context) error, error)) error {
+	f := newManualFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if check != nil {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	op, ours, err := f.operator(ctx)
+	if err != nil {
+		return err
+	}
+	defer op.client.Close()
+	defer op.store.Close()
+	defer op.releaseLease()
+
+	readCtx, cancel := context.WithTimeout(ctx, op.tickTimeout)
+	lines, send, err := preview(readCtx, op)
+	cancel()
+	if err != nil {
+		return err
+	}
+	if ok, err := f.confirm(lines); err != nil || !ok {
+		if err == nil {
+			fmt.Println("Not sent")
+		}
+		return err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, *f.wait)
+	defer cancel()
+	if err := send(waitCtx); err != nil {
+		return err
+	}
+	return f.finish(waitCtx, op, ours)
+}
+
+// manualBidCommand bids outside the strategy
+func manualBidCommand(args []string) error {
+	fs := flag.NewFlagSet("bid", flag.ContinueOnError)
+	rentFlag := fs.String("rent", "", "rent, e.g. 0.0001eth/block or 0.5eth/day")
+	depositFlag := fs.String("deposit", "", "deposit, e.g. 0.05eth (default: sized like the strategy's bids)")
+
+	var rent, deposit *big.Int
+	check := func() error {
+		if *rentFlag == "" {
+			return errors.New("-rent is required")
+		}
+		if *depositFlag != "" {
+			var err error
+			if deposit, err = units.ParseWei(*depositFlag); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return runManual(fs, args, check, func(ctx context.Context, op *Operator) ([]string, func(context.Context) error, error) {
+		var err error
+		// Per-day rent needs the configured block time
+		if rent, err = op.rent.Parse(*rentFlag); err != nil {
+			return nil, nil, err
+		}
+		p, err := op.previewBid(ctx, rent, deposit)
+		if err != nil {
+			return nil, nil, err
+		}
+		return p.describe(op), func(ctx context.Context) error {
+			// Wait out a treasury top-up so the bid goes out funded
+			if err := op.awaitFunds(ctx, p.deposit); err != nil {
+				return err
+			}
+			return op.sendBid(ctx, p)
+		}, nil
+	})
+}
+
+// manualFeeCommand sets the swap fee outside the strategy
+func manualFeeCommand(args []string) error {
+	fs := flag.NewFlagSet("set-fee", flag.ContinueOnError)
+	feeFlag := fs.String("fee", "", "fee, e.g. 0.3%, 30bps or 3000pips")
+
+	var fee uint64
+	check := func() error {
+		if *feeFlag == "" {
+			return errors.New("-fee is required")
+		}
+		var err error
+		fee, err = units.ParseFee(*feeFlag)
+		return err
+	}
+	return runManual(fs, args, check, func(ctx context.Context, op *Operator) ([]string, func(context.Context) error, error) {
+		p, err := op.previewFee(ctx, fee)
+		if err != nil {
+			return nil, nil, err
+		}
+		lines := append(p.describe(), "  The strategy moves the fee back to its own choice when the operator runs, unless paused from its status view")
+		return lines, func(ctx context.Context) error { return op.sendFee(ctx, p) }, nil
+	})
+}
+
+// claimRentCommand claims our LP share of the rent
+func claimRentCommand(args []string) error {
+	fs := flag.NewFlagSet("claim-rent", flag.ContinueOnError)
+	return runManual(fs, args, nil, func(ctx context.Context, op *Operator) ([]string, func(context.Context) error, error) {
+		anchor, err := op.headAnchor(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get head block: %w", err)
+		}
+		opts := &bind.CallOpts{Context: ctx, BlockHash: anchor.Hash}
+		shares, err := op.criticalHook.LpShares(opts, op.poolId, op.address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get LP shares: %w", err)
+		}
+		owed, err := op.criticalHook.GetPendingRent(opts, op.poolId, op.address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get pending rent: %w", err)
+		}
+		if owed.Sign() == 0 {
+			return nil, nil, errors.New("no rent to claim")
+		}
+		lines := []string{fmt.Sprintf("Claim %s of rent for %s LP shares", units.FormatWei(owed), shares)}
+		return lines, func(ctx context.Context) error { return op.claimRent(ctx, anchor) }, nil
+	})
+}
+
+// withdrawFeesCommand withdraws the withdrawal fees we earned as manager
+func withdrawFeesCommand(args []string) error {
+	fs := flag.NewFlagSet("withdraw-fees", flag.ContinueOnError)
+	return runManual(fs, args, nil, func(ctx context.Context, op *Operator) ([]string, func(context.Context) error, error) {
+		anchor, err := op.headAnchor(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get head block: %w", err)
+		}
+		fees, err := op.criticalHook.ManagerFees(&bind.CallOpts{Context: ctx, BlockHash: anchor.Hash}, op.address, op.poolId)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get manager fees: %w", err)
+		}
+		if fees.Sign() == 0 {
+			return nil, nil, errors.New("no fees to withdraw")
+		}
+		lines := []string{fmt.Sprintf("Withdraw %s of withdrawal fees earned as manager", units.FormatWei(fees))}
+		return lines, func(ctx context.Context) error { return op.withdrawFees(ctx, anchor) }, nil
+	})
+}
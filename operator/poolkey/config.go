@@ -0,0 +1,120 @@
+package poolkey
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DynamicFeeFlag marks a pool whose hook sets the fee, in place of a
+// static fee
+const DynamicFeeFlag = 0x800000
+
+// Entry is one pool of the operator's pool config
+type Entry struct {
+	Id          common.Hash    `json:"id"`
+	Currency0   common.Address `json:"currency0"`
+	Currency1   common.Address `json:"currency1"`
+	Fee         uint32         `json:"fee"`
+	TickSpacing int32          `json:"tickSpacing"`
+	Hooks       common.Address `json:"hooks"`
+}
+
+// EntryOf describes key, with its ID
+func EntryOf(key contracts.PoolKey) (Entry, error) {
+	id, err := ID(key)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		Id:          id,
+		Currency0:   key.Currency0,
+		Currency1:   key.Currency1,
+		Fee:         uint32(key.Fee.Uint64()),
+		TickSpacing: int32(key.TickSpacing.Int64()),
+		Hooks:       key.Hooks,
+	}, nil
+}
+
+// Key is the entry's PoolKey
+func (e Entry) Key() contracts.PoolKey {
+	return contracts.PoolKey{
+		Currency0:   e.Currency0,
+		Currency1:   e.Currency1,
+		Fee:         big.NewInt(int64(e.Fee)),
+		TickSpacing: big.NewInt(int64(e.TickSpacing)),
+		Hooks:       e.Hooks,
+	}
+}
+
+// Load reads a pool config, checking every entry's ID against its key. A
+// missing file is an empty config.
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pool config: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse pool config %s: %w", path, err)
+	}
+	for _, e := range entries {
+		id, err := ID(e.Key())
+		if err != nil {
+			return nil, err
+		}
+		if id != e.Id {
+			return nil, fmt.Errorf("pool config %s: %s has a key hashing to %s", path, e.Id.Hex(), id.Hex())
+		}
+	}
+	return entries, nil
+}
+
+// Lookup finds a pool by ID
+func Lookup(entries []Entry, id common.Hash) (Entry, bool) {
+	for _, e := range entries {
+		if e.Id == id {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Merge adds the entries of add not yet in entries, keeping their order,
+// and reports how many were new
+func Merge(entries, add []Entry) ([]Entry, int) {
+	n := 0
+	for _, e := range add {
+		if _, ok := Lookup(entries, e.Id); !ok {
+			entries = append(entries, e)
+			n++
+		}
+	}
+	return entries, n
+}
+
+// Save writes a pool config atomically
+func Save(path string, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write pool config: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,113 @@
+package poolkey
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// initializeABI is PoolManager's Initialize event. Only the ID and the
+// currencies are indexed, so pools can't be filtered by hooks on the node.
+const initializeABI = `[{"type":"event","name":"Initialize","anonymous":false,"inputs":[
+	{"name":"id","type":"bytes32","indexed":true},
+	{"name":"currency0","type":"address","indexed":true},
+	{"name":"currency1","type":"address","indexed":true},
+	{"name":"fee","type":"uint24","indexed":false},
+	{"name":"tickSpacing","type":"int24","indexed":false},
+	{"name":"hooks","type":"address","indexed":false},
+	{"name":"sqrtPriceX96","type":"uint160","indexed":false},
+	{"name":"tick","type":"int24","indexed":false}]}]`
+
+var initialize = func() abi.Event {
+	parsed, err := abi.JSON(strings.NewReader(initializeABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Events["Initialize"]
+}()
+
+// Pool is a pool found by its Initialize event
+type Pool struct {
+	Entry
+	Block        uint64   `json:"block"`
+	SqrtPriceX96 *big.Int `json:"sqrtPriceX96"`
+	Tick         int64    `json:"tick"`
+}
+
+// Decode reads an Initialize log, checking that its ID matches its key
+func Decode(l types.Log) (Pool, error) {
+	if len(l.Topics) != 4 || l.Topics[0] != initialize.ID {
+		return Pool{}, fmt.Errorf("log %s:%d is not an Initialize event", l.TxHash.Hex(), l.Index)
+	}
+	fields, err := initialize.Inputs.NonIndexed().Unpack(l.Data)
+	if err != nil {
+		return Pool{}, fmt.Errorf("failed to decode Initialize event: %w", err)
+	}
+
+	p := Pool{
+		Entry: Entry{
+			Id:          l.Topics[1],
+			Currency0:   common.BytesToAddress(l.Topics[2].Bytes()),
+			Currency1:   common.BytesToAddress(l.Topics[3].Bytes()),
+			Fee:         uint32(fields[0].(*big.Int).Uint64()),
+			TickSpacing: int32(fields[1].(*big.Int).Int64()),
+			Hooks:       fields[2].(common.Address),
+		},
+		Block:        l.BlockNumber,
+		SqrtPriceX96: fields[3].(*big.Int),
+		Tick:         fields[4].(*big.Int).Int64(),
+	}
+
+	id, err := ID(p.Key())
+	if err != nil {
+		return Pool{}, err
+	}
+	if id != p.Id {
+		return Pool{}, fmt.Errorf("Initialize event for %s has a key hashing to %s", p.Id.Hex(), id.Hex())
+	}
+	return p, nil
+}
+
+// Discover lists the pools initialized on poolManager in [from, to] with
+// hooks as their hook, reading logs step blocks at a time
+func Discover(ctx context.Context, logs ethereum.LogFilterer, poolManager, hooks common.Address, from, to, step uint64) ([]Pool, error) {
+	if step == 0 {
+		return nil, fmt.Errorf("block step must be positive")
+	}
+
+	var pools []Pool
+	for start := from; start <= to; start += step {
+		end := start + step - 1
+		if end > to {
+			end = to
+		}
+		found, err := logs.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{poolManager},
+			Topics:    [][]common.Hash{{initialize.ID}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter Initialize events in [%d, %d]: %w", start, end, err)
+		}
+		for _, l := range found {
+			p, err := Decode(l)
+			if err != nil {
+				return nil, err
+			}
+			if p.Hooks == hooks {
+				pools = append(pools, p)
+			}
+		}
+		if end == to {
+			break
+		}
+	}
+	return pools, nil
+}
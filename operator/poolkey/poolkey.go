@@ -0,0 +1,91 @@
+// Package poolkey parses Uniswap v4 PoolKeys and derives their PoolIds.
+// A PoolId is keccak256 of the ABI-encoded key, each of its five fields
+// padded to 32 bytes, as PoolIdLibrary.toId computes it on chain.
+package poolkey
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// keyArgs is the PoolKey tuple's field types, in order
+var keyArgs = func() abi.Arguments {
+	var args abi.Arguments
+	for _, name := range []string{"address", "address", "uint24", "int24", "address"} {
+		t, err := abi.NewType(name, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, abi.Argument{Type: t})
+	}
+	return args
+}()
+
+// ID is the PoolId of key
+func ID(key contracts.PoolKey) (common.Hash, error) {
+	if key.Fee == nil || key.TickSpacing == nil {
+		return common.Hash{}, fmt.Errorf("pool key has no fee or tick spacing")
+	}
+	packed, err := keyArgs.Pack(key.Currency0, key.Currency1, key.Fee, key.TickSpacing, key.Hooks)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode pool key: %w", err)
+	}
+	return crypto.Keccak256Hash(packed), nil
+}
+
+// Parse reads a key written as cast writes the tuple,
+// "(currency0,currency1,fee,tickSpacing,hooks)". The parentheses are
+// optional, and so are the hooks, which default to hooks.
+func Parse(s string, hooks common.Address) (contracts.PoolKey, error) {
+	fields := strings.Split(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "("), ")"), ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	if len(fields) == 4 {
+		fields = append(fields, hooks.Hex())
+	}
+	if len(fields) != 5 {
+		return contracts.PoolKey{}, fmt.Errorf("pool key %q: want currency0,currency1,fee,tickSpacing[,hooks]", s)
+	}
+
+	var addrs [3]common.Address
+	for i, f := range []string{fields[0], fields[1], fields[4]} {
+		if !common.IsHexAddress(f) {
+			return contracts.PoolKey{}, fmt.Errorf("pool key %q: invalid address %q", s, f)
+		}
+		addrs[i] = common.HexToAddress(f)
+	}
+	if new(big.Int).SetBytes(addrs[0][:]).Cmp(new(big.Int).SetBytes(addrs[1][:])) >= 0 {
+		return contracts.PoolKey{}, fmt.Errorf("pool key %q: currency0 must sort below currency1", s)
+	}
+
+	fee, err := strconv.ParseUint(fields[2], 0, 24)
+	if err != nil {
+		return contracts.PoolKey{}, fmt.Errorf("pool key %q: invalid fee: %w", s, err)
+	}
+	spacing, err := strconv.ParseInt(fields[3], 0, 24)
+	if err != nil || spacing <= 0 {
+		return contracts.PoolKey{}, fmt.Errorf("pool key %q: invalid tick spacing %q", s, fields[3])
+	}
+
+	return contracts.PoolKey{
+		Currency0:   addrs[0],
+		Currency1:   addrs[1],
+		Fee:         new(big.Int).SetUint64(fee),
+		TickSpacing: big.NewInt(spacing),
+		Hooks:       addrs[2],
+	}, nil
+}
+
+// String writes key in the form Parse reads
+func String(key contracts.PoolKey) string {
+	return fmt.Sprintf("(%s,%s,%s,%s,%s)", key.Currency0.Hex(), key.Currency1.Hex(), key.Fee, key.TickSpacing, key.Hooks.Hex())
+}
@@ -0,0 +1,169 @@
+package poolkey
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	token0 = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	token1 = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	hook   = common.HexToAddress("0x00000000000000000000000000000000000040c0")
+)
+
+func TestParse(t *testing.T) {
+	key, err := Parse("("+token0.Hex()+", "+token1.Hex()+", 3000, 60)", hook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Currency0 != token0 || key.Currency1 != token1 || key.Fee.Uint64() != 3000 || key.TickSpacing.Int64() != 60 || key.Hooks != hook {
+		t.Errorf("Parse() = %+v", key)
+	}
+
+	again, err := Parse(String(key), common.Address{})
+	if err != nil || again.Hooks != hook {
+		t.Errorf("Parse(String()) = %+v, %v", again, err)
+	}
+
+	for _, bad := range []string{
+		token0.Hex() + "," + token1.Hex() + ",3000",
+		token1.Hex() + "," + token0.Hex() + ",3000,60",
+		token0.Hex() + "," + token1.Hex() + ",16777216,60",
+		token0.Hex() + "," + token1.Hex() + ",3000,0",
+		"0x12," + token1.Hex() + ",3000,60",
+	} {
+		if _, err := Parse(bad, hook); err == nil {
+			t.Errorf("Parse(%q) accepted an invalid key", bad)
+		}
+	}
+}
+
+func TestID(t *testing.T) {
+	// The dynamic fee flag exercises the top bit of the uint24
+	key, err := Parse(token0.Hex()+","+token1.Hex()+",0x800000,60", hook)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	word := func(v *big.Int) []byte { return common.LeftPadBytes(v.Bytes(), 32) }
+	var encoded []byte
+	encoded = append(encoded, common.LeftPadBytes(token0.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(token1.Bytes(), 32)...)
+	encoded = append(encoded, word(big.NewInt(0x800000))...)
+	encoded = append(encoded, word(big.NewInt(60))...)
+	encoded = append(encoded, common.LeftPadBytes(hook.Bytes(), 32)...)
+
+	id, err := ID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.Keccak256Hash(encoded); id != want {
+		t.Errorf("ID() = %s, want %s", id.Hex(), want.Hex())
+	}
+}
+
+// initLog is the Initialize log of a pool with key
+func initLog(t *testing.T, key contracts.PoolKey, block uint64) types.Log {
+	t.Helper()
+	id, err := ID(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := initialize.Inputs.NonIndexed().Pack(key.Fee, key.TickSpacing, key.Hooks, new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(-60))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		BlockNumber: block,
+		Topics:      []common.Hash{initialize.ID, id, common.BytesToHash(key.Currency0.Bytes()), common.BytesToHash(key.Currency1.Bytes())},
+		Data:        data,
+	}
+}
+
+// fakeLogs serves a fixed set of logs by block range
+type fakeLogs []types.Log
+
+func (f fakeLogs) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var out []types.Log
+	for _, l := range f {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (fakeLogs) SubscribeFilterLogs(context.Context, ethereum.FilterQuery, chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestDiscover(t *testing.T) {
+	ours, _ := Parse(token0.Hex()+","+token1.Hex()+",0x800000,60", hook)
+	other, _ := Parse(token0.Hex()+","+token1.Hex()+",3000,60,0x0000000000000000000000000000000000000000", hook)
+	logs := fakeLogs{initLog(t, other, 10), initLog(t, ours, 25)}
+
+	pools, err := Discover(context.Background(), logs, common.Address{}, hook, 0, 30, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 1 {
+		t.Fatalf("Discover() found %d pools, want 1", len(pools))
+	}
+	p := pools[0]
+	if p.Block != 25 || p.Fee != DynamicFeeFlag || p.TickSpacing != 60 || p.Tick != -60 || p.Hooks != hook {
+		t.Errorf("Discover() = %+v", p)
+	}
+
+	// A log whose ID doesn't hash from its key is rejected
+	forged := initLog(t, ours, 5)
+	forged.Topics[1] = common.Hash{1}
+	if _, err := Decode(forged); err == nil {
+		t.Error("Decode() accepted an ID that does not match the key")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pools.json")
+	if entries, err := Load(path); err != nil || entries != nil {
+		t.Fatalf("Load() of a missing file = %v, %v", entries, err)
+	}
+
+	key, _ := Parse(token0.Hex()+","+token1.Hex()+",3000,60", hook)
+	entry, err := EntryOf(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, added := Merge(nil, []Entry{entry, entry})
+	if added != 1 {
+		t.Errorf("Merge() added %d, want 1", added)
+	}
+	if err := Save(path, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := Lookup(loaded, entry.Id); !ok || got != entry {
+		t.Errorf("Lookup() = %+v, %v, want %+v", got, ok, entry)
+	}
+
+	loaded[0].TickSpacing = 10
+	if err := Save(path, loaded); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() accepted an entry whose ID does not match its key")
+	}
+}
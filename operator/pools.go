@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"auction-pool/operator/contracts"
+	"auction-pool/operator/poolkey"
+	"auction-pool/operator/rpcclient"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// resolvePool finds the pool the operator runs on. POOL_KEY wins, then the
+// POOLS_FILE entry for POOL_ID, then the file's only pool; a POOL_ID with
+// neither falls back to TOKEN0 and TOKEN1 at the 0.3% fee tier.
+func resolvePool(hookAddr common.Address) (common.Hash, contracts.PoolKey, error) {
+	var wantId common.Hash
+	poolIdHex := os.Getenv("POOL_ID")
+	if poolIdHex != "" {
+		wantId = common.HexToHash(poolIdHex)
+	}
+
+	if s := os.Getenv("POOL_KEY"); s != "" {
+		key, err := poolkey.Parse(s, hookAddr)
+		if err != nil {
+			return common.Hash{}, key, fmt.Errorf("invalid POOL_KEY: %w", err)
+		}
+		if key.Hooks != hookAddr {
+			return common.Hash{}, key, fmt.Errorf("POOL_KEY's hooks %s is not HOOK_ADDRESS %s", key.Hooks.Hex(), hookAddr.Hex())
+		}
+		id, err := poolkey.ID(key)
+		if err != nil {
+			return common.Hash{}, key, err
+		}
+		if poolIdHex != "" && id != wantId {
+			return common.Hash{}, key, fmt.Errorf("POOL_KEY has ID %s, not POOL_ID %s", id.Hex(), wantId.Hex())
+		}
+		return id, key, nil
+	}
+
+	path := getEnvOrDefault("POOLS_FILE", "pools.json")
+	entries, err := poolkey.Load(path)
+	if err != nil {
+		return common.Hash{}, contracts.PoolKey{}, err
+	}
+	var ours []poolkey.Entry
+	for _, e := range entries {
+		if e.Hooks == hookAddr {
+			ours = append(ours, e)
+		}
+	}
+
+	if poolIdHex != "" {
+		if e, ok := poolkey.Lookup(ours, wantId); ok {
+			return e.Id, e.Key(), nil
+		}
+		return wantId, contracts.PoolKey{
+			Currency0:   common.HexToAddress(getEnvOrDefault("TOKEN0", "0x0000000000000000000000000000000000000000")),
+			Currency1:   common.HexToAddress(getEnvOrDefault("TOKEN1", "0x0000000000000000000000000000000000000000")),
+			Fee:         big.NewInt(3000),
+			TickSpacing: big.NewInt(60),
+			Hooks:       hookAddr,
+		}, nil
+	}
+	if len(ours) == 1 {
+		return ours[0].Id, ours[0].Key(), nil
+	}
+	if len(ours) > 1 {
+		return common.Hash{}, contracts.PoolKey{}, fmt.Errorf("%s lists %d pools on this hook; pick one with POOL_ID", path, len(ours))
+	}
+	return common.Hash{}, contracts.PoolKey{}, fmt.Errorf("POOL_ID or POOL_KEY environment variable required (or run discover -write to fill %s)", path)
+}
+
+// printPoolId prints the PoolId of a PoolKey
+func printPoolId(args []string) error {
+	fs := flag.NewFlagSet("pool-id", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: pool-id [-hooks address] '(currency0,currency1,fee,tickSpacing[,hooks])'")
+		fs.PrintDefaults()
+	}
+	hooks := fs.String("hooks", os.Getenv("HOOK_ADDRESS"), "hook address when the key leaves it out")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("pool-id takes one PoolKey")
+	}
+
+	key, err := poolkey.Parse(fs.Arg(0), common.HexToAddress(*hooks))
+	if err != nil {
+		return err
+	}
+	id, err := poolkey.ID(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(id.Hex())
+	return nil
+}
+
+// discover lists the pools PoolManager initialized with HOOK_ADDRESS as
+// their hook, and with -write adds them to POOLS_FILE
+func discover(args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ContinueOnError)
+	manager := fs.String("pool-manager", os.Getenv("POOL_MANAGER_ADDRESS"), "PoolManager address")
+	from := fs.Uint64("from", 0, "first block to scan")
+	to := fs.Uint64("to", 0, "last block to scan (default: head)")
+	step := fs.Uint64("step", 10000, "blocks per log query")
+	write := fs.Bool("write", false, "add the pools found to POOLS_FILE")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	hookAddress := os.Getenv("HOOK_ADDRESS")
+	if hookAddress == "" || *manager == "" {
+		return fmt.Errorf("HOOK_ADDRESS and -pool-manager (or POOL_MANAGER_ADDRESS) required")
+	}
+	hookAddr := common.HexToAddress(hookAddress)
+
+	ctx := context.Background()
+	client, err := rpcclient.Dial(ctx, rpcclient.Config{
+		URLs: strings.Split(getEnvOrDefault("RPC_URLS", getEnvOrDefault("RPC_URL", "http://localhost:8545")), ","),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to Ethereum client: %w", err)
+	}
+	defer client.Close()
+
+	if *to == 0 {
+		head, err := client.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read head: %w", err)
+		}
+		*to = head
+	}
+
+	pools, err := poolkey.Discover(ctx, client, common.HexToAddress(*manager), hookAddr, *from, *to, *step)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "pool\tcurrency0\tcurrency1\tfee\ttick_spacing\tblock")
+	for _, p := range pools {
+		fee := strconv.FormatUint(uint64(p.Fee), 10)
+		if p.Fee&poolkey.DynamicFeeFlag != 0 {
+			fee = "dynamic"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", p.Id.Hex(), p.Currency0.Hex(), p.Currency1.Hex(), fee, p.TickSpacing, p.Block)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !*write {
+		return nil
+	}
+
+	path := getEnvOrDefault("POOLS_FILE", "pools.json")
+	entries, err := poolkey.Load(path)
+	if err != nil {
+		return err
+	}
+	found := make([]poolkey.Entry, len(pools))
+	for i, p := range pools {
+		found[i] = p.Entry
+	}
+	entries, added := poolkey.Merge(entries, found)
+	if err := poolkey.Save(path, entries); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Added %d of %d pools to %s\n", added, len(pools), path)
+	return nil
+}
@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/store"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// activationDelay mirrors ACTIVATION_DELAY in AuctionPoolHook
+const activationDelay = 5
+
+// chainView is the part of the hook's state that reconciliation compares
+// against our persisted records, as of one specific block
+type chainView struct {
+	anchor reorg.Anchor
+
+	manager         common.Address
+	rentPerBlock    *big.Int
+	managerDeposit  *big.Int
+	lastRentBlock   uint64
+	currentFee      *big.Int
+	nextBidder      common.Address
+	nextRent        *big.Int
+	nextDeposit     *big.Int
+	activationBlock *big.Int
+}
+
+// headAnchor returns the block hash this iteration's reads are pinned to
+func (op *Operator) headAnchor(ctx context.Context) (reorg.Anchor, error) {
+	number, err := op.client.QuorumBlockNumber(ctx)
+	if err != nil {
+		return reorg.Anchor{}, err
+	}
+
+	header, err := op.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return reorg.Anchor{}, err
+	}
+
+	return reorg.AnchorOf(header), nil
+}
+
+// readChainView loads PoolAuctions and NextBid for our pool, both pinned to
+// the anchor's block hash so they describe one state on one fork
+func (op *Operator) readChainView(ctx context.Context, anchor reorg.Anchor) (*chainView, error) {
+	return op.readPoolView(ctx, anchor, op.poolId)
+}
+
+// readPoolView is readChainView for any pool of the hook
+func (op *Operator) readPoolView(ctx context.Context, anchor reorg.Anchor, poolId [32]byte) (*chainView, error) {
+	opts := &bind.CallOpts{Context: ctx, BlockHash: anchor.Hash}
+
+	state, err := op.criticalHook.PoolAuctions(opts, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool state: %w", err)
+	}
+
+	next, err := op.criticalHook.NextBid(opts, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next bid: %w", err)
+	}
+
+	return &chainView{
+		anchor:          anchor,
+		manager:         state.CurrentManager,
+		rentPerBlock:    state.RentPerBlock,
+		managerDeposit:  state.ManagerDeposit,
+		lastRentBlock:   state.LastRentBlock.Uint64(),
+		currentFee:      state.CurrentFee,
+		nextBidder:      next.Bidder,
+		nextRent:        next.RentPerBlock,
+		nextDeposit:     next.Deposit,
+		activationBlock: next.ActivationBlock,
+	}, nil
+}
+
+// reconcile brings persisted state in line with the chain at startup, so a
+// restart never repeats a bid or fee update that already happened
+func (op *Operator) reconcile(ctx context.Context) error {
+	op.checkPendingTxs(ctx)
+
+	anchor, err := op.headAnchor(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get head block: %w", err)
+	}
+
+	view, err := op.readChainView(ctx, anchor)
+	if err != nil {
+		return err
+	}
+	op.syncBid(view)
+
+	if fee := op.store.Fee(common.Hash(op.poolId)); fee != nil && view.manager == op.address {
+		if view.currentFee.Cmp(big.NewInt(int64(fee.Fee))) != 0 {
+			op.log().Warn("Fee on chain differs from the last fee we set",
+				zap.Uint64("fee", view.currentFee.Uint64()),
+				zap.String("fee_fmt", units.FormatFee(view.currentFee.Uint64())),
+				zap.Uint32("our_fee", fee.Fee),
+				zap.String("our_fee_fmt", units.FormatFee(uint64(fee.Fee))),
+				logging.Tx(fee.TxHash))
+		}
+	}
+
+	if bid := op.store.ActiveBid(common.Hash(op.poolId)); bid != nil {
+		op.log().Info("Reconciled bid",
+			logging.Tx(bid.TxHash),
+			zap.Stringer("rent_per_block", bid.RentPerBlock),
+			zap.String("rent_per_block_fmt", op.rent.Format(bid.RentPerBlock)),
+			zap.Stringer("deposit", bid.Deposit),
+			zap.String("deposit_fmt", units.FormatWei(bid.Deposit)),
+			zap.String("status", bid.Status))
+	}
+
+	return nil
+}
+
+// syncBid moves our bid record through its lifecycle based on chain state.
+// The record follows our latest bid: when we queue a bid behind our own
+// tenure, the record is the queued bid's and the term we are serving lives
+// on in its tenure record.
+func (op *Operator) syncBid(view *chainView) {
+	poolId := common.Hash(op.poolId)
+	bid := op.store.ActiveBid(poolId)
+
+	switch {
+	case view.nextBidder == op.address && (bid == nil || bid.Status != store.BidSent):
+		// A bid of ours still being sent is not the one queued yet
+		if bid == nil {
+			bid = op.adoptBid(view.nextRent, view.nextDeposit)
+			bid.ActivationBlock = view.activationBlock.Uint64()
+		}
+		if bid.Status != store.BidQueued {
+			bid.Status = store.BidQueued
+			op.putBid(bid)
+		}
+
+	case view.manager == op.address && activated(bid, view):
+		if bid == nil {
+			bid = op.adoptBid(view.rentPerBlock, new(big.Int))
+		}
+		if bid.Status != store.BidActive {
+			bid.Status = store.BidActive
+			op.putBid(bid)
+		}
+
+	case bid != nil && bid.Locked() && !op.tracker.HasPending(txmgr.KindSubmitBid, poolId):
+		if bid.Status == store.BidActive {
+			// Our tenure is over; the leftover deposit was refunded on handover
+			op.log().Info("Our tenure ended", logging.Address("manager", view.manager))
+			bid.Status = store.BidEnded
+		} else {
+			// Displaced from nextBid: _refundBid returns the full deposit
+			op.log().Info("Our queued bid was outbid, deposit refunded", logging.Tx(bid.TxHash),
+				zap.Stringer("deposit", bid.Deposit), zap.String("deposit_fmt", units.FormatWei(bid.Deposit)))
+			bid.Status = store.BidOutbid
+			op.recordAccounting(store.EntryDepositRefunded, bid.Deposit, common.Hash{}, 0, bid.TxHash)
+		}
+		op.putBid(bid)
+	}
+}
+
+// activated reports whether our bid record is the bid that made us
+// manager, rather than a later one of ours queued behind our own tenure.
+// A queued bid took over if its activation block has passed at its rent.
+func activated(bid *store.ActiveBid, view *chainView) bool {
+	switch {
+	case bid == nil || bid.Status == store.BidActive:
+		return true
+	case bid.Status == store.BidQueued:
+		return bid.ActivationBlock <= view.anchor.Number && bid.RentPerBlock.Cmp(view.rentPerBlock) == 0
+	}
+	return false
+}
+
+// adoptBid returns a record for a bid we find on chain without a local
+// record (e.g. state file lost). Its transaction is unknown.
+func (op *Operator) adoptBid(rent, deposit *big.Int) *store.ActiveBid {
+	return &store.ActiveBid{
+		PoolId:       common.Hash(op.poolId),
+		RentPerBlock: rent,
+		Deposit:      deposit,
+	}
+}
+
+// checkPendingTxs resolves in-flight transactions whose receipts are
+// available and, when a confirmation depth is set, buried deep enough
+func (op *Operator) checkPendingTxs(ctx context.Context) {
+	var head uint64
+	if op.confirmations > 0 {
+		var err error
+		if head, err = op.client.BlockNumber(ctx); err != nil {
+			op.log().Error("Failed to get block number", zap.Error(err))
+			return
+		}
+	}
+
+	for _, ptx := range op.tracker.List() {
+		receipt, err := op.client.TransactionReceipt(ctx, ptx.Hash)
+		if errors.Is(err, ethereum.NotFound) {
+			// Not mined yet; drop it only if its nonce was consumed by another tx
+			from := op.address
+			if ptx.From != (common.Address{}) {
+				from = ptx.From
+			}
+			nonce, err := op.client.NonceAt(ctx, from, nil)
+			if err != nil {
+				op.log().Error("Failed to get nonce", logging.Address("account", from), zap.Error(err))
+				return
+			}
+			if nonce > ptx.Nonce {
+				op.txLog(ptx).Warn("Transaction replaced or dropped", zap.Uint64("nonce", ptx.Nonce))
+				op.alert("tx_dropped:"+ptx.Hash.Hex(), alert.Warning, fmt.Sprintf("%s transaction dropped", ptx.Kind),
+					fmt.Sprintf("%s (nonce %d) was replaced or dropped", ptx.Hash.Hex(), ptx.Nonce))
+				op.onDropped(ptx)
+				op.resolvePending(ptx)
+			}
+			continue
+		}
+		if err != nil {
+			op.txLog(ptx).Error("Failed to get receipt", zap.Error(err))
+			return
+		}
+
+		if op.confirmations > 0 && head < receipt.BlockNumber.Uint64()+op.confirmations {
+			// Not final yet; if the block is reorged out the receipt disappears
+			// and the tx simply stays pending
+			continue
+		}
+
+		op.onReceipt(ptx, receipt)
+		op.resolvePending(ptx)
+	}
+}
+
+// onReceipt applies the outcome of a mined transaction to persisted state
+func (op *Operator) onReceipt(ptx *txmgr.PendingTx, receipt *types.Receipt) {
+	block := receipt.BlockNumber.Uint64()
+	op.recordGas(ptx, receipt)
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		op.txLog(ptx).Error("Transaction failed", logging.Block(block), zap.Uint64("status", receipt.Status))
+		op.alert("tx_failed:"+ptx.Hash.Hex(), alert.Critical, fmt.Sprintf("%s transaction failed", ptx.Kind),
+			fmt.Sprintf("%s reverted in block %d", ptx.Hash.Hex(), block))
+		op.onDropped(ptx)
+		return
+	}
+
+	op.txLog(ptx).Info("Transaction mined", logging.Block(block), zap.Uint64("gas_used", receipt.GasUsed))
+
+	switch ptx.Kind {
+	case txmgr.KindSubmitBid:
+		if w := op.window; w != nil {
+			if w.Included(block) {
+				op.txLog(ptx).Info("Counter-bid mined before the rival's activation",
+					logging.Address("rival", w.Rival), zap.Uint64("activation_block", w.ActivationBlock))
+			} else {
+				op.txLog(ptx).Error("Counter-bid mined after the rival's activation",
+					logging.Block(block), logging.Address("rival", w.Rival), zap.Uint64("activation_block", w.ActivationBlock))
+				op.alert(fmt.Sprintf("late_counter_bid:%d", w.ActivationBlock), alert.Critical, "Counter-bid mined too late",
+					fmt.Sprintf("%s mined in block %d, after %s's activation at block %d", ptx.Hash.Hex(), block, w.Rival.Hex(), w.ActivationBlock))
+			}
+		}
+		op.putBid(&store.ActiveBid{
+			PoolId:          ptx.PoolId,
+			TxHash:          ptx.Hash,
+			RentPerBlock:    ptx.Rent,
+			Deposit:         ptx.Value,
+			ActivationBlock: block + activationDelay,
+			Status:          store.BidQueued,
+		})
+		op.recordAccounting(store.EntryDepositLocked, ptx.Value, ptx.Hash, block, ptx.Hash)
+		if ptx.ReplacedDeposit != nil {
+			op.recordAccounting(store.EntryDepositRefunded, ptx.ReplacedDeposit, ptx.Hash, block, ptx.Replaces)
+		}
+
+	case txmgr.KindTreasuryTopUp:
+		op.recordAccounting(store.EntryTreasuryTopUp, ptx.Value, ptx.Hash, block, common.Hash{})
+
+	case txmgr.KindTreasurySweep:
+		op.recordAccounting(store.EntryTreasurySweep, ptx.Value, ptx.Hash, block, common.Hash{})
+		op.onSweep()
+
+	case txmgr.KindSetSwapFee:
+		if err := op.store.PutFee(&store.FeeSetting{
+			PoolId: ptx.PoolId,
+			Fee:    ptx.Fee,
+			TxHash: ptx.Hash,
+			SetAt:  time.Now(),
+		}); err != nil {
+			op.txLog(ptx).Error("Failed to persist fee", zap.Error(err))
+		}
+	}
+}
+
+// onDropped marks a bid whose transaction never took effect
+func (op *Operator) onDropped(ptx *txmgr.PendingTx) {
+	if ptx.Kind != txmgr.KindSubmitBid {
+		return
+	}
+	if bid := op.store.ActiveBid(ptx.PoolId); bid != nil && bid.TxHash == ptx.Hash {
+		bid.Status = store.BidReverted
+		op.putBid(bid)
+	}
+}
+
+func (op *Operator) resolvePending(ptx *txmgr.PendingTx) {
+	if err := op.tracker.Resolve(ptx.Hash); err != nil {
+		op.txLog(ptx).Error("Failed to update pending transactions", zap.Error(err))
+	}
+}
+
+func (op *Operator) putBid(bid *store.ActiveBid) {
+	if err := op.store.PutActiveBid(bid); err != nil {
+		op.log().Error("Failed to persist bid", logging.Tx(bid.TxHash), zap.Error(err))
+	}
+}
+
+// recordGas books the gas a mined transaction paid for, whether or not it
+// succeeded, against the bid it was sent for
+func (op *Operator) recordGas(ptx *txmgr.PendingTx, receipt *types.Receipt) {
+	if receipt.EffectiveGasPrice == nil {
+		return
+	}
+	gas := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+
+	kind, tenure := store.EntryGas, ptx.Hash
+	switch ptx.Kind {
+	case txmgr.KindSubmitBid:
+	case txmgr.KindTreasuryTopUp:
+		// The treasury pays for its own transfers
+		kind, tenure = store.EntryTreasuryGas, common.Hash{}
+	case txmgr.KindTreasurySweep:
+		tenure = common.Hash{}
+	default:
+		tenure = op.currentTenure()
+	}
+	op.recordAccounting(kind, gas, ptx.Hash, receipt.BlockNumber.Uint64(), tenure)
+}
+
+// recordAccounting books an entry for one of our own transactions
+func (op *Operator) recordAccounting(kind string, amount *big.Int, txHash common.Hash, block uint64, tenure common.Hash) {
+	op.appendAccounting(&store.AccountingEntry{
+		Time:   time.Now(),
+		PoolId: common.Hash(op.poolId),
+		Kind:   kind,
+		Amount: amount,
+		TxHash: txHash,
+		Block:  block,
+		Tenure: tenure,
+	})
+}
+
+// recordEvent books an entry for a hook event. The entry is keyed on the
+// event's log, so booking the same blocks again, as after a crash halfway
+// through a scan, adds nothing.
+func (op *Operator) recordEvent(kind string, amount *big.Int, ev types.Log, tenure common.Hash) {
+	index := ev.Index
+	op.appendAccounting(&store.AccountingEntry{
+		Time:     time.Now(),
+		PoolId:   common.Hash(op.poolId),
+		Kind:     kind,
+		Amount:   amount,
+		TxHash:   ev.TxHash,
+		Block:    ev.BlockNumber,
+		LogIndex: &index,
+		Tenure:   tenure,
+	})
+}
+
+func (op *Operator) appendAccounting(entry *store.AccountingEntry) {
+	err := op.store.AppendAccounting(entry)
+	if errors.Is(err, store.ErrBooked) {
+		return
+	}
+	if err != nil {
+		op.log().Error("Failed to persist accounting entry", zap.String("kind", entry.Kind), logging.Tx(entry.TxHash), zap.Error(err))
+	}
+
+	// Refunds and fee proceeds land in the hot wallet; send them on
+	if op.wallet != nil && (entry.Kind == store.EntryDepositRefunded || entry.Kind == store.EntryFeesWithdrawn) {
+		op.wallet.proceedsLanded()
+	}
+}
+
+// recordDecision persists and logs a decision, under a new decision ID that
+// the transactions carrying it out are tagged with
+func (op *Operator) recordDecision(anchor reorg.Anchor, action, reason string, rent *big.Int, fee uint32) {
+	op.trace.Decision = logging.NewID()
+	fields := []zap.Field{logging.Block(anchor.Number), zap.String("action", action), zap.String("reason", reason)}
+	if rent != nil {
+		fields = append(fields, zap.Stringer("rent_per_block", rent))
+	}
+	if fee != 0 {
+		fields = append(fields, zap.Uint32("fee", fee))
+	}
+	op.log().Info("Decision", fields...)
+
+	if err := op.store.AppendDecision(&store.Decision{
+		Id:          op.trace.Decision,
+		Correlation: op.trace.Correlation,
+		Time:        time.Now(),
+		PoolId:      common.Hash(op.poolId),
+		Block:       anchor.Number,
+		Hash:        anchor.Hash,
+		Action:      action,
+		Reason:      reason,
+		Rent:        rent,
+		Fee:         fee,
+	}); err != nil {
+		op.log().Error("Failed to persist decision", zap.Error(err))
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/store"
+	"auction-pool/operator/txmgr"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRecordGas(t *testing.T) {
+	sent := common.HexToHash("0x5e7")
+	tests := []struct {
+		name   string
+		kind   string
+		tenure bool
+		want   entry
+	}{
+		{name: "bid", kind: txmgr.KindSubmitBid, want: entry{store.EntryGas, 21000, sent}},
+		{name: "fee while manager", kind: txmgr.KindSetSwapFee, tenure: true, want: entry{store.EntryGas, 21000, bidTx}},
+		{name: "fee withdrawal after the tenure", kind: txmgr.KindWithdrawFees, want: entry{store.EntryGas, 21000, common.Hash{}}},
+		{name: "top-up", kind: txmgr.KindTreasuryTopUp, tenure: true, want: entry{store.EntryTreasuryGas, 21000, common.Hash{}}},
+		{name: "sweep", kind: txmgr.KindTreasurySweep, tenure: true, want: entry{store.EntryGas, 21000, common.Hash{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := testOperator(t)
+			if tt.tenure {
+				if err := op.store.PutTenure(&store.Tenure{Id: bidTx, PoolId: pool, RentPerBlock: big.NewInt(10), StartBlock: 100}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ptx := &txmgr.PendingTx{Hash: sent, Kind: tt.kind, PoolId: pool}
+			op.recordGas(ptx, &types.Receipt{GasUsed: 21000, EffectiveGasPrice: big.NewInt(1), BlockNumber: big.NewInt(120)})
+
+			entries := op.store.Accounting()
+			if len(entries) != 1 {
+				t.Fatalf("booked %d entries, want 1", len(entries))
+			}
+			e := entries[0]
+			if got := (entry{e.Kind, e.Amount.Int64(), e.Tenure}); got != tt.want || e.TxHash != sent || e.Block != 120 {
+				t.Errorf("booked %+v in tx %s at %d, want %+v in %s at 120", got, e.TxHash.Hex(), e.Block, tt.want, sent.Hex())
+			}
+		})
+	}
+
+	// Receipts from before London carry no effective gas price
+	op := testOperator(t)
+	op.recordGas(&txmgr.PendingTx{Hash: sent, Kind: txmgr.KindSubmitBid}, &types.Receipt{GasUsed: 21000, BlockNumber: big.NewInt(120)})
+	if len(op.store.Accounting()) != 0 {
+		t.Error("booked gas without a gas price")
+	}
+}
+
+// TestSyncBidBehindOurTenure has us bid again while we manage the pool, so
+// our queued bid and the tenure we serve are on chain together
+func TestSyncBidBehindOurTenure(t *testing.T) {
+	counterBid := common.HexToHash("0xc0b1d")
+
+	// managing returns an operator managing pool since block 100 on bidTx,
+	// with a counter-bid of 20 per block queued in block 110
+	managing := func(t *testing.T) *Operator {
+		op := testOperator(t)
+		op.tracker = txmgr.NewTracker(op.store)
+		withBid(t, op, 10, 1000)
+		if err := op.book(managerChanged(100, rival, us, 10)); err != nil {
+			t.Fatal(err)
+		}
+		op.syncBid(&chainView{anchor: reorg.Anchor{Number: 100}, manager: us, rentPerBlock: big.NewInt(10)})
+
+		op.onReceipt(&txmgr.PendingTx{Hash: counterBid, Kind: txmgr.KindSubmitBid, PoolId: pool, Rent: big.NewInt(20), Value: big.NewInt(2000)},
+			&types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(110)})
+		op.syncBid(&chainView{
+			anchor:          reorg.Anchor{Number: 112},
+			manager:         us,
+			rentPerBlock:    big.NewInt(10),
+			nextBidder:      us,
+			nextRent:        big.NewInt(20),
+			nextDeposit:     big.NewInt(2000),
+			activationBlock: big.NewInt(115),
+		})
+		if bid := op.store.ActiveBid(pool); bid.TxHash != counterBid || bid.Status != store.BidQueued || bid.Deposit.Int64() != 2000 {
+			t.Fatalf("ActiveBid() = %+v, want the counter-bid queued with its deposit", bid)
+		}
+		return op
+	}
+
+	t.Run("takes over", func(t *testing.T) {
+		op := managing(t)
+		if err := op.book(managerChanged(115, us, us, 20)); err != nil {
+			t.Fatal(err)
+		}
+		op.syncBid(&chainView{anchor: reorg.Anchor{Number: 116}, manager: us, rentPerBlock: big.NewInt(20)})
+
+		if bid := op.store.ActiveBid(pool); bid.TxHash != counterBid || bid.Status != store.BidActive {
+			t.Errorf("ActiveBid() = %+v, want the counter-bid active", bid)
+		}
+		tenures := op.store.Tenures()
+		if len(tenures) != 2 || tenures[0].Id != bidTx || tenures[0].EndBlock != 115 || tenures[1].Id != counterBid || tenures[1].EndBlock != 0 {
+			t.Fatalf("Tenures() = %+v, want %s ended at 115 and %s open", tenures, bidTx.Hex(), counterBid.Hex())
+		}
+		// Fifteen blocks of rent on the first tenure, the rest refunded
+		var got []entry
+		for _, e := range op.store.Accounting() {
+			got = append(got, entry{e.Kind, e.Amount.Int64(), e.Tenure})
+		}
+		want := []entry{
+			{store.EntryDepositLocked, 1000, bidTx},
+			{store.EntryDepositLocked, 2000, counterBid},
+			{store.EntryRentPaid, 150, bidTx},
+			{store.EntryDepositRefunded, 850, bidTx},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("booked %+v, want %+v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("outbid", func(t *testing.T) {
+		op := managing(t)
+		op.syncBid(&chainView{
+			anchor:          reorg.Anchor{Number: 113},
+			manager:         us,
+			rentPerBlock:    big.NewInt(10),
+			nextBidder:      rival,
+			nextRent:        big.NewInt(30),
+			nextDeposit:     big.NewInt(3000),
+			activationBlock: big.NewInt(118),
+		})
+
+		if bid := op.store.ActiveBid(pool); bid.TxHash != counterBid || bid.Status != store.BidOutbid {
+			t.Errorf("ActiveBid() = %+v, want the counter-bid outbid", bid)
+		}
+		if open := op.store.OpenTenure(pool); open == nil || open.Id != bidTx {
+			t.Errorf("OpenTenure() = %+v, want %s still open", open, bidTx.Hex())
+		}
+		entries := op.store.Accounting()
+		if last := entries[len(entries)-1]; last.Kind != store.EntryDepositRefunded || last.Amount.Int64() != 2000 || last.Tenure != counterBid {
+			t.Errorf("last entry %+v, want the counter-bid's deposit refunded", last)
+		}
+	})
+}
+
+func TestReplaceOurQueuedBid(t *testing.T) {
+	op := testOperator(t)
+	withBid(t, op, 10, 1000)
+
+	// Outbidding ourselves refunds the queued bid's deposit to us
+	higher := common.HexToHash("0xb1d2")
+	op.onReceipt(&txmgr.PendingTx{Hash: higher, Kind: txmgr.KindSubmitBid, PoolId: pool, Rent: big.NewInt(20), Value: big.NewInt(2000),
+		Replaces: bidTx, ReplacedDeposit: big.NewInt(1000)},
+		&types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(110)})
+
+	var got []entry
+	for _, e := range op.store.Accounting() {
+		got = append(got, entry{e.Kind, e.Amount.Int64(), e.Tenure})
+	}
+	want := []entry{
+		{store.EntryDepositLocked, 1000, bidTx},
+		{store.EntryDepositLocked, 2000, higher},
+		{store.EntryDepositRefunded, 1000, bidTx},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("booked %+v, want %+v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package reorg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Anchor identifies the exact block a read or decision was based on
+type Anchor struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// AnchorOf returns the anchor for a header
+func AnchorOf(header *types.Header) Anchor {
+	return Anchor{Number: header.Number.Uint64(), Hash: header.Hash()}
+}
+
+// HeaderReader is the chain access the watcher needs
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Watcher tracks anchored decisions until they are buried deep enough,
+// and reports any whose anchor block drops out of the canonical chain.
+type Watcher struct {
+	depth   uint64
+	headers HeaderReader
+
+	mu      sync.Mutex
+	entries map[string]Anchor
+}
+
+// NewWatcher creates a watcher requiring depth confirmations
+func NewWatcher(depth uint64, headers HeaderReader) *Watcher {
+	return &Watcher{
+		depth:   depth,
+		headers: headers,
+		entries: make(map[string]Anchor),
+	}
+}
+
+// Depth returns the confirmation depth
+func (w *Watcher) Depth() uint64 {
+	return w.depth
+}
+
+// Watch starts tracking id at anchor, unless it is already tracked
+func (w *Watcher) Watch(id string, anchor Anchor) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.entries[id]; !ok {
+		w.entries[id] = anchor
+	}
+}
+
+// Anchor returns the anchor id is tracked at
+func (w *Watcher) Anchor(id string) (Anchor, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	a, ok := w.entries[id]
+	return a, ok
+}
+
+// Forget stops tracking id
+func (w *Watcher) Forget(id string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.entries, id)
+}
+
+// Confirmed reports whether id's anchor has at least depth confirmations
+// at head. Callers should run Check first so a reorged anchor is never
+// reported as confirmed.
+func (w *Watcher) Confirmed(id string, head uint64) bool {
+	a, ok := w.Anchor(id)
+	if !ok {
+		return false
+	}
+	return head >= a.Number+w.depth
+}
+
+// Check verifies every tracked anchor is still canonical, forgetting and
+// returning the ids whose anchor block was reorged out
+func (w *Watcher) Check(ctx context.Context) ([]string, error) {
+	w.mu.Lock()
+	entries := make(map[string]Anchor, len(w.entries))
+	for id, a := range w.entries {
+		entries[id] = a
+	}
+	w.mu.Unlock()
+
+	var reorged []string
+	for id, a := range entries {
+		canonical, err := IsCanonical(ctx, w.headers, a)
+		if err != nil {
+			return nil, err
+		}
+		if !canonical {
+			reorged = append(reorged, id)
+			w.Forget(id)
+		}
+	}
+	sort.Strings(reorged)
+
+	return reorged, nil
+}
+
+// IsCanonical reports whether the anchor block is still on the canonical chain
+func IsCanonical(ctx context.Context, headers HeaderReader, a Anchor) (bool, error) {
+	header, err := headers.HeaderByNumber(ctx, new(big.Int).SetUint64(a.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		// The chain reorged to a shorter fork that has no block at this height
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get header %d: %w", a.Number, err)
+	}
+	return header.Hash() == a.Hash, nil
+}
@@ -0,0 +1,84 @@
+package reorg
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeChain serves headers for a canonical chain whose blocks can be swapped
+type fakeChain struct {
+	headers map[uint64]*types.Header
+}
+
+func newFakeChain(length uint64, fork int64) *fakeChain {
+	c := &fakeChain{headers: make(map[uint64]*types.Header)}
+	for n := uint64(1); n <= length; n++ {
+		c.headers[n] = &types.Header{Number: new(big.Int).SetUint64(n), Extra: []byte{byte(fork)}}
+	}
+	return c
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	h, ok := c.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return h, nil
+}
+
+func TestWatcherConfirmsCanonicalAnchor(t *testing.T) {
+	chain := newFakeChain(10, 0)
+	w := NewWatcher(3, chain)
+
+	w.Watch("bid", AnchorOf(chain.headers[8]))
+	// A later Watch for the same decision keeps the original anchor
+	w.Watch("bid", AnchorOf(chain.headers[10]))
+
+	reorged, err := w.Check(context.Background())
+	if err != nil || len(reorged) != 0 {
+		t.Fatalf("expected no reorgs, got %v, %v", reorged, err)
+	}
+	if w.Confirmed("bid", 10) {
+		t.Errorf("block 8 should not be confirmed at head 10 with depth 3")
+	}
+	if !w.Confirmed("bid", 11) {
+		t.Errorf("block 8 should be confirmed at head 11 with depth 3")
+	}
+}
+
+func TestWatcherReportsReorgedAnchor(t *testing.T) {
+	chain := newFakeChain(10, 0)
+	w := NewWatcher(2, chain)
+
+	w.Watch("bid", AnchorOf(chain.headers[9]))
+	w.Watch("fee", AnchorOf(chain.headers[5]))
+
+	// Blocks 9 and 10 are replaced by a competing fork
+	fork := newFakeChain(10, 1)
+	chain.headers[9], chain.headers[10] = fork.headers[9], fork.headers[10]
+
+	reorged, err := w.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(reorged) != 1 || reorged[0] != "bid" {
+		t.Fatalf("expected only bid to be reorged, got %v", reorged)
+	}
+	if _, ok := w.Anchor("bid"); ok {
+		t.Errorf("reorged decision should be forgotten")
+	}
+	if _, ok := w.Anchor("fee"); !ok {
+		t.Errorf("canonical decision should still be tracked")
+	}
+
+	// A reorg to a shorter chain removes the anchor height entirely
+	w.Watch("bid", AnchorOf(chain.headers[10]))
+	delete(chain.headers, 10)
+	if reorged, _ := w.Check(context.Background()); len(reorged) != 1 {
+		t.Errorf("expected anchor beyond new head to be reorged, got %v", reorged)
+	}
+}
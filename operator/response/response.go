@@ -0,0 +1,79 @@
+// Package response plans our answer to a rival bid queued to replace us.
+// A bid in nextBid activates ACTIVATION_DELAY blocks after it was mined;
+// until then a higher bid of ours displaces it and refunds the rival.
+package response
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Window is a rival bid waiting to activate
+type Window struct {
+	Rival common.Address
+	Rent  *big.Int
+	// ActivationBlock is the first block in which a swap installs the rival
+	ActivationBlock uint64
+}
+
+// Deadline is the last block our counter-bid can be mined in. From the
+// activation block on, any swap ordered before our bid hands the pool over.
+func (w Window) Deadline() uint64 {
+	if w.ActivationBlock == 0 {
+		return 0
+	}
+	return w.ActivationBlock - 1
+}
+
+// Params tunes when we submit within the window
+type Params struct {
+	// InclusionBlocks is how many blocks we allow a transaction to be mined
+	InclusionBlocks uint64
+	// Confirmations is how deep a decision's block must be before we act
+	Confirmations uint64
+}
+
+// Plan is what to do about a window at the current head
+type Plan struct {
+	Respond bool
+	// SubmitBy is the last block to broadcast in and still expect
+	// inclusion by the deadline
+	SubmitBy uint64
+	// Now skips the confirmation wait, which would run past SubmitBy
+	Now    bool
+	Reason string
+}
+
+// Decide plans the response at head. profitable is whether a counter-bid
+// still pays at the rent it would take.
+func Decide(w Window, head uint64, p Params, profitable bool) Plan {
+	deadline := w.Deadline()
+	submitBy := deadline
+	if submitBy > p.InclusionBlocks {
+		submitBy -= p.InclusionBlocks
+	} else {
+		submitBy = 0
+	}
+
+	switch {
+	case head >= deadline:
+		return Plan{SubmitBy: submitBy, Reason: fmt.Sprintf("window closed, rival activates at block %d", w.ActivationBlock)}
+	case !profitable:
+		return Plan{SubmitBy: submitBy, Reason: "counter-bid is not profitable"}
+	case head >= submitBy:
+		return Plan{Respond: true, SubmitBy: submitBy, Now: true,
+			Reason: fmt.Sprintf("%d block(s) to deadline %d, submitting now with inclusion at risk", deadline-head, deadline)}
+	case head+p.Confirmations > submitBy:
+		return Plan{Respond: true, SubmitBy: submitBy, Now: true,
+			Reason: fmt.Sprintf("waiting %d confirmation(s) would pass block %d, submitting now", p.Confirmations, submitBy)}
+	}
+	return Plan{Respond: true, SubmitBy: submitBy,
+		Reason: fmt.Sprintf("%d block(s) to deadline %d, submit by block %d", deadline-head, deadline, submitBy)}
+}
+
+// Included reports whether a counter-bid mined in block beat the rival
+func (w Window) Included(block uint64) bool {
+	return block <= w.Deadline()
+}
@@ -0,0 +1,44 @@
+package response
+
+import "testing"
+
+func TestDecide(t *testing.T) {
+	// Rival mined in block 100, activates at 105: our bid must land by 104
+	w := Window{ActivationBlock: 105}
+	p := Params{InclusionBlocks: 2}
+
+	tests := []struct {
+		name       string
+		head       uint64
+		params     Params
+		profitable bool
+		respond    bool
+		now        bool
+	}{
+		{"early", 100, p, true, true, false},
+		{"not profitable", 100, p, false, false, false},
+		{"at submit-by block", 102, p, true, true, true},
+		{"confirmations would overrun", 101, Params{InclusionBlocks: 2, Confirmations: 3}, true, true, true},
+		{"confirmations fit", 100, Params{InclusionBlocks: 2, Confirmations: 1}, true, true, false},
+		{"closed", 104, p, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Decide(w, tt.head, tt.params, tt.profitable)
+			if plan.Respond != tt.respond || plan.Now != tt.now {
+				t.Errorf("Decide() = %+v, want respond %v now %v", plan, tt.respond, tt.now)
+			}
+			if plan.SubmitBy != 102 {
+				t.Errorf("SubmitBy = %d, want 102", plan.SubmitBy)
+			}
+			if plan.Reason == "" {
+				t.Error("Decide() gave no reason")
+			}
+		})
+	}
+
+	if !w.Included(104) || w.Included(105) {
+		t.Error("Included() must accept block 104 and reject the activation block")
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"auction-pool/operator/alert"
+	"auction-pool/operator/logging"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/response"
+	"auction-pool/operator/rivals"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// minBidIncrement mirrors MIN_BID_INCREMENT in AuctionPoolHook
+var minBidIncrement = big.NewInt(100)
+
+// maxFee mirrors MAX_FEE in AuctionPoolHook, in pips
+const maxFee = 10000
+
+// requiredBid is the lowest rent submitBid would accept against view
+func (op *Operator) requiredBid(view *chainView) *big.Int {
+	highest := view.rentPerBlock
+	if view.nextRent.Cmp(highest) > 0 {
+		highest = view.nextRent
+	}
+	return new(big.Int).Add(highest, minBidIncrement)
+}
+
+// rivalProfiles profiles the other bidders as of anchor, or returns nil
+// if the bid history can't be read
+func (op *Operator) rivalProfiles(ctx context.Context, anchor reorg.Anchor) []*rivals.Profile {
+	bids, err := op.hook.GetBidHistory(&bind.CallOpts{Context: ctx, BlockHash: anchor.Hash}, op.poolId)
+	if err != nil {
+		op.log().Warn("Failed to read bid history", zap.Error(err))
+		return nil
+	}
+	return rivals.Build(bids, op.address)
+}
+
+// warnRivals reports the rival predicted to outlast a bid at rent
+func (op *Operator) warnRivals(profiles []*rivals.Profile, rent *big.Int) {
+	rival := rivals.Strongest(profiles)
+	if rival == nil || rival.Ceiling().Cmp(rent) < 0 {
+		return
+	}
+	op.log().Warn("Rival predicted to outbid us",
+		logging.Address("rival", rival.Bidder),
+		zap.Stringer("ceiling", rival.Ceiling()),
+		zap.String("ceiling_fmt", op.rent.Format(rival.Ceiling())),
+		zap.Stringer("max_rent", rival.MaxRent),
+		zap.String("max_rent_fmt", units.FormatWei(rival.MaxRent)),
+		zap.Int("bids", rival.Bids),
+		zap.Uint64("latency_blocks", rival.Latency))
+}
+
+// responseWindow is the rival bid queued to replace us as manager, or nil
+func (op *Operator) responseWindow(view *chainView) *response.Window {
+	if view.manager != op.address || view.nextBidder == (common.Address{}) || view.nextBidder == op.address {
+		return nil
+	}
+	return &response.Window{
+		Rival:           view.nextBidder,
+		Rent:            view.nextRent,
+		ActivationBlock: view.activationBlock.Uint64(),
+	}
+}
+
+// concede alerts, once per rival bid, that we are letting it replace us
+func (op *Operator) concede(anchor reorg.Anchor, plan response.Plan) {
+	w := op.window
+	if op.conceded == w.ActivationBlock {
+		return
+	}
+	op.conceded = w.ActivationBlock
+
+	op.recordDecision(anchor, "concede", fmt.Sprintf("%s; projected handover at block %d", plan.Reason, w.ActivationBlock), w.Rent, 0)
+	op.log().Warn("Conceding manager position",
+		logging.Address("rival", w.Rival),
+		zap.Stringer("rent_per_block", w.Rent),
+		zap.String("rent_per_block_fmt", op.rent.Format(w.Rent)),
+		zap.Uint64("activation_block", w.ActivationBlock),
+		zap.String("reason", plan.Reason))
+	op.alert(fmt.Sprintf("concede:%d", w.ActivationBlock), alert.Critical, "Conceding manager position",
+		fmt.Sprintf("%s will replace us at %s (%s); projected handover at block %d", w.Rival.Hex(), op.rent.Format(w.Rent), plan.Reason, w.ActivationBlock))
+}
+
+// winning reports whether we already hold the position: we are manager
+// with no rival queued, or our own bid is the queued one
+func (op *Operator) winning(view *chainView) bool {
+	return view.nextBidder == op.address ||
+		(view.manager == op.address && view.nextBidder == (common.Address{}))
+}
+
+// ready gates an action until the block that first triggered it has the
+// configured confirmation depth. The decision is re-made every tick and a
+// reorged anchor drops the proposal, so when ready returns true the action
+// has been re-validated against the current head on the surviving fork.
+func (op *Operator) ready(id string, anchor reorg.Anchor) bool {
+	if op.confirmations == 0 {
+		return true
+	}
+
+	op.proposals.Watch(id, anchor)
+	if !op.proposals.Confirmed(id, anchor.Number) {
+		first, _ := op.proposals.Anchor(id)
+		op.log().Info("Waiting for confirmations before acting",
+			zap.String("action", id), logging.Block(first.Number), zap.Uint64("confirmations", op.confirmations))
+		return false
+	}
+
+	op.proposals.Forget(id)
+	return true
+}
+
+// revalidate drops proposals whose anchor was reorged out and re-evaluates
+// unmined transactions that were decided on a block that no longer exists
+func (op *Operator) revalidate(ctx context.Context, view *chainView) {
+	reorged, err := op.proposals.Check(ctx)
+	if err != nil {
+		op.log().Error("Failed to check proposal anchors", zap.Error(err))
+		return
+	}
+	for _, id := range reorged {
+		op.log().Warn("Block behind a pending decision was reorged out, re-evaluating", zap.String("action", id))
+	}
+
+	for _, ptx := range op.tracker.List() {
+		if ptx.AnchorHash == (common.Hash{}) {
+			continue
+		}
+
+		anchor := reorg.Anchor{Number: ptx.AnchorBlock, Hash: ptx.AnchorHash}
+		canonical, err := reorg.IsCanonical(ctx, op.client, anchor)
+		if err != nil {
+			op.txLog(ptx).Error("Failed to check anchor", zap.Error(err))
+			return
+		}
+		if canonical {
+			continue
+		}
+
+		// Already mined: the confirmation depth guards its own block
+		if _, err := op.client.TransactionReceipt(ctx, ptx.Hash); err == nil {
+			continue
+		}
+
+		op.txLog(ptx).Warn("Block behind a pending transaction was reorged out", zap.Uint64("anchor_block", anchor.Number))
+		if !op.stillWanted(ptx, view) {
+			op.txLog(ptx).Info("Action no longer justified on the new chain, cancelling")
+			if err := op.cancelTx(ctx, ptx); err != nil {
+				op.txLog(ptx).Error("Failed to cancel", zap.Error(err))
+				continue
+			}
+		} else {
+			op.txLog(ptx).Info("Action still justified on the new chain, keeping it")
+		}
+
+		// Re-anchor so the same reorg is not handled twice
+		ptx.AnchorBlock, ptx.AnchorHash = view.anchor.Number, view.anchor.Hash
+		if err := op.tracker.Track(ptx); err != nil {
+			op.txLog(ptx).Error("Failed to persist pending transaction", zap.Error(err))
+		}
+	}
+}
+
+// stillWanted re-runs the decision behind ptx against the current state
+func (op *Operator) stillWanted(ptx *txmgr.PendingTx, view *chainView) bool {
+	switch ptx.Kind {
+	case txmgr.KindSubmitBid:
+		return !op.winning(view) && ptx.Rent != nil && ptx.Rent.Cmp(op.requiredBid(view)) >= 0
+	case txmgr.KindSetSwapFee:
+		return view.manager == op.address
+	}
+	return true
+}
+
+// cancelTx replaces an unmined transaction with a zero-value self-transfer
+// at the same nonce, paying enough more gas for nodes to accept it
+func (op *Operator) cancelTx(ctx context.Context, ptx *txmgr.PendingTx) error {
+	if err := op.mayTransact(); err != nil {
+		return err
+	}
+
+	orig, _, err := op.client.TransactionByHash(ctx, ptx.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to get original transaction: %w", err)
+	}
+
+	chainID, err := op.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+
+	replacement := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     ptx.Nonce,
+		GasTipCap: bumpGas(orig.GasTipCap()),
+		GasFeeCap: bumpGas(orig.GasFeeCap()),
+		Gas:       21000,
+		To:        &op.address,
+		Value:     new(big.Int),
+	})
+
+	signed, err := types.SignTx(replacement, types.LatestSignerForChainID(chainID), op.privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign cancellation: %w", err)
+	}
+
+	if err := op.client.SendTransaction(ctx, signed); err != nil {
+		return fmt.Errorf("failed to send cancellation: %w", err)
+	}
+
+	op.txLog(ptx).Info("Cancellation sent", zap.String("replacement", signed.Hash().Hex()))
+	return nil
+}
+
+// bumpGas raises a gas price by 12.5%, above the 10% replacement minimum
+func bumpGas(price *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(price, big.NewInt(1125))
+	bumped.Div(bumped, big.NewInt(1000))
+	return bumped.Add(bumped, big.NewInt(1))
+}
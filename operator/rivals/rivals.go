@@ -0,0 +1,179 @@
+// Package rivals profiles the other bidders of a pool from its bid history
+// so strategies can anticipate how far they will go.
+package rivals
+
+import (
+	"math/big"
+	"sort"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Hook constants the profiles are measured against; they mirror
+// AuctionPoolHook
+const (
+	ActivationDelay  = 5
+	MinDepositBlocks = 100
+)
+
+// MinBidIncrement mirrors MIN_BID_INCREMENT in AuctionPoolHook
+var MinBidIncrement = big.NewInt(100)
+
+// Profile summarizes one bidder's behaviour
+type Profile struct {
+	Bidder common.Address `json:"bidder"`
+	Bids   int            `json:"bids"`
+	// FirstBlock and LastBlock are when the first and latest bids were mined
+	FirstBlock uint64 `json:"firstBlock"`
+	LastBlock  uint64 `json:"lastBlock"`
+	// Interval is the median number of blocks between the bidder's bids,
+	// zero with a single bid
+	Interval uint64 `json:"interval"`
+
+	// Excess is the median amount by which the bidder raised the rent
+	// beyond MIN_BID_INCREMENT, in wei per block
+	Excess *big.Int `json:"excess"`
+	// Latency is the median number of blocks the bidder took to answer
+	// one of our bids, and Reactions how many times it did
+	Latency   uint64 `json:"latency"`
+	Reactions int    `json:"reactions"`
+	// DepositBlocks is the median number of blocks of rent the bidder's
+	// deposits covered; MIN_DEPOSIT_BLOCKS is the least the hook accepts
+	DepositBlocks uint64 `json:"depositBlocks"`
+
+	MaxRent *big.Int `json:"maxRent"`
+}
+
+// DepositMultiple is DepositBlocks over MIN_DEPOSIT_BLOCKS: 1 for a bidder
+// that always deposits the minimum, higher for one planning to stay
+func (p *Profile) DepositMultiple() float64 {
+	return float64(p.DepositBlocks) / MinDepositBlocks
+}
+
+// Ceiling predicts the highest rent the bidder will go to: its highest
+// bid so far, raised once more the way it usually raises
+func (p *Profile) Ceiling() *big.Int {
+	ceiling := new(big.Int).Add(p.MaxRent, MinBidIncrement)
+	return ceiling.Add(ceiling, p.Excess)
+}
+
+// block is when a bid was mined; the hook only records its activation
+func block(b contracts.AuctionPoolHookBid) uint64 {
+	if b.ActivationBlock == nil || b.ActivationBlock.Uint64() < ActivationDelay {
+		return 0
+	}
+	return b.ActivationBlock.Uint64() - ActivationDelay
+}
+
+// Build profiles every bidder in history except us, strongest ceiling
+// first. history is getBidHistory: every bid in submission order.
+func Build(history []contracts.AuctionPoolHookBid, us common.Address) []*Profile {
+	type samples struct {
+		blocks   []uint64
+		excess   []*big.Int
+		latency  []uint64
+		deposits []uint64
+	}
+
+	profiles := map[common.Address]*Profile{}
+	data := map[common.Address]*samples{}
+
+	for i, b := range history {
+		if b.Bidder == us {
+			continue
+		}
+		p, ok := profiles[b.Bidder]
+		if !ok {
+			p = &Profile{Bidder: b.Bidder, MaxRent: new(big.Int)}
+			profiles[b.Bidder] = p
+			data[b.Bidder] = &samples{}
+		}
+		s := data[b.Bidder]
+
+		at := block(b)
+		p.Bids++
+		if p.FirstBlock == 0 || at < p.FirstBlock {
+			p.FirstBlock = at
+		}
+		if at > p.LastBlock {
+			p.LastBlock = at
+		}
+		s.blocks = append(s.blocks, at)
+		if b.RentPerBlock.Cmp(p.MaxRent) > 0 {
+			p.MaxRent = new(big.Int).Set(b.RentPerBlock)
+		}
+		if b.RentPerBlock.Sign() > 0 {
+			s.deposits = append(s.deposits, new(big.Int).Div(b.Deposit, b.RentPerBlock).Uint64())
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := history[i-1]
+		// A bid after a handover to nobody starts from zero rent, so a
+		// raise below the minimum means the previous bid no longer counts
+		excess := new(big.Int).Sub(b.RentPerBlock, prev.RentPerBlock)
+		excess.Sub(excess, MinBidIncrement)
+		if excess.Sign() >= 0 {
+			s.excess = append(s.excess, excess)
+		}
+		if prev.Bidder == us && us != (common.Address{}) {
+			s.latency = append(s.latency, block(b)-block(prev))
+		}
+	}
+
+	out := make([]*Profile, 0, len(profiles))
+	for addr, p := range profiles {
+		s := data[addr]
+		p.Interval = median(intervals(s.blocks))
+		p.Excess = medianWei(s.excess)
+		p.Latency = median(s.latency)
+		p.Reactions = len(s.latency)
+		p.DepositBlocks = median(s.deposits)
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if c := out[i].Ceiling().Cmp(out[j].Ceiling()); c != 0 {
+			return c > 0
+		}
+		return out[i].Bidder.Hex() < out[j].Bidder.Hex()
+	})
+	return out
+}
+
+// Strongest returns the profile with the highest predicted ceiling, or nil
+func Strongest(profiles []*Profile) *Profile {
+	if len(profiles) == 0 {
+		return nil
+	}
+	return profiles[0]
+}
+
+func intervals(blocks []uint64) []uint64 {
+	var out []uint64
+	for i := 1; i < len(blocks); i++ {
+		out = append(out, blocks[i]-blocks[i-1])
+	}
+	return out
+}
+
+// median returns the lower median, or zero without samples
+func median(values []uint64) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)/2]
+}
+
+func medianWei(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	sorted := append([]*big.Int(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	return new(big.Int).Set(sorted[(len(sorted)-1)/2])
+}
@@ -0,0 +1,76 @@
+package rivals
+
+import (
+	"math/big"
+	"testing"
+
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	us    = common.HexToAddress("0x01")
+	alice = common.HexToAddress("0xa1")
+	bob   = common.HexToAddress("0xb0")
+)
+
+func bid(bidder common.Address, minedAt uint64, rent, depositBlocks int64) contracts.AuctionPoolHookBid {
+	return contracts.AuctionPoolHookBid{
+		Bidder:          bidder,
+		RentPerBlock:    big.NewInt(rent),
+		Deposit:         big.NewInt(rent * depositBlocks),
+		ActivationBlock: new(big.Int).SetUint64(minedAt + ActivationDelay),
+		Timestamp:       new(big.Int),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	history := []contracts.AuctionPoolHookBid{
+		bid(alice, 100, 1000, 100),
+		bid(us, 110, 1100, 100),
+		bid(alice, 113, 1250, 300), // answers us after 3 blocks, 50 over the minimum
+		bid(bob, 150, 1400, 100),
+		bid(us, 160, 1500, 100),
+		bid(alice, 165, 1700, 300), // answers us after 5 blocks, 100 over the minimum
+		// Handover to nobody reset the rent: not an increment sample
+		bid(bob, 400, 200, 100),
+	}
+
+	profiles := Build(history, us)
+	if len(profiles) != 2 {
+		t.Fatalf("Build() returned %d profiles, want 2", len(profiles))
+	}
+
+	a := Strongest(profiles)
+	if a.Bidder != alice {
+		t.Fatalf("strongest = %s, want alice", a.Bidder.Hex())
+	}
+	if a.Bids != 3 || a.FirstBlock != 100 || a.LastBlock != 165 || a.Interval != 13 {
+		t.Errorf("alice activity = %+v", a)
+	}
+	if a.Reactions != 2 || a.Latency != 3 {
+		t.Errorf("alice reacted %d times, median latency %d", a.Reactions, a.Latency)
+	}
+	if a.Excess.Int64() != 50 || a.MaxRent.Int64() != 1700 || a.DepositBlocks != 300 || a.DepositMultiple() != 3 {
+		t.Errorf("alice bidding = %+v", a)
+	}
+	if a.Ceiling().Int64() != 1850 {
+		t.Errorf("alice ceiling = %s, want 1850", a.Ceiling())
+	}
+
+	b := profiles[1]
+	if b.Bids != 2 || b.Reactions != 0 || b.Excess.Int64() != 50 || b.MaxRent.Int64() != 1400 {
+		t.Errorf("bob = %+v", b)
+	}
+}
+
+func TestBuildEmpty(t *testing.T) {
+	if profiles := Build(nil, us); len(profiles) != 0 || Strongest(profiles) != nil {
+		t.Errorf("Build(nil) = %v", profiles)
+	}
+	// Our own bids are never profiled
+	if profiles := Build([]contracts.AuctionPoolHookBid{bid(us, 10, 1000, 100)}, us); len(profiles) != 0 {
+		t.Errorf("Build() profiled us: %v", profiles)
+	}
+}
@@ -0,0 +1,626 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.uber.org/zap"
+)
+
+// Backend is the subset of ethclient.Client the wrapper fans out to
+type Backend interface {
+	ethereum.ChainReader
+	ethereum.ContractCaller
+	ethereum.LogFilterer
+	ethereum.TransactionReader
+	ethereum.TransactionSender
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.PendingStateReader
+	ethereum.ChainStateReader
+
+	BlockNumber(ctx context.Context) (uint64, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
+	CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error)
+	CodeAtHash(ctx context.Context, account common.Address, blockHash common.Hash) ([]byte, error)
+	Close()
+}
+
+// Config controls failover and quorum behaviour
+type Config struct {
+	URLs []string
+
+	// Quorum is the number of endpoints (M of N) that must agree on critical
+	// reads; values <= 1 disable quorum checks
+	Quorum int
+
+	// MaxLag is how many blocks an endpoint may trail the best known head
+	// before it is skipped
+	MaxLag uint64
+
+	// HealthInterval is how often endpoint heads are refreshed
+	HealthInterval time.Duration
+
+	// Logger reports endpoints that fail to dial; zap.L() if nil
+	Logger *zap.Logger
+}
+
+// Client fans requests out over several RPC endpoints. Reads go to the
+// healthiest endpoint and fail over on transport errors, transactions are
+// broadcast to every healthy endpoint, and critical reads can require
+// agreement from a quorum of endpoints.
+type Client struct {
+	endpoints []*endpoint
+	quorum    int
+	maxLag    uint64
+	interval  time.Duration
+}
+
+// Dial connects to every configured endpoint. Endpoints that fail to dial
+// are reported and skipped; at least one must succeed.
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.L()
+	}
+	backends := make(map[string]Backend)
+	var urls []string
+
+	for _, u := range cfg.URLs {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		c, err := ethclient.DialContext(ctx, u)
+		if err != nil {
+			logger.Warn("Failed to dial RPC", zap.String("endpoint", host(u)), zap.Error(err))
+			continue
+		}
+		backends[u] = c
+		urls = append(urls, u)
+	}
+
+	if len(backends) == 0 {
+		return nil, errors.New("no RPC endpoint could be dialed")
+	}
+
+	return New(cfg, urls, backends)
+}
+
+// host names an endpoint without the API key its URL may carry
+func host(endpoint string) string {
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return endpoint
+}
+
+// New builds a client over already-connected backends, keyed by URL in
+// the order given.
+func New(cfg Config, urls []string, backends map[string]Backend) (*Client, error) {
+	if cfg.Quorum > len(urls) {
+		return nil, fmt.Errorf("quorum %d exceeds the %d available endpoints", cfg.Quorum, len(urls))
+	}
+
+	interval := cfg.HealthInterval
+	if interval == 0 {
+		interval = 12 * time.Second
+	}
+
+	c := &Client{
+		quorum:   cfg.Quorum,
+		maxLag:   cfg.MaxLag,
+		interval: interval,
+	}
+	for _, url := range urls {
+		c.endpoints = append(c.endpoints, &endpoint{url: url, backend: backends[url], score: 1})
+	}
+
+	return c, nil
+}
+
+// Close closes every underlying connection
+func (c *Client) Close() {
+	for _, ep := range c.endpoints {
+		ep.backend.Close()
+	}
+}
+
+// Monitor refreshes endpoint heads until ctx is cancelled, so lagging
+// endpoints are skipped even when nothing is failing outright
+func (c *Client) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.refreshHeads(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) refreshHeads(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, ep := range c.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			head, err := ep.backend.BlockNumber(ctx)
+			ep.record(err, time.Since(start))
+			if err == nil {
+				ep.setHead(head)
+			}
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// Status reports every endpoint's health, healthiest first
+func (c *Client) Status() []EndpointStatus {
+	statuses := make([]EndpointStatus, 0, len(c.endpoints))
+	for _, ep := range c.ranked() {
+		statuses = append(statuses, ep.status())
+	}
+	return statuses
+}
+
+// Degraded lists the endpoints out of rotation because they lag the best
+// head or keep failing
+func (c *Client) Degraded() []EndpointStatus {
+	best := c.bestHead()
+
+	var out []EndpointStatus
+	for _, ep := range c.endpoints {
+		if st := ep.status(); !c.usable(st, best) {
+			out = append(out, st)
+		}
+	}
+	return out
+}
+
+// bestHead is the highest block any endpoint has reported
+func (c *Client) bestHead() uint64 {
+	var best uint64
+	for _, ep := range c.endpoints {
+		if h := ep.status().Head; h > best {
+			best = h
+		}
+	}
+	return best
+}
+
+// usable reports whether an endpoint is neither failing nor lagging
+func (c *Client) usable(st EndpointStatus, best uint64) bool {
+	if st.ConsecutiveFailures >= maxConsecutiveFailures {
+		return false
+	}
+	return c.maxLag == 0 || st.Head+c.maxLag >= best
+}
+
+// ranked returns endpoints ordered by health, with lagging and repeatedly
+// failing endpoints moved to the back rather than dropped entirely
+func (c *Client) ranked() []*endpoint {
+	best := c.bestHead()
+
+	eps := append([]*endpoint(nil), c.endpoints...)
+	sort.SliceStable(eps, func(i, j int) bool {
+		si, sj := eps[i].status(), eps[j].status()
+		ui, uj := c.usable(si, best), c.usable(sj, best)
+		if ui != uj {
+			return ui
+		}
+		return si.Score > sj.Score
+	})
+
+	return eps
+}
+
+// healthy returns the usable endpoints, falling back to all endpoints when
+// none look healthy so the operator keeps trying
+func (c *Client) healthy() []*endpoint {
+	best := c.bestHead()
+
+	var eps []*endpoint
+	for _, ep := range c.ranked() {
+		if c.usable(ep.status(), best) {
+			eps = append(eps, ep)
+		}
+	}
+	if len(eps) == 0 {
+		return c.ranked()
+	}
+	return eps
+}
+
+// do runs fn against endpoints in health order until one succeeds
+func (c *Client) do(ctx context.Context, fn func(Backend) error) error {
+	var errs []error
+	for _, ep := range c.healthy() {
+		start := time.Now()
+		err := fn(ep.backend)
+		if err == nil || !isTransportError(err) {
+			ep.record(nil, time.Since(start))
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		ep.record(err, time.Since(start))
+		errs = append(errs, fmt.Errorf("%s: %w", ep.url, err))
+	}
+
+	return fmt.Errorf("all RPC endpoints failed: %w", errors.Join(errs...))
+}
+
+// isTransportError reports whether err means the endpoint itself is at
+// fault (as opposed to a JSON-RPC answer such as a revert)
+func isTransportError(err error) bool {
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	return true
+}
+
+// ===== ethclient-compatible methods =====
+
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var n uint64
+	err := c.do(ctx, func(b Backend) (err error) {
+		n, err = b.BlockNumber(ctx)
+		return err
+	})
+	return n, err
+}
+
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var id *big.Int
+	err := c.do(ctx, func(b Backend) (err error) {
+		id, err = b.ChainID(ctx)
+		return err
+	})
+	return id, err
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	var block *types.Block
+	err := c.do(ctx, func(b Backend) (err error) {
+		block, err = b.BlockByHash(ctx, hash)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	err := c.do(ctx, func(b Backend) (err error) {
+		block, err = b.BlockByNumber(ctx, number)
+		return err
+	})
+	return block, err
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	var header *types.Header
+	err := c.do(ctx, func(b Backend) (err error) {
+		header, err = b.HeaderByHash(ctx, hash)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := c.do(ctx, func(b Backend) (err error) {
+		header, err = b.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var n uint
+	err := c.do(ctx, func(b Backend) (err error) {
+		n, err = b.TransactionCount(ctx, blockHash)
+		return err
+	})
+	return n, err
+}
+
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	var tx *types.Transaction
+	err := c.do(ctx, func(b Backend) (err error) {
+		tx, err = b.TransactionInBlock(ctx, blockHash, index)
+		return err
+	})
+	return tx, err
+}
+
+// SubscribeNewHead subscribes through the healthiest endpoint
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := c.do(ctx, func(b Backend) (err error) {
+		sub, err = b.SubscribeNewHead(ctx, ch)
+		return err
+	})
+	return sub, err
+}
+
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var (
+		tx      *types.Transaction
+		pending bool
+	)
+	err := c.do(ctx, func(b Backend) (err error) {
+		tx, pending, err = b.TransactionByHash(ctx, hash)
+		return err
+	})
+	return tx, pending, err
+}
+
+// TransactionReceipt asks every healthy endpoint before reporting NotFound,
+// since a lagging endpoint may simply not have the block yet. NotFound
+// needs an endpoint to have answered so; if none did, the tx may well be
+// mined and the endpoints' errors are returned instead.
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	var (
+		errs     []error
+		notFound bool
+	)
+	for _, ep := range c.healthy() {
+		start := time.Now()
+		receipt, err := ep.backend.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			ep.record(nil, time.Since(start))
+			return receipt, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if isTransportError(err) {
+			ep.record(err, time.Since(start))
+		} else {
+			ep.record(nil, time.Since(start))
+		}
+		if errors.Is(err, ethereum.NotFound) {
+			notFound = true
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", ep.url, err))
+	}
+
+	if notFound {
+		return nil, ethereum.NotFound
+	}
+	return nil, fmt.Errorf("all RPC endpoints failed: %w", errors.Join(errs...))
+}
+
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var bal *big.Int
+	err := c.do(ctx, func(b Backend) (err error) {
+		bal, err = b.BalanceAt(ctx, account, blockNumber)
+		return err
+	})
+	return bal, err
+}
+
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var data []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		data, err = b.StorageAt(ctx, account, key, blockNumber)
+		return err
+	})
+	return data, err
+}
+
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var code []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		code, err = b.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var nonce uint64
+	err := c.do(ctx, func(b Backend) (err error) {
+		nonce, err = b.NonceAt(ctx, account, blockNumber)
+		return err
+	})
+	return nonce, err
+}
+
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	var bal *big.Int
+	err := c.do(ctx, func(b Backend) (err error) {
+		bal, err = b.PendingBalanceAt(ctx, account)
+		return err
+	})
+	return bal, err
+}
+
+func (c *Client) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	var data []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		data, err = b.PendingStorageAt(ctx, account, key)
+		return err
+	})
+	return data, err
+}
+
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var code []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		code, err = b.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var nonce uint64
+	err := c.do(ctx, func(b Backend) (err error) {
+		nonce, err = b.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (c *Client) PendingTransactionCount(ctx context.Context) (uint, error) {
+	var n uint
+	err := c.do(ctx, func(b Backend) (err error) {
+		n, err = b.PendingTransactionCount(ctx)
+		return err
+	})
+	return n, err
+}
+
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		out, err = b.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+// CallContractAtHash executes a call against the state of a specific block,
+// so results cannot silently move to a different fork
+func (c *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		out, err = b.CallContractAtHash(ctx, msg, blockHash)
+		return err
+	})
+	return out, err
+}
+
+func (c *Client) CodeAtHash(ctx context.Context, account common.Address, blockHash common.Hash) ([]byte, error) {
+	var code []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		code, err = b.CodeAtHash(ctx, account, blockHash)
+		return err
+	})
+	return code, err
+}
+
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var out []byte
+	err := c.do(ctx, func(b Backend) (err error) {
+		out, err = b.PendingCallContract(ctx, msg)
+		return err
+	})
+	return out, err
+}
+
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var gas uint64
+	err := c.do(ctx, func(b Backend) (err error) {
+		gas, err = b.EstimateGas(ctx, msg)
+		return err
+	})
+	return gas, err
+}
+
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var price *big.Int
+	err := c.do(ctx, func(b Backend) (err error) {
+		price, err = b.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var tip *big.Int
+	err := c.do(ctx, func(b Backend) (err error) {
+		tip, err = b.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := c.do(ctx, func(b Backend) (err error) {
+		logs, err = b.FilterLogs(ctx, q)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs subscribes through the healthiest endpoint
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := c.do(ctx, func(b Backend) (err error) {
+		sub, err = b.SubscribeFilterLogs(ctx, q, ch)
+		return err
+	})
+	return sub, err
+}
+
+// SendTransaction broadcasts tx to every healthy endpoint concurrently and
+// succeeds if at least one accepted it
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	eps := c.healthy()
+	errs := make([]error, len(eps))
+
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			err := ep.backend.SendTransaction(ctx, tx)
+			if err != nil && isAlreadyKnown(err) {
+				err = nil
+			}
+			if err == nil || isTransportError(err) {
+				ep.record(err, time.Since(start))
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", ep.url, err)
+			}
+		}(i, ep)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("transaction rejected by every endpoint: %w", errors.Join(errs...))
+}
+
+// isAlreadyKnown matches the errors nodes return when another endpoint's
+// gossip delivered the same transaction first
+func isAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
@@ -0,0 +1,147 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeBackend answers the handful of calls the tests exercise; anything
+// else panics through the nil embedded interface
+type fakeBackend struct {
+	Backend
+
+	head    uint64
+	result  []byte
+	err     error
+	sent    int
+	sendErr error
+}
+
+func (f *fakeBackend) BlockNumber(context.Context) (uint64, error) {
+	return f.head, f.err
+}
+
+func (f *fakeBackend) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return f.result, f.err
+}
+
+func (f *fakeBackend) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return nil, f.err
+}
+
+func (f *fakeBackend) SendTransaction(context.Context, *types.Transaction) error {
+	f.sent++
+	return f.sendErr
+}
+
+func (f *fakeBackend) Close() {}
+
+func newTestClient(t *testing.T, quorum int, backends ...*fakeBackend) *Client {
+	t.Helper()
+
+	urls := make([]string, len(backends))
+	m := make(map[string]Backend)
+	for i, b := range backends {
+		urls[i] = string(rune('a' + i))
+		m[urls[i]] = b
+	}
+
+	c, err := New(Config{Quorum: quorum, MaxLag: 2}, urls, m)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return c
+}
+
+func TestFailoverOnTransportError(t *testing.T) {
+	down := &fakeBackend{err: errors.New("connection refused")}
+	up := &fakeBackend{head: 100}
+	c := newTestClient(t, 0, down, up)
+
+	for i := 0; i < maxConsecutiveFailures; i++ {
+		n, err := c.BlockNumber(context.Background())
+		if err != nil || n != 100 {
+			t.Fatalf("expected failover to healthy endpoint, got %d, %v", n, err)
+		}
+	}
+
+	// After its first failure the down endpoint is ranked behind the healthy one
+	if st := c.Status(); st[0].URL != "b" || st[1].ConsecutiveFailures != 1 {
+		t.Errorf("expected failing endpoint to be ranked last: %+v", st)
+	}
+}
+
+// TestReceiptNotFound checks a receipt is only reported missing when an
+// endpoint says so, not when every endpoint is unreachable
+func TestReceiptNotFound(t *testing.T) {
+	down := errors.New("connection refused")
+
+	c := newTestClient(t, 0, &fakeBackend{err: down}, &fakeBackend{err: down})
+	if _, err := c.TransactionReceipt(context.Background(), common.Hash{}); errors.Is(err, ethereum.NotFound) || !errors.Is(err, down) {
+		t.Errorf("all endpoints down: got %v, want the transport error", err)
+	}
+
+	c = newTestClient(t, 0, &fakeBackend{err: down}, &fakeBackend{err: ethereum.NotFound})
+	if _, err := c.TransactionReceipt(context.Background(), common.Hash{}); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("one endpoint answered: got %v, want NotFound", err)
+	}
+}
+
+func TestLaggingEndpointSkipped(t *testing.T) {
+	lagging := &fakeBackend{head: 90, result: []byte{1}}
+	current := &fakeBackend{head: 100, result: []byte{2}}
+	c := newTestClient(t, 0, lagging, current)
+	c.refreshHeads(context.Background())
+
+	out, err := c.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err != nil || out[0] != 2 {
+		t.Fatalf("expected read from up-to-date endpoint, got %v, %v", out, err)
+	}
+
+	if d := c.Degraded(); len(d) != 1 || d[0].URL != "a" {
+		t.Errorf("expected only the lagging endpoint to be degraded: %+v", d)
+	}
+}
+
+func TestQuorumReads(t *testing.T) {
+	a := &fakeBackend{head: 101, result: []byte{7}}
+	b := &fakeBackend{head: 100, result: []byte{7}}
+	liar := &fakeBackend{head: 102, result: []byte{9}}
+	c := newTestClient(t, 2, a, b, liar)
+
+	n, err := c.QuorumBlockNumber(context.Background())
+	if err != nil || n != 101 {
+		t.Fatalf("expected quorum block 101, got %d, %v", n, err)
+	}
+
+	out, err := c.Quorum().CallContract(context.Background(), ethereum.CallMsg{}, nil)
+	if err != nil || out[0] != 7 {
+		t.Fatalf("expected agreed result, got %v, %v", out, err)
+	}
+
+	b.result = []byte{8}
+	if _, err := c.Quorum().CallContract(context.Background(), ethereum.CallMsg{}, nil); !errors.Is(err, ErrNoQuorum) {
+		t.Fatalf("expected ErrNoQuorum, got %v", err)
+	}
+}
+
+func TestSendTransactionBroadcasts(t *testing.T) {
+	a := &fakeBackend{sendErr: errors.New("already known")}
+	b := &fakeBackend{}
+	down := &fakeBackend{sendErr: errors.New("connection reset")}
+	c := newTestClient(t, 0, a, b, down)
+
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000})
+	if err := c.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatalf("SendTransaction failed: %v", err)
+	}
+	if a.sent != 1 || b.sent != 1 || down.sent != 1 {
+		t.Errorf("expected broadcast to every endpoint, got %d/%d/%d", a.sent, b.sent, down.sent)
+	}
+}
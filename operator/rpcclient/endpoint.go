@@ -0,0 +1,80 @@
+package rpcclient
+
+import (
+	"sync"
+	"time"
+)
+
+// maxConsecutiveFailures is how many transport errors in a row take an
+// endpoint out of rotation until it answers a health check again
+const maxConsecutiveFailures = 3
+
+// scoreDecay weights the newest outcome in the success-rate moving average
+const scoreDecay = 0.2
+
+// EndpointStatus is a point-in-time view of an endpoint's health
+type EndpointStatus struct {
+	URL                 string
+	Score               float64 // moving average of request success, 0..1
+	Latency             time.Duration
+	Head                uint64
+	ConsecutiveFailures int
+	LastError           error
+}
+
+type endpoint struct {
+	url     string
+	backend Backend
+
+	mu       sync.Mutex
+	score    float64
+	latency  time.Duration
+	head     uint64
+	failures int
+	lastErr  error
+}
+
+// record folds one request outcome into the endpoint's health score
+func (ep *endpoint) record(err error, took time.Duration) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	outcome := 1.0
+	if err != nil {
+		outcome = 0
+		ep.failures++
+		ep.lastErr = err
+	} else {
+		ep.failures = 0
+	}
+	ep.score = (1-scoreDecay)*ep.score + scoreDecay*outcome
+
+	if ep.latency == 0 {
+		ep.latency = took
+	} else {
+		ep.latency = time.Duration((1-scoreDecay)*float64(ep.latency) + scoreDecay*float64(took))
+	}
+}
+
+func (ep *endpoint) setHead(head uint64) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if head > ep.head {
+		ep.head = head
+	}
+}
+
+func (ep *endpoint) status() EndpointStatus {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	return EndpointStatus{
+		URL:                 ep.url,
+		Score:               ep.score,
+		Latency:             ep.latency,
+		Head:                ep.head,
+		ConsecutiveFailures: ep.failures,
+		LastError:           ep.lastErr,
+	}
+}
@@ -0,0 +1,177 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoQuorum is returned when too few endpoints agree on a critical read
+var ErrNoQuorum = errors.New("rpc endpoints did not reach quorum")
+
+// QuorumBlockNumber returns the highest block that at least Quorum
+// endpoints have reached. With quorum disabled it is a plain BlockNumber.
+func (c *Client) QuorumBlockNumber(ctx context.Context) (uint64, error) {
+	if c.quorum <= 1 {
+		return c.BlockNumber(ctx)
+	}
+
+	heads := c.fanOut(ctx, func(b Backend) (interface{}, error) {
+		return b.BlockNumber(ctx)
+	})
+
+	var numbers []uint64
+	for _, r := range heads {
+		if r.err == nil {
+			numbers = append(numbers, r.value.(uint64))
+		}
+	}
+	if len(numbers) < c.quorum {
+		return 0, fmt.Errorf("%w: %d of %d endpoints returned a block number", ErrNoQuorum, len(numbers), c.quorum)
+	}
+
+	// The quorum-th highest head is a block every member of the quorum has seen
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	return numbers[c.quorum-1], nil
+}
+
+// Quorum returns a contract caller whose reads must return identical bytes
+// from at least Quorum endpoints. Bind it to the reads the strategy acts
+// on, e.g. contracts.NewAuctionPoolHookCaller(addr, client.Quorum()).
+func (c *Client) Quorum() *QuorumCaller {
+	return &QuorumCaller{c: c}
+}
+
+// QuorumCaller implements bind.ContractCaller and
+// bind.BlockHashContractCaller with M-of-N agreement
+type QuorumCaller struct {
+	c *Client
+}
+
+// CodeAt returns contract code agreed on by the quorum
+func (q *QuorumCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if q.c.quorum <= 1 {
+		return q.c.CodeAt(ctx, contract, blockNumber)
+	}
+
+	blockNumber, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return q.agree(ctx, func(b Backend) ([]byte, error) {
+		return b.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+// CallContract executes a call on every healthy endpoint at the same block
+// and returns the result once the quorum agrees on it
+func (q *QuorumCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if q.c.quorum <= 1 {
+		return q.c.CallContract(ctx, call, blockNumber)
+	}
+
+	blockNumber, err := q.pin(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return q.agree(ctx, func(b Backend) ([]byte, error) {
+		return b.CallContract(ctx, call, blockNumber)
+	})
+}
+
+// CodeAtHash returns contract code at a specific block agreed on by the quorum
+func (q *QuorumCaller) CodeAtHash(ctx context.Context, contract common.Address, blockHash common.Hash) ([]byte, error) {
+	if q.c.quorum <= 1 {
+		return q.c.CodeAtHash(ctx, contract, blockHash)
+	}
+	return q.agree(ctx, func(b Backend) ([]byte, error) {
+		return b.CodeAtHash(ctx, contract, blockHash)
+	})
+}
+
+// CallContractAtHash executes a call at a specific block on every healthy
+// endpoint and returns the result once the quorum agrees on it
+func (q *QuorumCaller) CallContractAtHash(ctx context.Context, call ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	if q.c.quorum <= 1 {
+		return q.c.CallContractAtHash(ctx, call, blockHash)
+	}
+	return q.agree(ctx, func(b Backend) ([]byte, error) {
+		return b.CallContractAtHash(ctx, call, blockHash)
+	})
+}
+
+// pin replaces "latest" with the quorum block so every endpoint answers
+// for the same state
+func (q *QuorumCaller) pin(ctx context.Context, blockNumber *big.Int) (*big.Int, error) {
+	if blockNumber != nil {
+		return blockNumber, nil
+	}
+	n, err := q.c.QuorumBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(n), nil
+}
+
+func (q *QuorumCaller) agree(ctx context.Context, fn func(Backend) ([]byte, error)) ([]byte, error) {
+	results := q.c.fanOut(ctx, func(b Backend) (interface{}, error) {
+		return fn(b)
+	})
+
+	votes := make(map[string]int)
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		key := string(r.value.([]byte))
+		votes[key]++
+		if votes[key] >= q.c.quorum {
+			return r.value.([]byte), nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %d distinct answers, errors: %w", ErrNoQuorum, len(votes), errors.Join(errs...))
+	}
+	return nil, fmt.Errorf("%w: %d distinct answers", ErrNoQuorum, len(votes))
+}
+
+type fanOutResult struct {
+	value interface{}
+	err   error
+}
+
+// fanOut runs fn on every healthy endpoint concurrently
+func (c *Client) fanOut(ctx context.Context, fn func(Backend) (interface{}, error)) []fanOutResult {
+	eps := c.healthy()
+	results := make([]fanOutResult, len(eps))
+
+	var wg sync.WaitGroup
+	for i, ep := range eps {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			value, err := fn(ep.backend)
+			if err == nil || isTransportError(err) {
+				ep.record(err, time.Since(start))
+			}
+			if err != nil {
+				err = fmt.Errorf("%s: %w", ep.url, err)
+			}
+			results[i] = fanOutResult{value: value, err: err}
+		}(i, ep)
+	}
+	wg.Wait()
+
+	return results
+}
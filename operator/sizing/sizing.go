@@ -0,0 +1,127 @@
+// Package sizing chooses how large a deposit to send with a bid. The hook
+// refunds whatever rent a tenure did not use, so a larger deposit costs
+// only the return the capital could have earned elsewhere, while one that
+// runs out evicts us for depletion.
+package sizing
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"auction-pool/operator/rivals"
+	"auction-pool/operator/units"
+)
+
+// ErrOverBudget is returned when even the minimum deposit exceeds the risk
+// budget
+var ErrOverBudget = errors.New("minimum deposit exceeds risk budget")
+
+const year = 365 * 24 * time.Hour
+
+// Params configures deposit sizing
+type Params struct {
+	// TargetBlocks is the tenure we want the deposit to pay for
+	TargetBlocks uint64
+	// CapitalCostBps is the annual return the deposit could earn elsewhere
+	CapitalCostBps uint64
+	// BlockTime converts blocks to time for the capital cost
+	BlockTime time.Duration
+	// RiskBudget caps the deposit in wei; nil for no cap
+	RiskBudget *big.Int
+}
+
+// Sizing is a chosen deposit and why
+type Sizing struct {
+	Blocks  uint64
+	Deposit *big.Int
+	// Reasons lists each rule that moved the deposit, in order
+	Reasons []string
+}
+
+func (s Sizing) String() string {
+	return fmt.Sprintf("%d blocks (%s)", s.Blocks, strings.Join(s.Reasons, "; "))
+}
+
+// RivalInterval is how many blocks we expect to pass before some rival
+// bids: the rivals' combined rate, from the interval at which each has
+// bid. Zero when no rival has bid more than once.
+func RivalInterval(profiles []*rivals.Profile) uint64 {
+	// Sum rates in bids per million blocks to stay in integers
+	const scale = 1_000_000
+	var rate uint64
+	for _, p := range profiles {
+		if p.Interval > 0 {
+			rate += scale / p.Interval
+		}
+	}
+	if rate == 0 {
+		return 0
+	}
+	return scale / rate
+}
+
+// Deposit sizes the deposit for a bid at rent, expecting profit per block
+// while manager. interval is the expected number of blocks until a rival
+// bids, zero if unknown.
+func Deposit(p Params, rent, profit *big.Int, interval uint64) (Sizing, error) {
+	if rent.Sign() <= 0 {
+		return Sizing{}, fmt.Errorf("rent must be positive, got %s", rent)
+	}
+
+	blocks := p.TargetBlocks
+	reasons := []string{fmt.Sprintf("target %d", blocks)}
+
+	// Rent beyond the next rival's activation is refunded unused
+	if interval > 0 {
+		if horizon := interval + rivals.ActivationDelay; horizon < blocks {
+			blocks = horizon
+			reasons = append(reasons, fmt.Sprintf("rivals bid every ~%d blocks, cut to %d", interval, blocks))
+		}
+	}
+
+	// Stop where holding the deposit costs more per block than managing
+	// earns: blocks * rent * costPerBlock <= profit - rent
+	if p.CapitalCostBps > 0 && p.BlockTime > 0 {
+		margin := new(big.Int).Sub(profit, rent)
+		if margin.Sign() <= 0 {
+			reasons = append(reasons, "no margin over rent to pay for capital")
+		} else {
+			// costPerBlock = bps/1e4 * blockTime/year
+			num := new(big.Int).Mul(margin, big.NewInt(10_000))
+			num.Mul(num, big.NewInt(int64(year/time.Second)))
+			den := new(big.Int).Mul(rent, new(big.Int).SetUint64(p.CapitalCostBps))
+			den.Mul(den, big.NewInt(int64(p.BlockTime/time.Second)))
+			if den.Sign() > 0 {
+				if limit := new(big.Int).Div(num, den); limit.IsUint64() && limit.Uint64() < blocks {
+					blocks = limit.Uint64()
+					reasons = append(reasons, fmt.Sprintf("capital cost %d bps caps at %d", p.CapitalCostBps, blocks))
+				}
+			}
+		}
+	}
+
+	if blocks < rivals.MinDepositBlocks {
+		blocks = rivals.MinDepositBlocks
+		reasons = append(reasons, fmt.Sprintf("raised to MIN_DEPOSIT_BLOCKS %d", blocks))
+	}
+
+	if p.RiskBudget != nil {
+		affordable := new(big.Int).Div(p.RiskBudget, rent)
+		if affordable.Cmp(big.NewInt(rivals.MinDepositBlocks)) < 0 {
+			return Sizing{}, fmt.Errorf("%w: %d blocks at %s/block against %s", ErrOverBudget, rivals.MinDepositBlocks, units.FormatWei(rent), units.FormatWei(p.RiskBudget))
+		}
+		if affordable.Uint64() < blocks {
+			blocks = affordable.Uint64()
+			reasons = append(reasons, fmt.Sprintf("risk budget %s caps at %d", units.FormatWei(p.RiskBudget), blocks))
+		}
+	}
+
+	return Sizing{
+		Blocks:  blocks,
+		Deposit: new(big.Int).Mul(rent, new(big.Int).SetUint64(blocks)),
+		Reasons: reasons,
+	}, nil
+}
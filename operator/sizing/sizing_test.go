@@ -0,0 +1,69 @@
+package sizing
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"auction-pool/operator/rivals"
+)
+
+func TestDeposit(t *testing.T) {
+	base := Params{TargetBlocks: 600, CapitalCostBps: 500, BlockTime: 12 * time.Second}
+	budget := func(wei int64) Params {
+		p := base
+		p.RiskBudget = big.NewInt(wei)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		params   Params
+		rent     int64
+		profit   int64
+		interval uint64
+		blocks   uint64
+		reasons  int
+	}{
+		{"target", base, 1000, 2000, 0, 600, 1},
+		{"rivals rebid sooner", base, 1000, 2000, 200, 205, 2},
+		{"rivals rebid later", base, 1000, 2000, 1000, 600, 1},
+		// 5% a year on 12s blocks: a margin of 5 wei pays for 262 blocks of
+		// deposit at 1e6 wei/block
+		{"capital cost", base, 1_000_000, 1_000_005, 0, 262, 2},
+		{"capital cost below the minimum", base, 1_000_000, 1_000_001, 0, 100, 3},
+		{"no margin", base, 1000, 900, 0, 600, 2},
+		{"risk budget", budget(300_000), 1000, 2000, 0, 300, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Deposit(tt.params, big.NewInt(tt.rent), big.NewInt(tt.profit), tt.interval)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Blocks != tt.blocks || s.Deposit.Cmp(big.NewInt(tt.rent*int64(tt.blocks))) != 0 {
+				t.Errorf("Deposit() = %s, want %d blocks", s, tt.blocks)
+			}
+			if len(s.Reasons) != tt.reasons {
+				t.Errorf("Deposit() reasons = %q, want %d", s.Reasons, tt.reasons)
+			}
+		})
+	}
+
+	if _, err := Deposit(budget(99_000), big.NewInt(1000), big.NewInt(2000), 0); !errors.Is(err, ErrOverBudget) {
+		t.Errorf("Deposit() over budget = %v, want ErrOverBudget", err)
+	}
+}
+
+func TestRivalInterval(t *testing.T) {
+	profiles := []*rivals.Profile{{Interval: 100}, {Interval: 300}, {Interval: 0}}
+	// 1/100 + 1/300 bids per block is one every 75 blocks
+	if got := RivalInterval(profiles); got != 75 {
+		t.Errorf("RivalInterval() = %d, want 75", got)
+	}
+	if got := RivalInterval(nil); got != 0 {
+		t.Errorf("RivalInterval(nil) = %d, want 0", got)
+	}
+}
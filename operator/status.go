@@ -0,0 +1,517 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"auction-pool/operator/history"
+	"auction-pool/operator/ledger"
+	"auction-pool/operator/reorg"
+	"auction-pool/operator/tui"
+	"auction-pool/operator/txmgr"
+	"auction-pool/operator/units"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// What the status view lists
+const (
+	statusEventBlocks = 100 // blocks of hook events read
+	statusEvents      = 8   // events shown, newest first
+	statusLogLines    = 6   // log lines shown
+)
+
+// poolStatus is what the status view shows for one pool
+type poolStatus struct {
+	poolId  common.Hash
+	at      time.Time
+	head    uint64
+	view    *chainView
+	events  []history.Event
+	pending []*txmgr.PendingTx
+	pnl     *ledger.PnL
+	err     error
+}
+
+// poolStatuses reads the status view's pools: ours, then every other pool
+// the ledger has entries for. Like every request it runs on the loop
+// goroutine.
+func (op *Operator) poolStatuses(ctx context.Context) []*poolStatus {
+	rows, err := ledger.ByPool(op.store.Accounting())
+	pnl := make(map[common.Hash]*ledger.PnL, len(rows))
+	for i := range rows {
+		pnl[rows[i].PoolId] = &rows[i]
+	}
+
+	ids := []common.Hash{common.Hash(op.poolId)}
+	for _, row := range rows {
+		if row.PoolId != ids[0] {
+			ids = append(ids, row.PoolId)
+		}
+	}
+
+	anchor, headErr := op.headAnchor(ctx)
+	pending := op.tracker.List()
+	pools := make([]*poolStatus, len(ids))
+	for i, id := range ids {
+		s := &poolStatus{poolId: id, at: time.Now(), pnl: pnl[id], err: err}
+		for _, ptx := range pending {
+			if ptx.PoolId == id {
+				s.pending = append(s.pending, ptx)
+			}
+		}
+		if headErr != nil {
+			s.err = fmt.Errorf("failed to get head block: %w", headErr)
+		} else {
+			op.readPoolStatus(ctx, s, anchor)
+		}
+		pools[i] = s
+	}
+	return pools
+}
+
+// readPoolStatus fills in a pool's state and recent events as of anchor
+func (op *Operator) readPoolStatus(ctx context.Context, s *poolStatus, anchor reorg.Anchor) {
+	var err error
+	s.head = anchor.Number
+	if s.view, err = op.readPoolView(ctx, anchor, s.poolId); err != nil {
+		s.err = err
+		return
+	}
+
+	var from uint64
+	if anchor.Number > statusEventBlocks {
+		from = anchor.Number - statusEventBlocks
+	}
+	if s.events, err = history.Fetch(ctx, &op.hook.AuctionPoolHookFilterer, s.poolId, from, anchor.Number, statusEventBlocks+1); err != nil {
+		s.err = err
+	}
+}
+
+// runway is how many more blocks the manager's deposit pays rent for after
+// what is owed at head
+func runway(view *chainView, head uint64) uint64 {
+	if view.rentPerBlock.Sign() == 0 {
+		return 0
+	}
+	var elapsed uint64
+	if head > view.lastRentBlock {
+		elapsed = head - view.lastRentBlock
+	}
+	left := new(big.Int).Mul(view.rentPerBlock, new(big.Int).SetUint64(elapsed))
+	left.Sub(view.managerDeposit, left)
+	if left.Sign() <= 0 {
+		return 0
+	}
+	return left.Div(left, view.rentPerBlock).Uint64()
+}
+
+// logTail keeps the last lines written to the log for the status view,
+// shortened to fit
+type logTail struct {
+	mu    sync.Mutex
+	max   int
+	lines []string
+}
+
+func (t *logTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		t.lines = append(t.lines, tailLine(line))
+	}
+	if len(t.lines) > t.max {
+		t.lines = append([]string(nil), t.lines[len(t.lines)-t.max:]...)
+	}
+	return len(p), nil
+}
+
+func (t *logTail) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.lines...)
+}
+
+// tailLine lays a JSON log line out as its time, level, message and
+// fields. The pool, caller and trace IDs are left to the log file.
+func tailLine(line string) string {
+	var fields map[string]any
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return line
+	}
+
+	var b strings.Builder
+	if n, ok := fields["ts"].(json.Number); ok {
+		if ts, err := n.Float64(); err == nil {
+			sec, frac := math.Modf(ts)
+			b.WriteString(time.Unix(int64(sec), int64(frac*1e9)).Format("15:04:05 "))
+		}
+	}
+	if level, _ := fields["level"].(string); level != "" && level != "info" {
+		b.WriteString(strings.ToUpper(level) + " ")
+	}
+	msg, _ := fields["msg"].(string)
+	b.WriteString(msg)
+
+	var keys []string
+	for k := range fields {
+		switch k {
+		case "ts", "level", "msg", "caller", "stacktrace", "pool_id", "correlation_id", "decision_id":
+		default:
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	return b.String()
+}
+
+// status runs the operator loop behind a live view of its pools. The log
+// goes to LOG_FILE instead of the terminal.
+func status(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(getEnvOrDefault("LOG_FILE", "operator.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer logFile.Close()
+
+	// Startup failures still reach the terminal
+	prev := logOutput.Set(io.MultiWriter(os.Stderr, logFile))
+	defer logOutput.Set(prev)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	op := newOperator(ctx)
+	defer op.client.Close()
+
+	term, err := tui.Open(os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	tail := &logTail{max: statusLogLines}
+	logOutput.Set(io.MultiWriter(logFile, tail))
+
+	done := make(chan struct{})
+	go func() {
+		op.run(ctx)
+		close(done)
+	}()
+
+	v := &statusView{op: op, term: term, logs: tail, updates: make(chan func())}
+	v.run(ctx)
+
+	// Let the loop persist in-flight transactions before exiting
+	stop()
+	<-done
+	return term.Close()
+}
+
+// statusView is the state of the status screen. Its fields belong to the
+// goroutine running it; the operator is only reached through op.do.
+type statusView struct {
+	op   *Operator
+	term *tui.Terminal
+	logs *logTail
+
+	pools      []*poolStatus
+	paused     bool
+	standby    bool
+	refreshing bool
+
+	// prompt, when set, takes keystrokes and hands the line to submit
+	prompt  *tui.Prompt
+	submit  func(ctx context.Context, line string)
+	message string
+
+	updates chan func()
+}
+
+func (v *statusView) run(ctx context.Context) {
+	keys := v.term.Keys()
+	redraw := time.NewTicker(time.Second)
+	defer redraw.Stop()
+	refresh := time.NewTicker(v.op.tickInterval)
+	defer refresh.Stop()
+
+	v.refresh(ctx)
+	for {
+		if err := v.term.Draw(v.lines(time.Now())); err != nil {
+			v.op.logger.Error("Failed to draw status view", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-redraw.C:
+		case <-refresh.C:
+			v.refresh(ctx)
+		case update := <-v.updates:
+			update()
+		case k, ok := <-keys:
+			if !ok || v.key(ctx, k) {
+				return
+			}
+		}
+	}
+}
+
+// act runs fn on the operator loop and applies the view update it returns
+// once done, so keys and redraws carry on while a tick or tx is in flight
+func (v *statusView) act(ctx context.Context, fn func(ctx context.Context) func()) {
+	go func() {
+		var update func()
+		if err := v.op.do(ctx, func(ctx context.Context) { update = fn(ctx) }); err != nil {
+			return
+		}
+		select {
+		case v.updates <- update:
+		case <-ctx.Done():
+		}
+	}()
+}
+
+func (v *statusView) refresh(ctx context.Context) {
+	if v.refreshing {
+		return
+	}
+	v.refreshing = true
+	v.act(ctx, func(ctx context.Context) func() {
+		pools, paused := v.op.poolStatuses(ctx), v.op.paused
+		standby := v.op.lease != nil && !v.op.lease.Held()
+		return func() {
+			v.pools, v.paused, v.standby, v.refreshing = pools, paused, standby, false
+		}
+	})
+}
+
+// key handles a keystroke and reports whether to quit
+func (v *statusView) key(ctx context.Context, k tui.Key) bool {
+	if v.prompt != nil {
+		line, done, cancelled := v.prompt.Handle(k)
+		switch {
+		case cancelled:
+			v.prompt, v.message = nil, ""
+		case done:
+			v.prompt = nil
+			v.submit(ctx, line)
+		}
+		return false
+	}
+
+	switch k {
+	case 'q':
+		return true
+	case 'r':
+		v.refresh(ctx)
+	case 'p':
+		v.act(ctx, func(context.Context) func() {
+			v.op.paused = !v.op.paused
+			paused := v.op.paused
+			if paused {
+				v.op.log().Info("Strategy paused from the status view")
+			} else {
+				v.op.log().Info("Strategy resumed from the status view")
+			}
+			return func() { v.paused = paused }
+		})
+	case 'b':
+		v.prompt = &tui.Prompt{Label: "Rent (e.g. 0.0001eth/block, 0.5eth/day): "}
+		v.submit = v.bid
+	case 'f':
+		v.prompt = &tui.Prompt{Label: "Fee (e.g. 0.3%, 30bps, 3000pips): "}
+		v.submit = v.setFee
+	}
+	return false
+}
+
+func (v *statusView) bid(ctx context.Context, line string) {
+	rent, err := v.op.rent.Parse(line)
+	if err != nil {
+		v.message = err.Error()
+		return
+	}
+	v.message = fmt.Sprintf("Bidding %s...", v.op.rent.Format(rent))
+	v.act(ctx, func(ctx context.Context) func() {
+		msg := fmt.Sprintf("Bid of %s submitted", v.op.rent.Format(rent))
+		if err := v.op.manualBid(ctx, rent); err != nil {
+			msg = "Bid failed: " + err.Error()
+		}
+		return func() { v.message = msg; v.refresh(ctx) }
+	})
+}
+
+func (v *statusView) setFee(ctx context.Context, line string) {
+	fee, err := units.ParseFee(line)
+	if err != nil {
+		v.message = err.Error()
+		return
+	}
+	v.message = fmt.Sprintf("Setting fee to %s...", units.FormatFee(fee))
+	v.act(ctx, func(ctx context.Context) func() {
+		msg := fmt.Sprintf("Fee update to %s submitted", units.FormatFee(fee))
+		if err := v.op.manualFee(ctx, fee); err != nil {
+			msg = "Fee update failed: " + err.Error()
+		} else if !v.op.paused {
+			msg += "; the strategy may change it back unless paused"
+		}
+		return func() { v.message = msg; v.refresh(ctx) }
+	})
+}
+
+// lines renders the screen at now
+func (v *statusView) lines(now time.Time) []string {
+	state := "strategy running"
+	if v.paused {
+		state = "STRATEGY PAUSED"
+	} else if v.standby {
+		state = "STANDBY (another operator leads)"
+	}
+	out := []string{
+		fmt.Sprintf("AuctionPool operator %s   hook %s   %s   %s",
+			truncateAddress(v.op.address.Hex()), truncateAddress(v.op.hookAddress.Hex()), state, now.Format("15:04:05")),
+		"",
+	}
+
+	if v.pools == nil {
+		out = append(out, "Reading pool state...")
+	}
+	for _, s := range v.pools {
+		out = append(out, v.poolLines(s, now)...)
+		out = append(out, "")
+	}
+
+	out = append(out, "Log")
+	for _, line := range v.logs.Lines() {
+		out = append(out, "  "+line)
+	}
+	out = append(out, "")
+
+	out = append(out, "[p] pause/resume  [b] bid  [f] set fee  [r] refresh  [q] quit")
+	if v.prompt != nil {
+		out = append(out, v.prompt.String())
+	} else if v.message != "" {
+		out = append(out, v.message)
+	}
+	return out
+}
+
+func (v *statusView) poolLines(s *poolStatus, now time.Time) []string {
+	out := []string{fmt.Sprintf("Pool %s   block %d, read %s ago",
+		truncateAddress(s.poolId.Hex()), s.head, now.Sub(s.at).Round(time.Second))}
+	if s.err != nil {
+		out = append(out, "  ⚠️  "+s.err.Error())
+	}
+
+	// eta estimates how long until a block, counting time since the read
+	eta := func(block uint64) string {
+		if block <= s.head {
+			return "now"
+		}
+		d := time.Duration(block-s.head)*v.op.rent.BlockTime - now.Sub(s.at)
+		if d < 0 {
+			d = 0
+		}
+		return fmt.Sprintf("in %d block(s), ~%s", block-s.head, d.Round(time.Second))
+	}
+
+	if view := s.view; view != nil {
+		if view.manager == (common.Address{}) {
+			out = append(out, "  Manager    none")
+		} else {
+			out = append(out,
+				"  Manager    "+v.who(view.manager),
+				"  Rent       "+v.op.rent.Format(view.rentPerBlock),
+				"  Fee        "+units.FormatFee(view.currentFee.Uint64()))
+			left := runway(view, s.head)
+			out = append(out, fmt.Sprintf("  Deposit    %s, rent collected to block %d, runs out %s",
+				units.FormatWei(view.managerDeposit), view.lastRentBlock, eta(s.head+left)))
+		}
+
+		if view.nextBidder == (common.Address{}) {
+			out = append(out, "  Next bid   none")
+		} else {
+			out = append(out, fmt.Sprintf("  Next bid   %s at %s, deposit %s, activates at block %s %s",
+				v.who(view.nextBidder), v.op.rent.Format(view.nextRent), units.FormatWei(view.nextDeposit),
+				view.activationBlock, eta(view.activationBlock.Uint64())))
+		}
+	}
+
+	if len(s.pending) == 0 {
+		out = append(out, "  In flight  none")
+	}
+	for i, ptx := range s.pending {
+		label := "  In flight  "
+		if i > 0 {
+			label = "             "
+		}
+		out = append(out, fmt.Sprintf("%s%s %s nonce %d, sent %s ago",
+			label, ptx.Kind, truncateAddress(ptx.Hash.Hex()), ptx.Nonce, now.Sub(ptx.SentAt).Round(time.Second)))
+	}
+
+	if p := s.pnl; p != nil {
+		out = append(out, fmt.Sprintf("  P&L        net %s: rent %s, gas %s, fees %s, swaps %s, LP rent %s, deposit held %s",
+			units.FormatWei(p.Net), units.FormatWei(p.RentPaid), units.FormatWei(p.Gas), units.FormatWei(p.FeesEarned),
+			units.FormatWei(p.SwapProfit), units.FormatWei(p.RentClaimed), units.FormatWei(p.DepositHeld)))
+	} else {
+		out = append(out, "  P&L        nothing booked")
+	}
+
+	out = append(out, fmt.Sprintf("  Events in the last %d blocks", statusEventBlocks))
+	if len(s.events) == 0 {
+		out = append(out, "    none")
+	}
+	for i := len(s.events) - 1; i >= 0 && i >= len(s.events)-statusEvents; i-- {
+		out = append(out, fmt.Sprintf("    %d  %s", s.events[i].Block, v.describe(s.events[i])))
+	}
+	return out
+}
+
+// who writes an address, marking our own
+func (v *statusView) who(addr common.Address) string {
+	if addr == v.op.address {
+		return truncateAddress(addr.Hex()) + " (us)"
+	}
+	return truncateAddress(addr.Hex())
+}
+
+func (v *statusView) describe(ev history.Event) string {
+	switch {
+	case ev.BidSubmitted != nil:
+		return fmt.Sprintf("BidSubmitted    %s at %s, deposit %s",
+			v.who(ev.BidSubmitted.Bidder), v.op.rent.Format(ev.BidSubmitted.RentPerBlock), units.FormatWei(ev.BidSubmitted.Deposit))
+	case ev.ManagerChanged != nil:
+		return fmt.Sprintf("ManagerChanged  %s -> %s at %s",
+			v.who(ev.ManagerChanged.OldManager), v.who(ev.ManagerChanged.NewManager), v.op.rent.Format(ev.ManagerChanged.RentPerBlock))
+	case ev.RentCollected != nil:
+		return "RentCollected   " + units.FormatWei(ev.RentCollected)
+	case ev.FeeUpdated != nil:
+		return "FeeUpdated      " + units.FormatFee(ev.FeeUpdated.Fee)
+	case ev.WithdrawalFee != nil:
+		return "WithdrawalFee   " + units.FormatWei(ev.WithdrawalFee)
+	}
+	return "unknown event"
+}
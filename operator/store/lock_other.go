@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package store
+
+import "os"
+
+// tryLock has no lock to take on this platform; keep to one process per
+// state file by hand
+func tryLock(*os.File) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1,566 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// schemaVersion is bumped whenever the on-disk layout changes incompatibly
+const schemaVersion = 1
+
+// maxDecisions bounds the decision history kept on disk
+const maxDecisions = 1000
+
+// Transaction kinds recorded by the operator
+const (
+	KindSubmitBid     = "submitBid"
+	KindSetSwapFee    = "setSwapFee"
+	KindClaimRent     = "claimRent"
+	KindWithdrawFees  = "withdrawManagerFees"
+	KindTreasuryTopUp = "treasuryTopUp"
+	KindTreasurySweep = "treasurySweep"
+)
+
+// Bid lifecycle states, mirroring where the bid sits in the hook
+const (
+	BidSent     = "sent"     // broadcast, not yet mined
+	BidQueued   = "queued"   // stored in nextBid, waiting for activation
+	BidActive   = "active"   // we are the current manager
+	BidOutbid   = "outbid"   // displaced from nextBid and refunded
+	BidEnded    = "ended"    // our tenure ended, leftover deposit refunded
+	BidReverted = "reverted" // the submitBid transaction failed
+)
+
+// Accounting entry kinds; package ledger books each as a balanced pair of
+// postings
+const (
+	EntryDepositLocked   = "deposit_locked"   // submitBid value held by the hook
+	EntryDepositRefunded = "deposit_refunded" // _refundBid, or the leftover deposit on handover
+	EntryRentPaid        = "rent_paid"        // rent taken from our deposit while manager
+	EntryFeesWithdrawn   = "fees_withdrawn"   // withdrawal fees earned, paid out by withdrawManagerFees
+	EntryGas             = "gas"              // gas spent on our transactions
+	EntrySwapProfit      = "swap_profit"      // profit of swaps made at the manager's zero fee
+	EntryRentClaimed     = "rent_claimed"     // rent paid out to us as an LP by claimRent
+	EntryTreasuryTopUp   = "treasury_topup"   // treasury funding the hot wallet ahead of a bid
+	EntryTreasurySweep   = "treasury_sweep"   // hot wallet excess sent back to the treasury
+	EntryTreasuryGas     = "treasury_gas"     // gas the treasury spent on top-ups
+)
+
+// PendingTx is a transaction that has been broadcast but whose receipt
+// has not been observed yet
+type PendingTx struct {
+	Hash   common.Hash `json:"hash"`
+	Kind   string      `json:"kind"`
+	PoolId common.Hash `json:"poolId"`
+	Nonce  uint64      `json:"nonce"`
+	Value  *big.Int    `json:"value"`
+	Rent   *big.Int    `json:"rent,omitempty"` // submitBid only
+	Fee    uint32      `json:"fee,omitempty"`  // setSwapFee only
+	SentAt time.Time   `json:"sentAt"`
+
+	// Block the decision behind this tx was based on
+	AnchorBlock uint64      `json:"anchorBlock,omitempty"`
+	AnchorHash  common.Hash `json:"anchorHash,omitempty"`
+
+	// From is the sender when it isn't the operator, as for top-ups
+	From common.Address `json:"from,omitempty"`
+
+	// Replaces is our queued bid a submitBid displaces from nextBid, and
+	// ReplacedDeposit the deposit refunded for it once this tx is mined
+	Replaces        common.Hash `json:"replaces,omitempty"`
+	ReplacedDeposit *big.Int    `json:"replacedDeposit,omitempty"`
+
+	// Log trace the tx was sent under, so its receipt logs alongside it
+	Correlation string `json:"correlation,omitempty"`
+	Decision    string `json:"decision,omitempty"`
+}
+
+func (tx *PendingTx) clone() *PendingTx {
+	c := *tx
+	c.Value = cloneInt(tx.Value)
+	c.Rent = cloneInt(tx.Rent)
+	c.ReplacedDeposit = cloneInt(tx.ReplacedDeposit)
+	return &c
+}
+
+// ActiveBid is our most recent bid for a pool and where it stands
+type ActiveBid struct {
+	PoolId          common.Hash `json:"poolId"`
+	TxHash          common.Hash `json:"txHash"`
+	RentPerBlock    *big.Int    `json:"rentPerBlock"`
+	Deposit         *big.Int    `json:"deposit"`
+	ActivationBlock uint64      `json:"activationBlock,omitempty"`
+	Status          string      `json:"status"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+}
+
+func (b *ActiveBid) clone() *ActiveBid {
+	if b == nil {
+		return nil
+	}
+	c := *b
+	c.RentPerBlock = cloneInt(b.RentPerBlock)
+	c.Deposit = cloneInt(b.Deposit)
+	return &c
+}
+
+// Locked reports whether the bid's deposit is still held by the hook
+func (b *ActiveBid) Locked() bool {
+	return b.Status == BidSent || b.Status == BidQueued || b.Status == BidActive
+}
+
+// FeeSetting is the last swap fee we set for a pool
+type FeeSetting struct {
+	PoolId common.Hash `json:"poolId"`
+	Fee    uint32      `json:"fee"`
+	TxHash common.Hash `json:"txHash"`
+	SetAt  time.Time   `json:"setAt"`
+}
+
+// Decision is one strategy outcome, kept for auditing
+type Decision struct {
+	// Id and Correlation find the decision in the log
+	Id          string `json:"id,omitempty"`
+	Correlation string `json:"correlation,omitempty"`
+
+	Time   time.Time   `json:"time"`
+	PoolId common.Hash `json:"poolId"`
+	Block  uint64      `json:"block"`
+	Hash   common.Hash `json:"hash"`
+	Action string      `json:"action"`
+	Reason string      `json:"reason,omitempty"`
+	Rent   *big.Int    `json:"rent,omitempty"`
+	Fee    uint32      `json:"fee,omitempty"`
+}
+
+// AccountingEntry records value moving between us and the hook
+type AccountingEntry struct {
+	Time   time.Time   `json:"time"`
+	PoolId common.Hash `json:"poolId"`
+	Kind   string      `json:"kind"`
+	Amount *big.Int    `json:"amount"`
+	TxHash common.Hash `json:"txHash,omitempty"`
+	Block  uint64      `json:"block,omitempty"`
+	// LogIndex is the hook event the entry was booked from, if any
+	LogIndex *uint `json:"logIndex,omitempty"`
+	// Tenure is the submitBid transaction of the bid the entry belongs to
+	Tenure common.Hash `json:"tenure,omitempty"`
+}
+
+// Tenure is a period in which one of our bids made us manager
+type Tenure struct {
+	// Id is the submitBid transaction of the winning bid
+	Id           common.Hash `json:"id"`
+	PoolId       common.Hash `json:"poolId"`
+	RentPerBlock *big.Int    `json:"rentPerBlock"`
+	StartBlock   uint64      `json:"startBlock"`
+	// LastRentBlock is where rent was last taken from the deposit
+	LastRentBlock uint64 `json:"lastRentBlock"`
+	// EndBlock is zero while the tenure lasts
+	EndBlock uint64 `json:"endBlock,omitempty"`
+}
+
+func (t *Tenure) clone() *Tenure {
+	c := *t
+	c.RentPerBlock = cloneInt(t.RentPerBlock)
+	return &c
+}
+
+func cloneInt(x *big.Int) *big.Int {
+	if x == nil {
+		return nil
+	}
+	return new(big.Int).Set(x)
+}
+
+type snapshot struct {
+	Version    int                         `json:"version"`
+	PendingTxs map[common.Hash]*PendingTx  `json:"pendingTxs"`
+	ActiveBids map[common.Hash]*ActiveBid  `json:"activeBids"`
+	Fees       map[common.Hash]*FeeSetting `json:"fees"`
+	Decisions  []*Decision                 `json:"decisions"`
+	Accounting []*AccountingEntry          `json:"accounting"`
+	Tenures    map[common.Hash]*Tenure     `json:"tenures,omitempty"`
+
+	// LedgerBlock is the last block whose hook events were booked
+	LedgerBlock uint64 `json:"ledgerBlock,omitempty"`
+}
+
+// ErrLocked is returned by Open while another process has the store open
+var ErrLocked = errors.New("state file is in use by another process")
+
+// ErrBooked is returned by AppendAccounting for a hook event's entry that
+// is already on the books, as when a scan is repeated after a crash
+var ErrBooked = errors.New("entry already booked")
+
+// errReadOnly refuses writes through a store opened with Load
+var errReadOnly = errors.New("state store is read-only")
+
+// Store is a small crash-safe state store persisted as a single JSON file.
+// Every mutation is written through to disk with an atomic rename, so the
+// operator can be killed at any point without losing or tearing state.
+// Each write replaces the whole file from memory, so Open locks the store
+// to one process; a second writer would silently undo the first's updates.
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	data     *snapshot
+	lock     *os.File
+	readOnly bool
+}
+
+// Open loads the store at path, creating an empty one if it does not exist.
+// It holds an exclusive lock on path+".lock" until Close or exit, and
+// returns ErrLocked while another process holds it.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state lock: %w", err)
+	}
+	ok, err := tryLock(lock)
+	if err != nil || !ok {
+		holder, _ := os.ReadFile(lock.Name())
+		lock.Close()
+		if err == nil {
+			err = fmt.Errorf("%w: %s held by pid %s", ErrLocked, lock.Name(), strings.TrimSpace(string(holder)))
+		}
+		return nil, err
+	}
+	// Name the holder for whoever finds the store locked
+	if err := lock.Truncate(0); err == nil {
+		lock.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+
+	s, err := load(path)
+	if err != nil {
+		lock.Close()
+		return nil, err
+	}
+	s.lock = lock
+	return s, nil
+}
+
+// Load reads the store at path without locking it, for commands that only
+// read while the operator runs. Writes through it fail.
+func Load(path string) (*Store, error) {
+	s, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	s.readOnly = true
+	return s, nil
+}
+
+func load(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		data: &snapshot{
+			Version:    schemaVersion,
+			PendingTxs: make(map[common.Hash]*PendingTx),
+			ActiveBids: make(map[common.Hash]*ActiveBid),
+			Fees:       make(map[common.Hash]*FeeSetting),
+			Tenures:    make(map[common.Hash]*Tenure),
+		},
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, s.data); err != nil {
+		return nil, fmt.Errorf("failed to decode state file: %w", err)
+	}
+	if s.data.Version != schemaVersion {
+		return nil, fmt.Errorf("unsupported state file version %d (want %d)", s.data.Version, schemaVersion)
+	}
+	if s.data.Tenures == nil {
+		s.data.Tenures = make(map[common.Hash]*Tenure)
+	}
+
+	return s, nil
+}
+
+// Close releases the store's lock; it must not be written to afterwards
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lock == nil {
+		return nil
+	}
+	// Closing the file drops the flock
+	err := s.lock.Close()
+	s.lock, s.readOnly = nil, true
+	return err
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	return s.path
+}
+
+// update applies fn under the lock and writes the result through to disk
+func (s *Store) update(fn func(d *snapshot)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.readOnly {
+		return errReadOnly
+	}
+	fn(s.data)
+	return s.flush()
+}
+
+// Flush writes the current state to disk
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flush()
+}
+
+func (s *Store) flush() error {
+	if s.readOnly {
+		return errReadOnly
+	}
+	raw, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	// fsync before rename so a crash never leaves a truncated file behind
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+
+	return nil
+}
+
+// ===== Pending transactions =====
+
+// PutPendingTx records a copy of an in-flight transaction
+func (s *Store) PutPendingTx(tx *PendingTx) error {
+	return s.update(func(d *snapshot) {
+		d.PendingTxs[tx.Hash] = tx.clone()
+	})
+}
+
+// DeletePendingTx drops a transaction once it is mined or replaced
+func (s *Store) DeletePendingTx(hash common.Hash) error {
+	return s.update(func(d *snapshot) {
+		delete(d.PendingTxs, hash)
+	})
+}
+
+// PendingTxs returns a copy of the in-flight transactions ordered by
+// nonce. Changes to them are saved with PutPendingTx.
+func (s *Store) PendingTxs() []*PendingTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	txs := make([]*PendingTx, 0, len(s.data.PendingTxs))
+	for _, tx := range s.data.PendingTxs {
+		txs = append(txs, tx.clone())
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+
+	return txs
+}
+
+// ===== Bids =====
+
+// PutActiveBid replaces our bid record for the pool with a copy of bid
+func (s *Store) PutActiveBid(bid *ActiveBid) error {
+	return s.update(func(d *snapshot) {
+		stored := bid.clone()
+		stored.UpdatedAt = time.Now()
+		d.ActiveBids[bid.PoolId] = stored
+	})
+}
+
+// ActiveBid returns a copy of our bid record for the pool, or nil. Changes
+// to it are saved with PutActiveBid.
+func (s *Store) ActiveBid(poolId common.Hash) *ActiveBid {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data.ActiveBids[poolId].clone()
+}
+
+// ActiveBids returns a copy of every bid record
+func (s *Store) ActiveBids() []*ActiveBid {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bids := make([]*ActiveBid, 0, len(s.data.ActiveBids))
+	for _, b := range s.data.ActiveBids {
+		bids = append(bids, b.clone())
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].PoolId.Hex() < bids[j].PoolId.Hex() })
+
+	return bids
+}
+
+// ===== Fees =====
+
+// PutFee records the fee we last set for a pool
+func (s *Store) PutFee(fee *FeeSetting) error {
+	return s.update(func(d *snapshot) {
+		stored := *fee
+		d.Fees[fee.PoolId] = &stored
+	})
+}
+
+// Fee returns the fee we last set for the pool, or nil
+func (s *Store) Fee(poolId common.Hash) *FeeSetting {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fee, ok := s.data.Fees[poolId]
+	if !ok {
+		return nil
+	}
+	c := *fee
+	return &c
+}
+
+// ===== History =====
+
+// AppendDecision records a strategy decision, keeping the newest maxDecisions
+func (s *Store) AppendDecision(dec *Decision) error {
+	return s.update(func(d *snapshot) {
+		d.Decisions = append(d.Decisions, dec)
+		if len(d.Decisions) > maxDecisions {
+			d.Decisions = d.Decisions[len(d.Decisions)-maxDecisions:]
+		}
+	})
+}
+
+// Decisions returns the recorded decisions, oldest first
+func (s *Store) Decisions() []*Decision {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Decision(nil), s.data.Decisions...)
+}
+
+// AppendAccounting records an accounting entry. An entry booked from a
+// hook event goes on the books once; a repeat returns ErrBooked.
+func (s *Store) AppendAccounting(entry *AccountingEntry) error {
+	booked := false
+	err := s.update(func(d *snapshot) {
+		if entry.LogIndex != nil {
+			for _, e := range d.Accounting {
+				if e.LogIndex != nil && *e.LogIndex == *entry.LogIndex && e.TxHash == entry.TxHash && e.Kind == entry.Kind {
+					booked = true
+					return
+				}
+			}
+		}
+		d.Accounting = append(d.Accounting, entry)
+	})
+	if booked {
+		return ErrBooked
+	}
+	return err
+}
+
+// Accounting returns every accounting entry, oldest first
+func (s *Store) Accounting() []*AccountingEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*AccountingEntry(nil), s.data.Accounting...)
+}
+
+// ===== Ledger =====
+
+// PutTenure records or updates a tenure with a copy of t
+func (s *Store) PutTenure(t *Tenure) error {
+	return s.update(func(d *snapshot) {
+		d.Tenures[t.Id] = t.clone()
+	})
+}
+
+// OpenTenure returns a copy of our ongoing tenure in the pool, or nil.
+// Changes to it are saved with PutTenure.
+func (s *Store) OpenTenure(poolId common.Hash) *Tenure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.data.Tenures {
+		if t.PoolId == poolId && t.EndBlock == 0 {
+			return t.clone()
+		}
+	}
+	return nil
+}
+
+// Tenures returns a copy of every tenure ordered by start block
+func (s *Store) Tenures() []*Tenure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenures := make([]*Tenure, 0, len(s.data.Tenures))
+	for _, t := range s.data.Tenures {
+		tenures = append(tenures, t.clone())
+	}
+	sort.Slice(tenures, func(i, j int) bool { return tenures[i].StartBlock < tenures[j].StartBlock })
+
+	return tenures
+}
+
+// SetLedgerBlock records the last block whose hook events were booked
+func (s *Store) SetLedgerBlock(block uint64) error {
+	return s.update(func(d *snapshot) {
+		d.LedgerBlock = block
+	})
+}
+
+// LedgerBlock returns the last block whose hook events were booked, or
+// zero before the first scan
+func (s *Store) LedgerBlock() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.data.LedgerBlock
+}
@@ -0,0 +1,238 @@
+package store
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "operator_state.json")
+	poolId := common.HexToHash("0x01")
+
+	st, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	if err := st.PutActiveBid(&ActiveBid{
+		PoolId:       poolId,
+		RentPerBlock: big.NewInt(1000),
+		Deposit:      big.NewInt(100000),
+		Status:       BidQueued,
+	}); err != nil {
+		t.Fatalf("PutActiveBid failed: %v", err)
+	}
+	if err := st.PutFee(&FeeSetting{PoolId: poolId, Fee: 3000}); err != nil {
+		t.Fatalf("PutFee failed: %v", err)
+	}
+	if err := st.AppendAccounting(&AccountingEntry{PoolId: poolId, Kind: EntryDepositLocked, Amount: big.NewInt(100000)}); err != nil {
+		t.Fatalf("AppendAccounting failed: %v", err)
+	}
+	for i := 0; i < maxDecisions+5; i++ {
+		if err := st.AppendDecision(&Decision{PoolId: poolId, Block: uint64(i), Action: "none"}); err != nil {
+			t.Fatalf("AppendDecision failed: %v", err)
+		}
+	}
+
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+
+	bid := reopened.ActiveBid(poolId)
+	if bid == nil || bid.Status != BidQueued || !bid.Locked() || bid.Deposit.Cmp(big.NewInt(100000)) != 0 {
+		t.Fatalf("unexpected bid after reopen: %+v", bid)
+	}
+	if fee := reopened.Fee(poolId); fee == nil || fee.Fee != 3000 {
+		t.Errorf("unexpected fee after reopen: %+v", fee)
+	}
+	if entries := reopened.Accounting(); len(entries) != 1 || entries[0].Kind != EntryDepositLocked {
+		t.Errorf("unexpected accounting after reopen: %+v", entries)
+	}
+
+	decisions := reopened.Decisions()
+	if len(decisions) != maxDecisions {
+		t.Fatalf("expected %d decisions, got %d", maxDecisions, len(decisions))
+	}
+	if decisions[0].Block != 5 {
+		t.Errorf("expected oldest decisions to be trimmed, first block is %d", decisions[0].Block)
+	}
+}
+
+func TestOpenRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operator_state.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Fatal("expected error for unsupported version")
+	}
+}
+
+func TestTenures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operator_state.json")
+	poolId := common.HexToHash("0x01")
+
+	st, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.OpenTenure(poolId) != nil || st.LedgerBlock() != 0 {
+		t.Fatal("expected an empty ledger")
+	}
+
+	ended := &Tenure{Id: common.HexToHash("0xb1"), PoolId: poolId, RentPerBlock: big.NewInt(10), StartBlock: 100, EndBlock: 200}
+	open := &Tenure{Id: common.HexToHash("0xb2"), PoolId: poolId, RentPerBlock: big.NewInt(20), StartBlock: 200}
+	for _, tenure := range []*Tenure{open, ended} {
+		if err := st.PutTenure(tenure); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.SetLedgerBlock(250); err != nil {
+		t.Fatal(err)
+	}
+
+	st.Close()
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reopened.OpenTenure(poolId); got == nil || got.Id != open.Id {
+		t.Errorf("OpenTenure() = %+v, want %s", got, open.Id.Hex())
+	}
+	if tenures := reopened.Tenures(); len(tenures) != 2 || tenures[0].Id != ended.Id {
+		t.Errorf("Tenures() = %+v, want oldest first", tenures)
+	}
+	if reopened.LedgerBlock() != 250 {
+		t.Errorf("LedgerBlock() = %d, want 250", reopened.LedgerBlock())
+	}
+}
+
+func TestOpenLocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operator_state.json")
+	poolId := common.HexToHash("0x01")
+
+	st, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.PutFee(&FeeSetting{PoolId: poolId, Fee: 3000}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path); !errors.Is(err, ErrLocked) {
+		t.Fatalf("second Open() error = %v, want ErrLocked", err)
+	}
+
+	// A reader sees the state but cannot write over the holder's
+	reader, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee := reader.Fee(poolId); fee == nil || fee.Fee != 3000 {
+		t.Errorf("Load() fee = %+v, want 3000", fee)
+	}
+	if err := reader.PutFee(&FeeSetting{PoolId: poolId, Fee: 500}); !errors.Is(err, errReadOnly) {
+		t.Errorf("write through Load() error = %v, want errReadOnly", err)
+	}
+
+	if err := st.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.PutFee(&FeeSetting{PoolId: poolId, Fee: 500}); err == nil {
+		t.Error("write after Close() succeeded")
+	}
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() after Close() failed: %v", err)
+	}
+	defer reopened.Close()
+	if fee := reopened.Fee(poolId); fee == nil || fee.Fee != 3000 {
+		t.Errorf("reopened fee = %+v, want 3000", fee)
+	}
+}
+
+func TestAppendAccountingOncePerEvent(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "operator_state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+
+	tx := common.HexToHash("0xaa")
+	index := uint(3)
+	event := func(kind string) *AccountingEntry {
+		i := index
+		return &AccountingEntry{Kind: kind, Amount: big.NewInt(10), TxHash: tx, LogIndex: &i}
+	}
+	if err := st.AppendAccounting(event(EntryRentPaid)); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.AppendAccounting(event(EntryRentPaid)); !errors.Is(err, ErrBooked) {
+		t.Errorf("rebooking an event: error = %v, want ErrBooked", err)
+	}
+	// One event books rent and a refund; entries without a log all count
+	if err := st.AppendAccounting(event(EntryDepositRefunded)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := st.AppendAccounting(&AccountingEntry{Kind: EntrySwapProfit, Amount: big.NewInt(5), TxHash: tx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(st.Accounting()); n != 4 {
+		t.Errorf("booked %d entries, want 4", n)
+	}
+}
+
+func TestGettersReturnCopies(t *testing.T) {
+	st, err := Open(filepath.Join(t.TempDir(), "operator_state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer st.Close()
+	poolId := common.HexToHash("0x01")
+
+	bid := &ActiveBid{PoolId: poolId, RentPerBlock: big.NewInt(10), Deposit: big.NewInt(1000), Status: BidQueued}
+	if err := st.PutActiveBid(bid); err != nil {
+		t.Fatal(err)
+	}
+	bid.Status = BidReverted
+	got := st.ActiveBid(poolId)
+	got.Status = BidActive
+	got.Deposit.SetInt64(1)
+	st.ActiveBids()[0].Status = BidEnded
+	if stored := st.ActiveBid(poolId); stored.Status != BidQueued || stored.Deposit.Int64() != 1000 {
+		t.Errorf("bid changed outside PutActiveBid: %+v", stored)
+	}
+
+	if err := st.PutTenure(&Tenure{Id: common.HexToHash("0xb1"), PoolId: poolId, RentPerBlock: big.NewInt(10), StartBlock: 100}); err != nil {
+		t.Fatal(err)
+	}
+	st.OpenTenure(poolId).EndBlock = 200
+	st.Tenures()[0].RentPerBlock.SetInt64(0)
+	if open := st.OpenTenure(poolId); open == nil || open.RentPerBlock.Int64() != 10 {
+		t.Errorf("tenure changed outside PutTenure: %+v", open)
+	}
+
+	tx := &PendingTx{Hash: common.HexToHash("0x7a"), Kind: KindSubmitBid, PoolId: poolId, Value: big.NewInt(1000), Rent: big.NewInt(10), AnchorBlock: 100}
+	if err := st.PutPendingTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	tx.Value.SetInt64(0)
+	listed := st.PendingTxs()[0]
+	listed.AnchorBlock = 200
+	listed.Rent.SetInt64(0)
+	if stored := st.PendingTxs()[0]; stored.AnchorBlock != 100 || stored.Value.Int64() != 1000 || stored.Rent.Int64() != 10 {
+		t.Errorf("pending tx changed outside PutPendingTx: %+v", stored)
+	}
+}
@@ -0,0 +1,189 @@
+// Package treasury keeps the operator's bidding key hot but thin. Capital
+// sits at a treasury address whose key signs through its own backend; the
+// hot wallet is topped up just before a bid needs funds, never above a
+// cap, and swept back once refunds and fee proceeds land in it.
+package treasury
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TransferGas is the gas of a plain ETH transfer
+const TransferGas = 21000
+
+// Policy bounds what the hot wallet holds
+type Policy struct {
+	// Cap is the most a top-up fills the hot wallet to
+	Cap *big.Int
+	// GasReserve stays in the hot wallet on top of what a bid needs
+	GasReserve *big.Int
+	// SweepMin is the smallest excess worth sending back
+	SweepMin *big.Int
+}
+
+// TopUp is how much to send a hot wallet holding balance so it can pay
+// need plus the gas reserve, or zero if it already can
+func (p Policy) TopUp(balance, need *big.Int) (*big.Int, error) {
+	target := new(big.Int).Add(need, p.GasReserve)
+	if balance.Cmp(target) >= 0 {
+		return new(big.Int), nil
+	}
+	if target.Cmp(p.Cap) > 0 {
+		return nil, fmt.Errorf("need %s plus %s gas reserve, above the hot wallet cap of %s", need, p.GasReserve, p.Cap)
+	}
+	return target.Sub(target, balance), nil
+}
+
+// Sweep is how much of balance to send back, keeping the gas reserve and
+// fee for the sweep itself, or zero when that is below SweepMin
+func (p Policy) Sweep(balance, fee *big.Int) *big.Int {
+	excess := new(big.Int).Sub(balance, p.GasReserve)
+	excess.Sub(excess, fee)
+	if excess.Sign() <= 0 || excess.Cmp(p.SweepMin) < 0 {
+		return new(big.Int)
+	}
+	return excess
+}
+
+// Signer signs transfers from one address
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// KeySigner signs with a private key held in memory
+type KeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// LoadKeystore decrypts an encrypted JSON key file
+func LoadKeystore(path, passphrase string) (*KeySigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	key, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+func (s *KeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// External signs through a Clef-compatible external signer, which can
+// hold the key on another host or a hardware wallet and ask a human or a
+// rule file before signing
+type External struct {
+	signer  *external.ExternalSigner
+	account accounts.Account
+}
+
+// NewExternal connects to the signer at endpoint to sign for address
+func NewExternal(endpoint string, address common.Address) (*External, error) {
+	signer, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to external signer: %w", err)
+	}
+	return &External{signer: signer, account: accounts.Account{Address: address}}, nil
+}
+
+func (s *External) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *External) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.signer.SignTx(s.account, tx, chainID)
+}
+
+// Backend is what sending a transfer needs from the chain
+type Backend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// Fees prices a transfer under EIP-1559
+type Fees struct {
+	Tip *big.Int
+	Cap *big.Int
+}
+
+// Max is the most a transfer at these fees can cost
+func (f Fees) Max() *big.Int {
+	return new(big.Int).Mul(f.Cap, big.NewInt(TransferGas))
+}
+
+// SuggestFees tips what the node suggests and caps at twice the base fee
+// plus the tip, which holds through six full blocks
+func SuggestFees(ctx context.Context, b Backend) (Fees, error) {
+	tip, err := b.SuggestGasTipCap(ctx)
+	if err != nil {
+		return Fees{}, fmt.Errorf("failed to suggest gas tip: %w", err)
+	}
+	head, err := b.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return Fees{}, fmt.Errorf("failed to get head: %w", err)
+	}
+	feeCap := new(big.Int).Set(tip)
+	if head.BaseFee != nil {
+		feeCap.Add(feeCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	}
+	return Fees{Tip: tip, Cap: feeCap}, nil
+}
+
+// Transfer sends amount from signer's address to to
+func Transfer(ctx context.Context, b Backend, signer Signer, to common.Address, amount *big.Int, fees Fees) (*types.Transaction, error) {
+	chainID, err := b.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	nonce, err := b.PendingNonceAt(ctx, signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %w", signer.Address().Hex(), err)
+	}
+
+	tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: fees.Tip,
+		GasFeeCap: fees.Cap,
+		Gas:       TransferGas,
+		To:        &to,
+		Value:     amount,
+	}), chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transfer from %s: %w", signer.Address().Hex(), err)
+	}
+	if tx.To() == nil || *tx.To() != to || tx.Value().Cmp(amount) != 0 {
+		return nil, fmt.Errorf("signer returned a different transaction than requested")
+	}
+	if err := b.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send transfer: %w", err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,130 @@
+package treasury
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+func eth(milli int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(milli), big.NewInt(1e15))
+}
+
+func TestPolicy(t *testing.T) {
+	p := Policy{Cap: eth(1000), GasReserve: eth(20), SweepMin: eth(10)}
+
+	tests := []struct {
+		name    string
+		balance int64
+		need    int64
+		want    int64
+		err     bool
+	}{
+		{"funded", 600, 500, 0, false},
+		{"short", 100, 500, 420, false},
+		{"empty", 0, 980, 1000, false},
+		{"over cap", 0, 990, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := p.TopUp(eth(tt.balance), eth(tt.need))
+		if (err != nil) != tt.err {
+			t.Errorf("%s: TopUp() error = %v", tt.name, err)
+			continue
+		}
+		if err == nil && got.Cmp(eth(tt.want)) != 0 {
+			t.Errorf("%s: TopUp() = %s, want %s", tt.name, got, eth(tt.want))
+		}
+	}
+
+	if got := p.Sweep(eth(500), eth(1)); got.Cmp(eth(479)) != 0 {
+		t.Errorf("Sweep() = %s, want %s", got, eth(479))
+	}
+	if got := p.Sweep(eth(30), eth(1)); got.Sign() != 0 {
+		t.Errorf("Sweep() of dust = %s, want 0", got)
+	}
+	if got := p.Sweep(eth(10), eth(1)); got.Sign() != 0 {
+		t.Errorf("Sweep() below the reserve = %s, want 0", got)
+	}
+}
+
+// fakeBackend records the transactions sent through it
+type fakeBackend struct {
+	nonce uint64
+	sent  []*types.Transaction
+}
+
+func (f *fakeBackend) ChainID(context.Context) (*big.Int, error) { return big.NewInt(31337), nil }
+func (f *fakeBackend) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return f.nonce, nil
+}
+func (f *fakeBackend) SuggestGasTipCap(context.Context) (*big.Int, error) { return big.NewInt(2), nil }
+func (f *fakeBackend) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{BaseFee: big.NewInt(10)}, nil
+}
+func (f *fakeBackend) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+func TestTransfer(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := NewKeySigner(key)
+	b := &fakeBackend{nonce: 7}
+	ctx := context.Background()
+
+	fees, err := SuggestFees(ctx, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fees.Cap.Int64() != 22 || fees.Max().Int64() != 22*TransferGas {
+		t.Errorf("SuggestFees() = %+v", fees)
+	}
+
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tx, err := Transfer(ctx, b, signer, to, eth(5), fees)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.sent) != 1 || tx.Nonce() != 7 || *tx.To() != to || tx.Value().Cmp(eth(5)) != 0 || tx.Gas() != TransferGas {
+		t.Errorf("Transfer() sent %+v", tx)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(31337)), tx)
+	if err != nil || from != signer.Address() {
+		t.Errorf("transfer signed by %s, %v; want %s", from.Hex(), err, signer.Address().Hex())
+	}
+}
+
+func TestLoadKeystore(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	data, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "treasury.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := LoadKeystore(path, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.Address() != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("LoadKeystore() address = %s", signer.Address().Hex())
+	}
+	if _, err := LoadKeystore(path, "wrong"); err == nil {
+		t.Error("LoadKeystore() accepted a wrong passphrase")
+	}
+}
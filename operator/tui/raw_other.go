@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package tui
+
+import "errors"
+
+func makeRaw(fd int) (func() error, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
+
+func size(fd int) (int, int, error) {
+	return 0, 0, errors.New("terminal size is not supported on this platform")
+}
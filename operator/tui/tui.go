@@ -0,0 +1,144 @@
+// Package tui draws a full-screen text view and reads keystrokes one at a
+// time. It speaks plain ANSI escapes and switches the terminal to raw mode
+// itself, so the operator needs no UI framework.
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Key is one keystroke
+type Key rune
+
+// Keys with no printable rune
+const (
+	KeyEnter     Key = '\r'
+	KeyEsc       Key = 0x1b
+	KeyBackspace Key = 0x7f
+)
+
+// Terminal owns the screen while a view is shown
+type Terminal struct {
+	in      *os.File
+	out     io.Writer
+	restore func() error
+}
+
+// Open switches in to raw mode and out to the alternate screen. Close
+// puts both back.
+func Open(in *os.File, out io.Writer) (*Terminal, error) {
+	restore, err := makeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to put terminal in raw mode: %w", err)
+	}
+	// Alternate screen, cursor hidden
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	return &Terminal{in: in, out: out, restore: restore}, nil
+}
+
+// Close restores the screen and terminal mode Open found
+func (t *Terminal) Close() error {
+	fmt.Fprint(t.out, "\x1b[?25h\x1b[?1049l")
+	return t.restore()
+}
+
+// Draw replaces the screen with lines, clipped to the terminal's size
+func (t *Terminal) Draw(lines []string) error {
+	width, height, err := size(int(t.in.Fd()))
+	if err != nil {
+		width, height = 80, 24
+	}
+	_, err = io.WriteString(t.out, frame(lines, width, height))
+	return err
+}
+
+// frame renders lines as one write that homes the cursor, overwrites each
+// row and clears what the previous frame left below
+func frame(lines []string, width, height int) string {
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(clip(line, width))
+		b.WriteString("\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	return b.String()
+}
+
+// clip cuts s to width runes
+func clip(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width])
+}
+
+// Keys reads keystrokes until the input closes. Newlines arrive as
+// KeyEnter and backspace as KeyBackspace whichever byte the terminal sends.
+func (t *Terminal) Keys() <-chan Key {
+	keys := make(chan Key)
+	go func() {
+		defer close(keys)
+		r := bufio.NewReader(t.in)
+		for {
+			c, _, err := r.ReadRune()
+			if err != nil {
+				return
+			}
+			keys <- decode(c)
+		}
+	}()
+	return keys
+}
+
+func decode(c rune) Key {
+	switch c {
+	case '\n':
+		return KeyEnter
+	case '\b':
+		return KeyBackspace
+	}
+	return Key(c)
+}
+
+// Prompt edits one line of input a keystroke at a time
+type Prompt struct {
+	Label string
+	input []rune
+}
+
+// Handle applies a keystroke. done is set by Enter, with the line typed;
+// cancelled by Esc.
+func (p *Prompt) Handle(k Key) (line string, done, cancelled bool) {
+	switch k {
+	case KeyEnter:
+		return strings.TrimSpace(string(p.input)), true, false
+	case KeyEsc:
+		return "", false, true
+	case KeyBackspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+	default:
+		if k >= ' ' {
+			p.input = append(p.input, rune(k))
+		}
+	}
+	return "", false, false
+}
+
+func (p *Prompt) String() string {
+	return p.Label + string(p.input) + "_"
+}
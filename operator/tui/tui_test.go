@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrompt(t *testing.T) {
+	p := &Prompt{Label: "Rent: "}
+	for _, k := range "0.1ex" {
+		p.Handle(Key(k))
+	}
+	p.Handle(KeyBackspace)
+	p.Handle(Key('t'))
+	p.Handle(Key('h'))
+	if got := p.String(); got != "Rent: 0.1eth_" {
+		t.Errorf("String() = %q", got)
+	}
+
+	line, done, cancelled := p.Handle(decode('\n'))
+	if !done || cancelled || line != "0.1eth" {
+		t.Errorf("Handle(Enter) = %q, %v, %v", line, done, cancelled)
+	}
+	if _, done, cancelled := p.Handle(KeyEsc); done || !cancelled {
+		t.Error("Handle(Esc) did not cancel")
+	}
+}
+
+func TestFrame(t *testing.T) {
+	out := frame([]string{"héllo world", "second", "third"}, 5, 2)
+	if !strings.HasPrefix(out, "\x1b[H") || !strings.HasSuffix(out, "\x1b[J") {
+		t.Errorf("frame() = %q, want it to home the cursor and clear below", out)
+	}
+	if want := "héllo\x1b[K\r\nsecon\x1b[K"; !strings.Contains(out, want) {
+		t.Errorf("frame() = %q, want rows clipped to %q", out, want)
+	}
+	if strings.Contains(out, "third") {
+		t.Error("frame() drew past the terminal's height")
+	}
+}
@@ -0,0 +1,65 @@
+package txmgr
+
+import (
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Transaction kinds tracked by the operator
+const (
+	KindSubmitBid     = store.KindSubmitBid
+	KindSetSwapFee    = store.KindSetSwapFee
+	KindClaimRent     = store.KindClaimRent
+	KindWithdrawFees  = store.KindWithdrawFees
+	KindTreasuryTopUp = store.KindTreasuryTopUp
+	KindTreasurySweep = store.KindTreasurySweep
+)
+
+// PendingTx is a transaction that has been broadcast but whose receipt
+// has not been observed yet
+type PendingTx = store.PendingTx
+
+// Tracker keeps the set of in-flight transactions in the operator's state
+// store so a restarted operator resumes watching them instead of re-sending.
+type Tracker struct {
+	store *store.Store
+}
+
+// NewTracker creates a tracker backed by st; transactions left over from
+// a previous run are picked up automatically.
+func NewTracker(st *store.Store) *Tracker {
+	return &Tracker{store: st}
+}
+
+// Track records a freshly broadcast transaction and persists immediately,
+// so a crash right after sending cannot lose it.
+func (t *Tracker) Track(tx *PendingTx) error {
+	return t.store.PutPendingTx(tx)
+}
+
+// Resolve drops a transaction once it has been mined or replaced.
+func (t *Tracker) Resolve(hash common.Hash) error {
+	return t.store.DeletePendingTx(hash)
+}
+
+// HasPending reports whether a transaction of the given kind is still in
+// flight for the pool.
+func (t *Tracker) HasPending(kind string, poolId common.Hash) bool {
+	for _, tx := range t.store.PendingTxs() {
+		if tx.Kind == kind && tx.PoolId == poolId {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the in-flight transactions ordered by nonce.
+func (t *Tracker) List() []*PendingTx {
+	return t.store.PendingTxs()
+}
+
+// Save writes the in-flight set to disk.
+func (t *Tracker) Save() error {
+	return t.store.Flush()
+}
@@ -0,0 +1,63 @@
+package txmgr
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"auction-pool/operator/store"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func openTracker(t *testing.T, path string) *Tracker {
+	t.Helper()
+
+	st, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("store.Open failed: %v", err)
+	}
+	return NewTracker(st)
+}
+
+func TestTrackerSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending.json")
+	poolId := common.HexToHash("0x01")
+
+	tracker := openTracker(t, path)
+
+	bid := &PendingTx{
+		Hash:   common.HexToHash("0xaa"),
+		Kind:   KindSubmitBid,
+		PoolId: poolId,
+		Nonce:  7,
+		Value:  big.NewInt(1e15),
+		SentAt: time.Unix(1700000000, 0).UTC(),
+	}
+	if err := tracker.Track(bid); err != nil {
+		t.Fatalf("Track failed: %v", err)
+	}
+
+	tracker.store.Close()
+	restarted := openTracker(t, path)
+	if !restarted.HasPending(KindSubmitBid, poolId) {
+		t.Fatalf("expected pending bid after restart")
+	}
+	if restarted.HasPending(KindSetSwapFee, poolId) {
+		t.Errorf("unexpected pending fee update")
+	}
+
+	got := restarted.List()
+	if len(got) != 1 || got[0].Nonce != 7 || got[0].Value.Cmp(bid.Value) != 0 {
+		t.Fatalf("unexpected pending set after restart: %+v", got)
+	}
+
+	if err := restarted.Resolve(bid.Hash); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	restarted.store.Close()
+	if len(openTracker(t, path).List()) != 0 {
+		t.Errorf("expected no pending transactions after resolve")
+	}
+}
@@ -0,0 +1,218 @@
+// Package units formats and parses the quantities the operator deals in:
+// wei amounts, swap fees in pips and rent per block.
+//
+// Uniswap v4 fees are pips, millionths of the amount swapped: 3000 is
+// 0.30%, and the hook's MAX_FEE of 10000 is 1%. Parsing is exact decimal
+// arithmetic, never floating point, so "0.1eth" is exactly 1e17 wei.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ErrSyntax is wrapped by every parse error
+var ErrSyntax = errors.New("invalid quantity")
+
+// Denominations, in wei
+var (
+	Wei   = big.NewInt(1)
+	Gwei  = big.NewInt(1e9)
+	Ether = big.NewInt(1e18)
+)
+
+// PipsPerUnit is 100% in pips
+const PipsPerUnit = 1_000_000
+
+var denominations = []struct {
+	name     string
+	decimals int
+}{
+	{"eth", 18},
+	{"ether", 18},
+	{"gwei", 9},
+	{"wei", 0},
+}
+
+// ParseWei parses an amount such as "0.5eth", "20 gwei" or "1000wei". A
+// bare number is wei.
+func ParseWei(s string) (*big.Int, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	decimals := 0
+	for _, d := range denominations {
+		if strings.HasSuffix(text, d.name) {
+			text, decimals = strings.TrimSpace(strings.TrimSuffix(text, d.name)), d.decimals
+			break
+		}
+	}
+
+	v, err := parseDecimal(text, decimals)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrSyntax, s, err)
+	}
+	return v, nil
+}
+
+// parseDecimal parses a non-negative decimal scaled by 10^decimals,
+// refusing digits the scale can't hold
+func parseDecimal(s string, decimals int) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return nil, errors.New("no digits")
+	}
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("more than %d decimal places", decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	v, _ := new(big.Int).SetString(digits, 10)
+	return v, nil
+}
+
+// formatDecimal writes v / 10^decimals without trailing zeros
+func formatDecimal(v *big.Int, decimals int) string {
+	neg := v.Sign() < 0
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	out := whole
+	if frac != "" {
+		out += "." + frac
+	}
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+// FormatWei writes an amount exactly in the largest unit that keeps it
+// readable: ETH from 0.000001 ETH, gwei from 0.001 gwei, otherwise wei
+func FormatWei(v *big.Int) string {
+	if v == nil {
+		return "0 wei"
+	}
+	abs := new(big.Int).Abs(v)
+	switch {
+	case abs.Cmp(big.NewInt(1e12)) >= 0:
+		return formatDecimal(v, 18) + " ETH"
+	case abs.Cmp(big.NewInt(1e6)) >= 0:
+		return formatDecimal(v, 9) + " gwei"
+	}
+	return v.String() + " wei"
+}
+
+// FormatEther writes an amount in ETH
+func FormatEther(v *big.Int) string {
+	return formatDecimal(v, 18) + " ETH"
+}
+
+// FormatFee writes a fee in pips as a percentage, e.g. 3000 as "0.3%"
+func FormatFee(pips uint64) string {
+	return formatDecimal(new(big.Int).SetUint64(pips), 4) + "%"
+}
+
+// ParseFee parses a fee as a percentage ("0.3%"), basis points ("30bps")
+// or pips ("3000pips", or a bare integer)
+func ParseFee(s string) (uint64, error) {
+	text := strings.ToLower(strings.TrimSpace(s))
+
+	var (
+		v   *big.Int
+		err error
+	)
+	switch {
+	case strings.HasSuffix(text, "%"):
+		v, err = parseDecimal(strings.TrimSpace(strings.TrimSuffix(text, "%")), 4)
+	case strings.HasSuffix(text, "bps"):
+		v, err = parseDecimal(strings.TrimSpace(strings.TrimSuffix(text, "bps")), 2)
+	default:
+		v, err = parseDecimal(strings.TrimSpace(strings.TrimSuffix(text, "pips")), 0)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w fee %q: %v", ErrSyntax, s, err)
+	}
+	if v.Cmp(big.NewInt(PipsPerUnit)) > 0 {
+		return 0, fmt.Errorf("%w fee %q: above 100%%", ErrSyntax, s)
+	}
+	return v.Uint64(), nil
+}
+
+// Rent converts rent between per-block and per-period amounts at a chain's
+// block time
+type Rent struct {
+	BlockTime time.Duration
+}
+
+// perBlocks is how many blocks a period spans, as a fraction
+func (r Rent) perBlocks(period time.Duration) (num, den *big.Int) {
+	return big.NewInt(int64(period)), big.NewInt(int64(r.BlockTime))
+}
+
+// Per converts rent per block to rent per period
+func (r Rent) Per(perBlock *big.Int, period time.Duration) *big.Int {
+	num, den := r.perBlocks(period)
+	out := new(big.Int).Mul(perBlock, num)
+	return out.Quo(out, den)
+}
+
+// PerBlock converts rent per period to rent per block, rounding down
+func (r Rent) PerBlock(amount *big.Int, period time.Duration) *big.Int {
+	num, den := r.perBlocks(period)
+	out := new(big.Int).Mul(amount, den)
+	return out.Quo(out, num)
+}
+
+var periods = map[string]time.Duration{
+	"block": 0,
+	"hour":  time.Hour,
+	"h":     time.Hour,
+	"day":   24 * time.Hour,
+	"d":     24 * time.Hour,
+}
+
+// Parse parses rent such as "0.0001eth/block", "0.5 eth/day" or "2gwei"
+// (per block) and returns wei per block
+func (r Rent) Parse(s string) (*big.Int, error) {
+	amount, unit, found := strings.Cut(s, "/")
+	v, err := ParseWei(amount)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return v, nil
+	}
+
+	period, ok := periods[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		return nil, fmt.Errorf("%w rent %q: unknown period %q (want block, hour or day)", ErrSyntax, s, unit)
+	}
+	if period == 0 {
+		return v, nil
+	}
+	if r.BlockTime <= 0 {
+		return nil, fmt.Errorf("%w rent %q: per-%s rent needs a block time", ErrSyntax, s, unit)
+	}
+	return r.PerBlock(v, period), nil
+}
+
+// Format writes rent per block with its daily equivalent
+func (r Rent) Format(perBlock *big.Int) string {
+	if perBlock == nil {
+		perBlock = new(big.Int)
+	}
+	out := FormatWei(perBlock) + "/block"
+	if r.BlockTime > 0 {
+		out += fmt.Sprintf(" (%s/day)", FormatWei(r.Per(perBlock, 24*time.Hour)))
+	}
+	return out
+}
@@ -0,0 +1,125 @@
+package units
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestParseWei(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0.0001eth", "100000000000000"},
+		{"1.5 ETH", "1500000000000000000"},
+		{"2ether", "2000000000000000000"},
+		{"20 gwei", "20000000000"},
+		{"0.5gwei", "500000000"},
+		{"1000wei", "1000"},
+		{"1000", "1000"},
+		{".5eth", "500000000000000000"},
+	}
+	for _, tt := range tests {
+		got, err := ParseWei(tt.in)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("ParseWei(%q) = %v, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "eth", "-1eth", "1.5wei", "0.0000000001gwei", "1e18", "1,000", "0x10", "1 btc"} {
+		if _, err := ParseWei(in); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseWei(%q) = %v, want ErrSyntax", in, err)
+		}
+	}
+}
+
+func TestFormatWei(t *testing.T) {
+	tests := []struct {
+		in   *big.Int
+		want string
+	}{
+		{big.NewInt(2e15), "0.002 ETH"},
+		{new(big.Int).Mul(big.NewInt(3), Ether), "3 ETH"},
+		{big.NewInt(1_500_000_000), "1.5 gwei"},
+		{big.NewInt(-2e15), "-0.002 ETH"},
+		{big.NewInt(100), "100 wei"},
+		{nil, "0 wei"},
+	}
+	for _, tt := range tests {
+		if got := FormatWei(tt.in); got != tt.want {
+			t.Errorf("FormatWei(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := FormatEther(big.NewInt(1)); got != "0.000000000000000001 ETH" {
+		t.Errorf("FormatEther(1) = %q", got)
+	}
+}
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		in   string
+		pips uint64
+	}{
+		{"0.3%", 3000},
+		{"1%", 10000},
+		{"0.0001%", 1},
+		{"30bps", 3000},
+		{"0.5 bps", 50},
+		{"3000pips", 3000},
+		{"3000", 3000},
+	}
+	for _, tt := range tests {
+		got, err := ParseFee(tt.in)
+		if err != nil || got != tt.pips {
+			t.Errorf("ParseFee(%q) = %d, %v, want %d", tt.in, got, err, tt.pips)
+		}
+	}
+	for _, in := range []string{"0.00001%", "101%", "1.5", "fee"} {
+		if _, err := ParseFee(in); !errors.Is(err, ErrSyntax) {
+			t.Errorf("ParseFee(%q) = %v, want ErrSyntax", in, err)
+		}
+	}
+
+	for pips, want := range map[uint64]string{3000: "0.3%", 10000: "1%", 1: "0.0001%", 0: "0%"} {
+		if got := FormatFee(pips); got != want {
+			t.Errorf("FormatFee(%d) = %q, want %q", pips, got, want)
+		}
+	}
+}
+
+func TestRent(t *testing.T) {
+	r := Rent{BlockTime: 12 * time.Second}
+
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0.0001eth/block", 1e14},
+		{"2gwei", 2e9},
+		// 300 blocks an hour
+		{"0.03eth/hour", 1e14},
+		{"0.72 ETH / day", 1e14},
+		{"1wei/day", 0},
+	}
+	for _, tt := range tests {
+		got, err := r.Parse(tt.in)
+		if err != nil || got.Int64() != tt.want {
+			t.Errorf("Parse(%q) = %v, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := r.Parse("1eth/week"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Parse(per week) = %v, want ErrSyntax", err)
+	}
+	if _, err := (Rent{}).Parse("1eth/day"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Parse without a block time = %v, want ErrSyntax", err)
+	}
+
+	if got := r.Per(big.NewInt(1e14), time.Hour); got.Int64() != 3e16 {
+		t.Errorf("Per(hour) = %s", got)
+	}
+	if got := r.Format(big.NewInt(1e14)); got != "0.0001 ETH/block (0.72 ETH/day)" {
+		t.Errorf("Format() = %q", got)
+	}
+}
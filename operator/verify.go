@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"auction-pool/operator/abicheck"
+	"auction-pool/operator/contracts"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// verifyHook checks the contract deployed at hook is the one the bindings
+// describe before the operator acts on it, returning its code hash.
+// codeHash, if set, pins the exact deployment. artifactPath, if set, names
+// the forge artifact of the expected build, whose ABI and metadata must
+// match too.
+func verifyHook(ctx context.Context, caller bind.ContractCaller, hook common.Address, codeHash, artifactPath string) (common.Hash, error) {
+	code, err := caller.CodeAt(ctx, hook, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get hook code: %w", err)
+	}
+
+	hash := crypto.Keccak256Hash(code)
+	if codeHash != "" && common.HexToHash(codeHash) != hash {
+		return hash, fmt.Errorf("%w: hook code hash %s, expected %s", abicheck.ErrDrift, hash.Hex(), codeHash)
+	}
+
+	binding, err := abicheck.FromMetaData("operator/contracts", contracts.AuctionPoolHookMetaData)
+	if err != nil {
+		return hash, err
+	}
+	drifts := abicheck.CheckCode(code, binding.ABI)
+
+	if artifactPath != "" {
+		art, err := abicheck.LoadArtifact(artifactPath)
+		if err != nil {
+			return hash, err
+		}
+		drifts = append(drifts, abicheck.Compare(art.ABI, binding)...)
+		drifts = append(drifts, abicheck.CompareMetadata(art.Code, code)...)
+	}
+	return hash, abicheck.Err(drifts)
+}
@@ -31,7 +31,7 @@ contract AuctionPoolHook is BaseHook {
         uint256 rentPerBlock;        // Current rent rate (wei per block)
         uint256 managerDeposit;      // Manager's remaining deposit
         uint256 lastRentBlock;       // Last block rent was collected
-        uint24 currentFee;           // Current swap fee (in hundredths of bps)
+        uint24 currentFee;           // Current swap fee in pips (1e-6, 3000 = 0.30%)
         uint256 totalRentPaid;       // Cumulative rent paid (for stats)
     }
 
@@ -43,9 +43,9 @@ contract AuctionPoolHook is BaseHook {
         uint256 timestamp;           // When bid was submitted
     }
 
-    uint24 public constant MAX_FEE = 10000;           // 1% max fee (in hundredths of bps)
+    uint24 public constant MAX_FEE = 10000;           // 1% max fee (pips)
     uint256 public constant ACTIVATION_DELAY = 5;      // blocks
-    uint24 public constant WITHDRAWAL_FEE = 1;         // 0.01% (in hundredths of bps)
+    uint24 public constant WITHDRAWAL_FEE = 1;         // 0.0001% of the liquidity removed (pips)
     uint256 public constant MIN_BID_INCREMENT = 100;   // 100 wei/block minimum increase
     uint256 public constant MIN_DEPOSIT_BLOCKS = 100;  // Deposit must cover 100 blocks
 